@@ -0,0 +1,9 @@
+# An asset created by an ingestion plugin, looked up by MRN.
+data "marmot_asset" "orders_table" {
+  mrn = "mrn://table/snowflake/analytics.public.orders"
+}
+
+resource "marmot_lineage" "orders_to_processor" {
+  source = data.marmot_asset.orders_table.mrn
+  target = marmot_asset.order_processor.mrn
+}
@@ -0,0 +1,17 @@
+# Every production table must be tagged restricted and name an owner and a
+# classification.
+data "marmot_compliance_report" "tables" {
+  types = ["Table"]
+  tags  = ["production"]
+
+  required_tags          = ["restricted"]
+  required_metadata_keys = ["owner", "classification"]
+
+  # Fail the plan when any asset regresses.
+  lifecycle {
+    postcondition {
+      condition     = self.compliant
+      error_message = "Non-compliant assets: ${join(", ", [for a in self.non_compliant : a.mrn])}"
+    }
+  }
+}
@@ -0,0 +1,37 @@
+data "marmot_dbt_manifest" "shop" {
+  path = "${path.module}/dbt/target/manifest.json"
+}
+
+locals {
+  dbt_nodes = merge(
+    { for id, m in data.marmot_dbt_manifest.shop.models : id => {
+      name        = m.alias
+      description = m.description
+      type        = m.materialization == "view" ? "view" : "table"
+      tags        = m.tags
+    } },
+    { for id, s in data.marmot_dbt_manifest.shop.sources : id => {
+      name        = s.identifier
+      description = s.description
+      type        = "table"
+      tags        = s.tags
+    } },
+  )
+}
+
+resource "marmot_asset" "dbt" {
+  for_each = local.dbt_nodes
+
+  name        = each.value.name
+  type        = each.value.type
+  description = each.value.description
+  services    = ["dbt"]
+  tags        = each.value.tags
+}
+
+resource "marmot_lineage" "dbt" {
+  for_each = { for e in data.marmot_dbt_manifest.shop.edges : "${e.source}>${e.target}" => e }
+
+  source = marmot_asset.dbt[each.value.source].mrn
+  target = marmot_asset.dbt[each.value.target].mrn
+}
@@ -0,0 +1,8 @@
+# A term mentioned in documentation as "Finance / ARR", resolved by its slug.
+data "marmot_glossary_term" "arr" {
+  slug = "finance/arr"
+}
+
+output "arr_definition" {
+  value = data.marmot_glossary_term.arr.definition
+}
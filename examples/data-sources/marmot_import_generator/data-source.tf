@@ -0,0 +1,17 @@
+# Generate import blocks and skeleton resources for every Kafka topic, then
+# write them out with:
+#   terraform output -raw kafka_imports > imports.tf
+#   terraform output -raw kafka_config > assets.tf
+data "marmot_import_generator" "kafka" {
+  types    = ["Topic"]
+  services = ["Kafka"]
+  limit    = 500
+}
+
+output "kafka_imports" {
+  value = data.marmot_import_generator.kafka.import_blocks
+}
+
+output "kafka_config" {
+  value = data.marmot_import_generator.kafka.config
+}
@@ -0,0 +1,12 @@
+# Fail the plan unless the revenue mart is fed from the approved orders table.
+data "marmot_lineage_path" "revenue_from_orders" {
+  source = "mrn://table/postgresql/orders"
+  target = "mrn://table/snowflake/revenue_mart"
+
+  lifecycle {
+    postcondition {
+      condition     = self.exists
+      error_message = "revenue_mart has no lineage from the orders table."
+    }
+  }
+}
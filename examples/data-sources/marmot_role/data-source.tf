@@ -0,0 +1,13 @@
+# Built-in roles can be looked up by name and granted like any other.
+data "marmot_role" "admin" {
+  name = "admin"
+}
+
+resource "marmot_role_assignment" "alice_admin" {
+  user_id   = data.marmot_user.alice.id
+  role_name = data.marmot_role.admin.name
+}
+
+data "marmot_user" "alice" {
+  username = "alice"
+}
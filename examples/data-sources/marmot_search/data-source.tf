@@ -0,0 +1,16 @@
+# Every asset that looks like it holds personal data.
+data "marmot_search" "pii" {
+  query = "pii"
+  types = ["Table", "Topic"]
+  limit = 500
+}
+
+# Fail the plan if any of them is missing the restricted tag.
+check "pii_is_restricted" {
+  assert {
+    condition = alltrue([
+      for asset in data.marmot_search.pii.results : contains(asset.tags, "restricted")
+    ])
+    error_message = "Assets matching 'pii' must carry the 'restricted' tag."
+  }
+}
@@ -0,0 +1,19 @@
+# Scorecard for the analytics team's assets.
+data "marmot_team_ownership_report" "analytics" {
+  team_id = marmot_team.analytics.id
+}
+
+output "analytics_assets_by_type" {
+  value = data.marmot_team_ownership_report.analytics.asset_counts_by_type
+}
+
+# Warn when more than a tenth of the team's assets lack documentation.
+check "analytics_documentation" {
+  assert {
+    condition = (
+      data.marmot_team_ownership_report.analytics.assets_missing_documentation * 10
+      <= data.marmot_team_ownership_report.analytics.asset_count
+    )
+    error_message = "Over 10% of analytics assets have no documentation page."
+  }
+}
@@ -0,0 +1,18 @@
+# Look up a user by username...
+data "marmot_user" "alice" {
+  username = "alice"
+}
+
+# ...or by an email address from one of their linked identities.
+data "marmot_user" "bob" {
+  email = "bob@example.com"
+}
+
+resource "marmot_data_product" "reporting" {
+  name = "reporting"
+
+  owner_user_ids = [
+    data.marmot_user.alice.id,
+    data.marmot_user.bob.id,
+  ]
+}
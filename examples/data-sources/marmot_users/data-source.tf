@@ -0,0 +1,11 @@
+# All active users whose username or name matches "data".
+data "marmot_users" "data_team" {
+  query  = "data"
+  active = true
+}
+
+resource "marmot_data_product" "warehouse" {
+  name = "warehouse"
+
+  owner_user_ids = data.marmot_users.data_team.ids
+}
@@ -0,0 +1,7 @@
+resource "marmot_asset" "orders_bucket" {
+  name = "orders-archive"
+  type = "bucket"
+
+  # "AWS S3", "Amazon S3", and "s3" all become "s3".
+  services = [provider::marmot::canonical_service(var.service)]
+}
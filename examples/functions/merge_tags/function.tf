@@ -0,0 +1,7 @@
+resource "marmot_asset" "orders_table" {
+  name     = "orders"
+  type     = "table"
+  services = ["snowflake"]
+
+  tags = provider::marmot::merge_tags(var.team_tags, var.domain_tags, ["Orders"])
+}
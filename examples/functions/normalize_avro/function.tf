@@ -0,0 +1,9 @@
+resource "marmot_asset" "orders_topic" {
+  name     = "orders"
+  type     = "topic"
+  services = ["kafka"]
+
+  schema = {
+    value = provider::marmot::normalize_avro(file("${path.module}/schemas/order.avsc"))
+  }
+}
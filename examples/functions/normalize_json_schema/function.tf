@@ -0,0 +1,9 @@
+resource "marmot_asset" "orders_api" {
+  name     = "orders-api"
+  type     = "api"
+  services = ["http"]
+
+  schema = {
+    request = provider::marmot::normalize_json_schema(file("${path.module}/schemas/order.schema.json"))
+  }
+}
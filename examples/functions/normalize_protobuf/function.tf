@@ -0,0 +1,9 @@
+resource "marmot_asset" "orders_service" {
+  name     = "orders-service"
+  type     = "service"
+  services = ["grpc"]
+
+  schema = {
+    proto = provider::marmot::normalize_protobuf(file("${path.module}/proto/orders.proto"))
+  }
+}
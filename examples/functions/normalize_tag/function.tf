@@ -0,0 +1,7 @@
+resource "marmot_asset" "orders_table" {
+  name     = "orders"
+  type     = "table"
+  services = ["snowflake"]
+
+  tags = [for tag in var.tags : provider::marmot::normalize_tag(tag)]
+}
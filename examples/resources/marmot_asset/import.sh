@@ -1,2 +1,3 @@
-# Assets are imported by their ID.
+# Assets are imported by their ID or their MRN.
 terraform import marmot_asset.example 018e1234-5678-7abc-def0-123456789abc
+terraform import marmot_asset.example mrn://table/postgresql/orders
@@ -0,0 +1,15 @@
+# Document the columns of a table that ingestion created, leaving the column
+# list, types, and the rest of the asset to the scanner.
+resource "marmot_asset_columns" "orders" {
+  asset_mrn = "mrn://table/postgresql/orders"
+
+  columns = {
+    id = {
+      description = "Order ID, assigned at checkout."
+    }
+    customer_email = {
+      description = "Email the order confirmation was sent to."
+      tags        = ["pii"]
+    }
+  }
+}
@@ -0,0 +1,10 @@
+# Stamp ownership metadata onto a table that ingestion created, without
+# taking over the rest of the asset.
+resource "marmot_asset_metadata" "orders_cost_center" {
+  asset_mrn = "mrn://table/postgresql/orders"
+
+  metadata = {
+    cost_center  = "cc-1042"
+    data_steward = "platform-team"
+  }
+}
@@ -0,0 +1,6 @@
+# Each team tags the shared orders table from its own configuration;
+# tags added elsewhere are left alone.
+resource "marmot_asset_tags" "orders_finance" {
+  asset_mrn = "mrn://table/postgresql/orders"
+  tags      = ["finance", "sox"]
+}
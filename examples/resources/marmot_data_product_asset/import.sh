@@ -1,2 +1,4 @@
-# Memberships are imported with the composite ID "<data_product_id>/<asset_id>".
+# Memberships are imported with the composite ID "<data_product_id>/<asset>",
+# where the asset is given by its ID or its MRN.
 terraform import marmot_data_product_asset.example 018e1234-5678-7abc-def0-123456789abc/018eabcd-1234-7def-8901-23456789abcd
+terraform import marmot_data_product_asset.example 018e1234-5678-7abc-def0-123456789abc/mrn://table/postgresql/orders
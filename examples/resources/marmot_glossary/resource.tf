@@ -0,0 +1,26 @@
+# The whole glossary tree, kept in one YAML file next to the configuration.
+resource "marmot_glossary" "company" {
+  document = file("${path.module}/glossary.yaml")
+}
+
+# The same document inline. A term with a key keeps its ID when renamed or
+# moved to another parent.
+resource "marmot_glossary" "finance" {
+  document = <<-EOT
+    - name: Finance
+      definition: Terms used in financial reporting.
+      children:
+        - name: ARR
+          key: arr
+          definition: Annual recurring revenue from active subscriptions.
+          metadata:
+            owner: finance-analytics
+  EOT
+}
+
+# A CSV document, such as a spreadsheet export. Paths join names from the
+# root with "/", and parents may appear after their children.
+resource "marmot_glossary" "from_spreadsheet" {
+  format   = "csv"
+  document = file("${path.module}/glossary.csv")
+}
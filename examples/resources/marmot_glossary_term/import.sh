@@ -1,2 +1,5 @@
 # Glossary terms are imported by their ID.
 terraform import marmot_glossary_term.active_customer 018e1234-5678-7abc-def0-123456789abc
+
+# Or by the path of term names from the root of the hierarchy.
+terraform import marmot_glossary_term.arr "Finance/Revenue/ARR"
@@ -0,0 +1,4 @@
+# Topic assets are imported by their asset ID or MRN, including topics that
+# Kafka ingestion registered first.
+terraform import marmot_kafka_topic_asset.example 018e1234-5678-7abc-def0-123456789abc
+terraform import marmot_kafka_topic_asset.example mrn://topic/kafka/orders
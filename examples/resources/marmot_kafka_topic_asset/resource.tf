@@ -0,0 +1,16 @@
+# Registers the topic the same way Kafka ingestion would, so it looks
+# identical to scanned topics in the catalog.
+resource "marmot_kafka_topic_asset" "orders_created" {
+  name               = "orders.created"
+  cluster            = "prod-eu"
+  partitions         = 12
+  replication_factor = 3
+
+  key_schema_type   = "AVRO"
+  key_schema        = file("${path.module}/schemas/order-key.avsc")
+  value_schema_type = "AVRO"
+  value_schema      = file("${path.module}/schemas/order.avsc")
+
+  description = "Emitted once an order has been placed and paid for."
+  tags        = ["orders", "events"]
+}
@@ -1,2 +1,4 @@
-# Lineage edges are imported by their ID.
+# Lineage edges are imported by their ID, or by their ends as
+# "<source>-><target>", each an MRN or an asset ID.
 terraform import marmot_lineage.example 018e1234-5678-7abc-def0-123456789abc
+terraform import marmot_lineage.example 'mrn://table/postgresql/orders->mrn://dashboard/looker/revenue'
@@ -3,6 +3,21 @@ resource "marmot_lineage" "example" {
   target = marmot_asset.target.mrn
 }
 
+# Either end can also reference an asset by ID; it is resolved to the
+# asset's MRN on create.
+resource "marmot_lineage" "by_id" {
+  source_asset_id = marmot_asset.source.id
+  target          = "mrn://dashboard/looker/revenue"
+}
+
+# A temporary edge, such as one for a backfill, is deleted by the first
+# apply after expires_at.
+resource "marmot_lineage" "backfill" {
+  source     = "mrn://table/postgresql/orders_archive"
+  target     = marmot_asset.target.mrn
+  expires_at = "2026-01-31T18:00:00Z"
+}
+
 resource "marmot_asset" "source" {
   name     = "source-asset"
   type     = "dataset"
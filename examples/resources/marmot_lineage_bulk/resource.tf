@@ -0,0 +1,26 @@
+resource "marmot_lineage_bulk" "orders_pipeline" {
+  edges = [
+    {
+      source = "mrn://table/postgresql/raw_orders"
+      target = "mrn://table/snowflake/stg_orders"
+    },
+    {
+      source = "mrn://table/snowflake/stg_orders"
+      target = "mrn://table/snowflake/fct_orders"
+    },
+    {
+      source = "mrn://table/snowflake/fct_orders"
+      target = "mrn://dashboard/looker/revenue"
+    },
+  ]
+}
+
+# A whole dbt DAG as one resource.
+resource "marmot_lineage_bulk" "dbt" {
+  edges = [
+    for e in data.marmot_dbt_manifest.shop.edges : {
+      source = marmot_asset.dbt[e.source].mrn
+      target = marmot_asset.dbt[e.target].mrn
+    }
+  ]
+}
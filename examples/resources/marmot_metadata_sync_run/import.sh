@@ -0,0 +1,2 @@
+# Runs are imported by their run ID.
+terraform import marmot_metadata_sync_run.orders_db 018e1234-5678-7abc-def0-123456789abc
@@ -0,0 +1,24 @@
+resource "marmot_pipeline" "orders_db" {
+  name      = "orders-db"
+  plugin_id = "postgresql"
+
+  config = jsonencode({
+    host     = aws_db_instance.orders.address
+    database = "orders"
+  })
+
+  cron_expression = "0 3 * * *"
+}
+
+# Catalog the database as soon as it exists, and again whenever it is
+# replaced, instead of waiting for the nightly run.
+resource "marmot_metadata_sync_run" "orders_db" {
+  pipeline_id = marmot_pipeline.orders_db.id
+
+  triggers = {
+    database_id = aws_db_instance.orders.id
+  }
+
+  wait_for_completion = true
+  timeout             = "15m"
+}
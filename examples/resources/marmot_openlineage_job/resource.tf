@@ -0,0 +1,21 @@
+# Publish lineage exported from Airflow or Spark as-is.
+resource "marmot_openlineage_job" "exported" {
+  event = file("${path.module}/openlineage/daily_orders.json")
+}
+
+# Or build the event in HCL. eventType, eventTime, run.runId, producer, and
+# schemaURL are filled in when left out.
+resource "marmot_openlineage_job" "daily_orders" {
+  event = jsonencode({
+    job = {
+      namespace = "airflow"
+      name      = "daily_orders"
+    }
+    inputs = [
+      { namespace = "postgres://db.internal:5432", name = "shop.public.orders" },
+    ]
+    outputs = [
+      { namespace = "snowflake://acme", name = "analytics.daily_orders" },
+    ]
+  })
+}
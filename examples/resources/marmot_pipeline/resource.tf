@@ -9,4 +9,24 @@ resource "marmot_pipeline" "bigquery_analytics" {
 
   cron_expression = "0 */6 * * *" # every six hours
   enabled         = true
-}
\ No newline at end of file
+}
+# Credentials go in sensitive_config, which is merged into config when sent
+# but never stored in state. Bump the version to push a rotated password.
+resource "marmot_pipeline" "orders_db" {
+  name      = "orders-db"
+  plugin_id = "postgresql"
+
+  config = jsonencode({
+    host     = "orders.db.internal"
+    port     = 5432
+    user     = "marmot"
+    database = "orders"
+  })
+
+  sensitive_config = {
+    password = var.orders_db_password
+  }
+  sensitive_config_version = "1"
+
+  cron_expression = "0 3 * * *"
+}
@@ -0,0 +1,4 @@
+# Table assets are imported by their asset ID or MRN, including tables that
+# the PostgreSQL scanner registered first.
+terraform import marmot_postgres_table_asset.example 018e1234-5678-7abc-def0-123456789abc
+terraform import marmot_postgres_table_asset.example mrn://table/postgresql/shop.public.orders
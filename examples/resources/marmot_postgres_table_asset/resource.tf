@@ -0,0 +1,29 @@
+# Pre-registers the table a migration creates, named the way the
+# PostgreSQL scanner will find it.
+resource "marmot_postgres_table_asset" "orders" {
+  database = "shop"
+  schema   = "public"
+  table    = "orders"
+
+  columns = [
+    {
+      name        = "id"
+      data_type   = "bigint"
+      nullable    = false
+      primary_key = true
+    },
+    {
+      name      = "customer_id"
+      data_type = "bigint"
+      nullable  = false
+    },
+    {
+      name        = "placed_at"
+      data_type   = "timestamp with time zone"
+      description = "When the customer placed the order"
+    },
+  ]
+
+  description = "One row per order placed in the shop."
+  tags        = ["orders"]
+}
@@ -0,0 +1,2 @@
+# Roles are imported by their ID.
+terraform import marmot_role.catalog_editor 018e1234-5678-7abc-def0-123456789abc
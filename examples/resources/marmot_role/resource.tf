@@ -0,0 +1,11 @@
+resource "marmot_role" "catalog_editor" {
+  name        = "catalog-editor"
+  description = "Can browse and edit catalog assets and glossary terms"
+
+  permissions = [
+    "assets:read",
+    "assets:write",
+    "glossary:read",
+    "glossary:write",
+  ]
+}
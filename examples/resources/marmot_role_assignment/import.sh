@@ -0,0 +1,2 @@
+# Role assignments are imported with the composite ID "<user_id>/<role_name>".
+terraform import marmot_role_assignment.alice_editor 018e1234-5678-7abc-def0-123456789abc/catalog-editor
@@ -0,0 +1,14 @@
+resource "marmot_role_assignment" "alice_editor" {
+  user_id   = marmot_user.alice.id
+  role_name = marmot_role.catalog_editor.name
+}
+
+resource "marmot_role" "catalog_editor" {
+  name        = "catalog-editor"
+  permissions = ["assets:read", "assets:write"]
+}
+
+resource "marmot_user" "alice" {
+  name     = "Alice Nguyen"
+  username = "alice"
+}
@@ -0,0 +1,4 @@
+# Bucket assets are imported by their asset ID or MRN, including buckets that
+# S3 ingestion registered first.
+terraform import marmot_s3_bucket_asset.example 018e1234-5678-7abc-def0-123456789abc
+terraform import marmot_s3_bucket_asset.example mrn://bucket/s3/analytics-exports
@@ -0,0 +1,13 @@
+# The module that creates the bucket registers it in the catalog too.
+resource "aws_s3_bucket" "raw" {
+  bucket = "acme-analytics-raw"
+}
+
+resource "marmot_s3_bucket_asset" "raw" {
+  name     = aws_s3_bucket.raw.bucket
+  region   = "eu-west-1"
+  prefixes = ["events/", "exports/"]
+
+  description = "Landing zone for raw event and export files."
+  tags        = ["analytics", "raw"]
+}
@@ -0,0 +1,2 @@
+# SSO team mappings are imported by ID.
+terraform import marmot_sso_team_mapping.example 018e1234-5678-7abc-def0-123456789abc
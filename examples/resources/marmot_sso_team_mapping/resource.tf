@@ -0,0 +1,11 @@
+resource "marmot_team" "data_platform" {
+  name = "data-platform"
+}
+
+# Members of the Okta group "data-platform-engineers" join the team on login.
+resource "marmot_sso_team_mapping" "data_platform" {
+  provider_name  = "okta"
+  sso_group_name = "data-platform-engineers"
+  team_id        = marmot_team.data_platform.id
+  member_role    = "member"
+}
@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package acctest supports the provider's acceptance tests, which create
+// real resources on a Marmot server and only run when TF_ACC is set.
+//
+// `make testacc-up` starts a disposable Marmot in Docker from the
+// docker-compose.yml next to this file, and `make testacc-local` runs the
+// acceptance tests against it. To use another server, set MARMOT_HOST and
+// either MARMOT_API_KEY or MARMOT_TOKEN.
+//
+// The tests themselves live next to the resources they cover, in
+// *_acc_test.go files built with the acceptance tag, and run through
+// terraform-plugin-testing's resource.Test. `make testacc` sets the tag.
+package acctest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+const (
+	// DefaultHost is where docker-compose.yml serves Marmot.
+	DefaultHost = "http://localhost:8080"
+
+	// DefaultUsername and DefaultPassword are the credentials of the admin
+	// user a new Marmot server starts with. PreCheck logs in with them when
+	// no API key or token is set; MARMOT_ACC_USERNAME and
+	// MARMOT_ACC_PASSWORD override them.
+	DefaultUsername = "admin"
+	DefaultPassword = "admin"
+
+	// NamePrefix starts the name of everything the acceptance tests
+	// create, so leftovers from a failed run are easy to find and remove.
+	NamePrefix = "tfacc-"
+
+	// startupTimeout is how long PreCheck waits for a server that was just
+	// started to accept a login.
+	startupTimeout = 2 * time.Minute
+)
+
+var (
+	loginOnce sync.Once
+	loginErr  error
+)
+
+// PreCheck skips t unless TF_ACC is set, and otherwise points the provider
+// at the acceptance test server: MARMOT_HOST defaults to DefaultHost, and
+// without MARMOT_API_KEY or MARMOT_TOKEN it logs in and sets MARMOT_TOKEN.
+// Call it first in every acceptance test.
+func PreCheck(t testing.TB) {
+	t.Helper()
+
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("acceptance tests run only when TF_ACC is set")
+	}
+
+	loginOnce.Do(func() {
+		if os.Getenv("MARMOT_HOST") == "" {
+			loginErr = os.Setenv("MARMOT_HOST", DefaultHost)
+			if loginErr != nil {
+				return
+			}
+		}
+		if os.Getenv("MARMOT_API_KEY") != "" || os.Getenv("MARMOT_TOKEN") != "" {
+			return
+		}
+
+		var token string
+		token, loginErr = login(os.Getenv("MARMOT_HOST"), envOr("MARMOT_ACC_USERNAME", DefaultUsername), envOr("MARMOT_ACC_PASSWORD", DefaultPassword))
+		if loginErr == nil {
+			loginErr = os.Setenv("MARMOT_TOKEN", token)
+		}
+	})
+	if loginErr != nil {
+		t.Fatalf("acceptance test server at %s is not usable: %s", os.Getenv("MARMOT_HOST"), loginErr)
+	}
+}
+
+// Client returns an SDK client for the acceptance test server, for checking
+// what a test created or destroyed. Call PreCheck first.
+func Client(t testing.TB) *marmot.Client {
+	t.Helper()
+
+	client, err := marmot.NewClient(marmot.ClientOptions{
+		Host:   os.Getenv("MARMOT_HOST"),
+		APIKey: os.Getenv("MARMOT_API_KEY"),
+		Token:  os.Getenv("MARMOT_TOKEN"),
+	})
+	if err != nil {
+		t.Fatalf("creating Marmot client: %s", err)
+	}
+	return client
+}
+
+// RandomName returns NamePrefix and prefix followed by a random suffix, so
+// concurrent and repeated runs don't collide.
+func RandomName(prefix string) string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return NamePrefix + prefix + "-" + hex.EncodeToString(b)
+}
+
+// login exchanges a username and password for an access token, retrying
+// while the server is still starting.
+func login(host, username, password string) (string, error) {
+	body, err := json.Marshal(map[string]string{"username": username, "password": password})
+	if err != nil {
+		return "", err
+	}
+	loginURL := strings.TrimSuffix(host, "/") + marmot.DefaultBasePath + "/users/login"
+
+	ctx, cancel := context.WithTimeout(context.Background(), startupTimeout)
+	defer cancel()
+
+	for {
+		token, err := tryLogin(ctx, loginURL, body)
+		if err == nil {
+			return token, nil
+		}
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("logging in as %s: %w", username, err)
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+func tryLogin(ctx context.Context, loginURL string, body []byte) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, loginURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s returned %s", loginURL, resp.Status)
+	}
+
+	var out struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", err
+	}
+	if out.AccessToken == "" {
+		return "", fmt.Errorf("%s returned no access token", loginURL)
+	}
+	return out.AccessToken, nil
+}
+
+func envOr(name, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
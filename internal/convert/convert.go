@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package convert reads the JSON values that the Marmot API models leave
+// untyped, such as asset metadata, source properties, and pipeline config,
+// which the generated client declares as interface{}. The client decodes
+// them with encoding/json, so objects arrive as map[string]any and arrays
+// as []any.
+package convert
+
+import "fmt"
+
+// Object returns v as a JSON object, or nil when v is not one. The map is
+// v itself, not a copy, so changes to it change v.
+func Object(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+// CopyObject returns a shallow copy of Object(v), or nil when v is not an
+// object.
+func CopyObject(v any) map[string]any {
+	m := Object(v)
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]any, len(m))
+	for k, val := range m {
+		out[k] = val
+	}
+	return out
+}
+
+// Array returns v as a JSON array, or nil when v is not one.
+func Array(v any) []any {
+	a, _ := v.([]any)
+	return a
+}
+
+// StringMap returns the values of the object v formatted as strings:
+// strings as they are and everything else with %v. It returns nil when v is
+// not an object or is empty.
+func StringMap(v any) map[string]string {
+	m := Object(v)
+	if len(m) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, val := range m {
+		if s, ok := val.(string); ok {
+			out[k] = s
+		} else {
+			out[k] = fmt.Sprintf("%v", val)
+		}
+	}
+	return out
+}
+
+// NonEmptyStringMap is StringMap for values read back into Terraform string
+// maps, where a key holding null or an empty string reads as unset: those
+// keys are left out, and numbers are written in a fixed format. The result
+// is never nil.
+func NonEmptyStringMap(m map[string]any) map[string]string {
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		switch val := v.(type) {
+		case nil:
+		case string:
+			if val != "" {
+				result[k] = val
+			}
+		case int, int32, int64:
+			result[k] = fmt.Sprintf("%d", val)
+		case float32, float64:
+			result[k] = fmt.Sprintf("%.6f", val)
+		case bool:
+			result[k] = fmt.Sprintf("%t", val)
+		default:
+			if s := fmt.Sprintf("%v", val); s != "" {
+				result[k] = s
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/marmot/sdk/go/auth"
+)
+
+// providerData is what Configure hands to every resource and data source.
+type providerData struct {
+	// client is the Marmot SDK client, used wherever the SDK covers the API.
+	client *marmot.Client
+
+	// api calls Marmot endpoints the SDK doesn't wrap yet.
+	api *apiClient
+
+	// assets caches asset lookups made by data sources. Resources use
+	// assets.forResources(), which doesn't cache "not found".
+	assets *assetLookup
+
+	// ignoreLabelCase is the provider's ignore_label_case setting.
+	ignoreLabelCase bool
+
+	// uiBaseURL is where the Marmot UI is served: the host, under any
+	// gateway prefix in front of the API path.
+	uiBaseURL string
+
+	// defaultEnvironments is the provider's default_environments setting.
+	defaultEnvironments map[string]AssetEnvironmentModel
+
+	// validateOnPlan is the provider's validate_on_plan setting.
+	validateOnPlan bool
+
+	// checkExternalLinks is the provider's check_external_links setting.
+	checkExternalLinks bool
+
+	// failOnUnknownEnums is the provider's fail_on_unknown_enum_values
+	// setting.
+	failOnUnknownEnums bool
+
+	// readOnly is the provider's read_only setting. Requests that change
+	// the catalog already fail in the transport; resources check it to skip
+	// changes they would otherwise make during refresh.
+	readOnly bool
+}
+
+// apiClient is a small JSON client for Marmot REST endpoints that the SDK
+// does not expose. It shares the SDK's HTTP client, so requests go through
+// the same rate limits and error capture, and authenticates with the same
+// credential and User-Agent.
+type apiClient struct {
+	httpClient *http.Client
+	baseURL    string
+	cred       auth.Credential
+	userAgent  string
+}
+
+func newAPIClient(httpClient *http.Client, host, basePath string, cred auth.Credential, userAgent string) *apiClient {
+	return &apiClient{
+		httpClient: httpClient,
+		baseURL:    strings.TrimRight(host, "/") + basePath,
+		cred:       cred,
+		userAgent:  userAgent,
+	}
+}
+
+// do sends a request with in encoded as the JSON body, when non-nil, and
+// decodes a successful response into out, when non-nil. Failed responses are
+// returned as the SDK's typed errors, so marmot.IsNotFound and friends work
+// on them too.
+func (c *apiClient) do(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if in != nil {
+		encoded, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("encode %s %s request: %w", method, path, err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	switch c.cred.Scheme() {
+	case auth.SchemeBearer:
+		req.Header.Set("Authorization", "Bearer "+c.cred.Token())
+	default:
+		req.Header.Set(string(auth.SchemeAPIKey), c.cred.Token())
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return responseError(resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode %s %s response: %w", method, path, err)
+	}
+	return nil
+}
+
+// responseError maps a failed response onto the SDK's error types.
+func responseError(resp *http.Response) error {
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+
+	var parsed apiErrorBody
+	msg := ""
+	if json.Unmarshal(raw, &parsed) == nil {
+		msg = parsed.Message
+		if msg == "" {
+			msg = parsed.Error
+		}
+	}
+
+	base := &marmot.APIError{StatusCode: resp.StatusCode, Message: msg}
+	switch {
+	case resp.StatusCode == http.StatusBadRequest:
+		return &marmot.ValidationError{APIError: base}
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		return &marmot.AuthError{APIError: base}
+	case resp.StatusCode == http.StatusNotFound:
+		return &marmot.NotFoundError{APIError: base}
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return &marmot.RateLimitError{APIError: base}
+	case resp.StatusCode >= 500:
+		return &marmot.ServerError{APIError: base}
+	default:
+		return base
+	}
+}
@@ -0,0 +1,496 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AssetColumnsResource{}
+var _ resource.ResourceWithConfigValidators = &AssetColumnsResource{}
+
+// columnsSchemaField is the asset schema field that database ingestion
+// plugins list columns in, as a JSON array of column objects. The keys below
+// are the ones the resource reads or writes; every other key is the
+// scanner's and is kept as read.
+const (
+	columnsSchemaField = "columns"
+
+	columnKeyName        = "name"
+	columnKeyDescription = "description"
+	columnKeyTags        = "tags"
+)
+
+func NewAssetColumnsResource() resource.Resource {
+	return &AssetColumnsResource{}
+}
+
+// AssetColumnsResource defines the resource implementation.
+type AssetColumnsResource struct {
+	client *marmot.Client
+}
+
+// AssetColumnsResourceModel describes the asset columns resource data model.
+type AssetColumnsResourceModel struct {
+	AssetMRN types.String                `tfsdk:"asset_mrn"`
+	AssetID  types.String                `tfsdk:"asset_id"`
+	Columns  map[string]AssetColumnModel `tfsdk:"columns"`
+}
+
+// AssetColumnModel is the documentation of one column.
+type AssetColumnModel struct {
+	Description types.String `tfsdk:"description"`
+	Tags        types.Set    `tfsdk:"tags"`
+}
+
+func (r *AssetColumnsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset_columns"
+}
+
+func (r *AssetColumnsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Documents the columns of an asset that is managed elsewhere, such as a " +
+			"table created by ingestion, without managing the rest of the asset. Columns are read " +
+			"from the asset's `columns` schema field, where the ingestion plugins for databases " +
+			"store them, and only the `description` and `tags` of the listed columns are written. " +
+			"The column names, types, and everything else the scanner records are left as they are, " +
+			"and what this resource set is removed on destroy.\n\n" +
+			"A scan that rewrites the schema may drop the descriptions; the next plan shows that as " +
+			"drift and the apply writes them back. Applying fails for a column the asset doesn't " +
+			"have, so a renamed or dropped column is noticed.\n\n" +
+			"Don't document the same column in more than one place, including the `columns` of a " +
+			"`marmot_postgres_table_asset` or the `schema` of a `marmot_asset` for the same asset.",
+
+		Attributes: map[string]schema.Attribute{
+			"asset_mrn": schema.StringAttribute{
+				MarkdownDescription: "MRN of the asset, e.g. `mrn://table/postgresql/orders`. " +
+					"Conflicts with `asset_id`; computed when that is set.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					isMRN(),
+				},
+			},
+			"asset_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the asset, such as `marmot_asset.x.id`. Conflicts with " +
+					"`asset_mrn`; computed when that is set.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"columns": schema.MapNestedAttribute{
+				MarkdownDescription: "Documentation to set, keyed by column name. An attribute left " +
+					"unset leaves the column's current value alone; one removed from here, or a column " +
+					"removed from here, is cleared.",
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Column description",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"tags": schema.SetAttribute{
+							MarkdownDescription: "Tags on the column",
+							Optional:            true,
+							ElementType:         types.StringType,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+								setvalidator.ValueStringsAre(stringvalidator.LengthBetween(1, maxTagLength)),
+							},
+						},
+					},
+				},
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *AssetColumnsResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("asset_mrn"), path.MatchRoot("asset_id")),
+	}
+}
+
+func (r *AssetColumnsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *AssetColumnsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data AssetColumnsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assetID, err := resolveAssetRefs(ctx, r.client, &data.AssetMRN, &data.AssetID)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to find asset", err)
+		return
+	}
+
+	edits := columnEdits(ctx, data.Columns, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !r.updateColumns(ctx, assetID, edits, true, &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Info(ctx, "Asset columns documented", map[string]any{
+		"asset_id": assetID,
+		"columns":  sortedColumnNames(data.Columns),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AssetColumnsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data AssetColumnsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, err := r.client.Assets.Get(ctx, data.AssetID.ValueString())
+	if err != nil {
+		if marmot.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to read asset", err)
+		return
+	}
+
+	columns, err := decodeSchemaColumns(asset.Schema[columnsSchemaField])
+	if err != nil {
+		resp.Diagnostics.AddError("Unreadable Asset Columns", fmt.Sprintf("Asset %s: %s.", asset.ID, err))
+		return
+	}
+	byName := indexSchemaColumns(columns)
+
+	// Only the attributes the configuration sets are read back. A column that
+	// is gone drops out of state, so the next apply reports it.
+	current := make(map[string]AssetColumnModel, len(data.Columns))
+	for name, prior := range data.Columns {
+		column, ok := byName[name]
+		if !ok {
+			continue
+		}
+		model := AssetColumnModel{Description: types.StringNull(), Tags: types.SetNull(types.StringType)}
+		if !prior.Description.IsNull() {
+			if s, _ := column[columnKeyDescription].(string); s != "" {
+				model.Description = types.StringValue(s)
+			}
+		}
+		if !prior.Tags.IsNull() {
+			model.Tags = stringsToSet(ctx, columnTags(column), &resp.Diagnostics)
+		}
+		current[name] = model
+	}
+	if len(current) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.Columns = current
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AssetColumnsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data, state AssetColumnsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	edits := columnEdits(ctx, data.Columns, state.Columns, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !r.updateColumns(ctx, data.AssetID.ValueString(), edits, true, &resp.Diagnostics) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AssetColumnsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data AssetColumnsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	edits := columnEdits(ctx, nil, data.Columns, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !r.updateColumns(ctx, data.AssetID.ValueString(), edits, false, &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Info(ctx, "Asset column documentation removed", map[string]any{
+		"asset_id": data.AssetID.ValueString(),
+		"columns":  sortedColumnNames(data.Columns),
+	})
+}
+
+// columnEdit is what an apply changes on one column: values to set, and
+// keys to clear because the configuration stopped setting them.
+type columnEdit struct {
+	description *string
+	tags        []string
+	clear       []string
+}
+
+// columnEdits compares the planned columns with those in prior state. A key
+// is set when planned, and cleared when prior state set it and the plan
+// doesn't.
+func columnEdits(ctx context.Context, planned, prior map[string]AssetColumnModel, diags *diag.Diagnostics) map[string]columnEdit {
+	edits := map[string]columnEdit{}
+	for name, column := range planned {
+		var edit columnEdit
+		was := prior[name]
+		if !column.Description.IsNull() {
+			description := column.Description.ValueString()
+			edit.description = &description
+		} else if !was.Description.IsNull() {
+			edit.clear = append(edit.clear, columnKeyDescription)
+		}
+		if !column.Tags.IsNull() {
+			edit.tags = sortedUniqueTags(setStrings(ctx, column.Tags, diags))
+		} else if !was.Tags.IsNull() {
+			edit.clear = append(edit.clear, columnKeyTags)
+		}
+		edits[name] = edit
+	}
+	for name, was := range prior {
+		if _, ok := planned[name]; ok {
+			continue
+		}
+		var edit columnEdit
+		if !was.Description.IsNull() {
+			edit.clear = append(edit.clear, columnKeyDescription)
+		}
+		if !was.Tags.IsNull() {
+			edit.clear = append(edit.clear, columnKeyTags)
+		}
+		edits[name] = edit
+	}
+	return edits
+}
+
+// updateColumns applies edits to the asset's columns schema field, leaving
+// its other fields as they are. With requireColumns, a column to set that
+// the asset doesn't have is an error; otherwise it is skipped, as on
+// destroy. It returns false when it added an error.
+func (r *AssetColumnsResource) updateColumns(ctx context.Context, assetID string, edits map[string]columnEdit, requireColumns bool, diags *diag.Diagnostics) bool {
+	mu, _ := assetMetadataLocks.LoadOrStore(assetID, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+
+	asset, err := r.client.Assets.Get(ctx, assetID)
+	if err != nil {
+		if !requireColumns && marmot.IsNotFound(err) {
+			return true
+		}
+		addClientError(ctx, diags, "Unable to read asset", err)
+		return false
+	}
+
+	doc := asset.Schema[columnsSchemaField]
+	columns, err := decodeSchemaColumns(doc)
+	if err != nil {
+		diags.AddError("Unreadable Asset Columns", fmt.Sprintf("Asset %s: %s.", asset.ID, err))
+		return false
+	}
+	byName := indexSchemaColumns(columns)
+
+	var missing []string
+	for name, edit := range edits {
+		column, ok := byName[name]
+		if !ok {
+			if edit.description != nil || edit.tags != nil {
+				missing = append(missing, name)
+			}
+			continue
+		}
+		for _, key := range edit.clear {
+			delete(column, key)
+		}
+		if edit.description != nil {
+			column[columnKeyDescription] = *edit.description
+		}
+		if edit.tags != nil {
+			column[columnKeyTags] = edit.tags
+		}
+	}
+	if len(missing) > 0 && requireColumns {
+		sort.Strings(missing)
+		detail := fmt.Sprintf("Asset %s has no columns in its %s schema field.", asset.Mrn, columnsSchemaField)
+		if len(byName) > 0 {
+			detail = fmt.Sprintf("Asset %s has no columns named %s. Its columns are: %s.",
+				asset.Mrn, strings.Join(missing, ", "), strings.Join(sortedSchemaColumnNames(byName), ", "))
+		}
+		diags.AddAttributeError(path.Root("columns"), "Asset Column Not Found", detail)
+		return false
+	}
+
+	updated, err := encodeCanonicalJSON(columns)
+	if err != nil {
+		diags.AddError("Unable to Encode Columns", err.Error())
+		return false
+	}
+	if doc == "" || updated == doc {
+		return true
+	}
+
+	schemaFields := make(map[string]string, len(asset.Schema))
+	for k, v := range asset.Schema {
+		schemaFields[k] = v
+	}
+	schemaFields[columnsSchemaField] = updated
+
+	// The update replaces every field it is given, and lists that are left
+	// out are cleared, so the rest of the asset is sent back as it was read.
+	_, err = r.client.Assets.Update(ctx, assetID, marmot.UpdateAssetInput{
+		Name:            asset.Name,
+		Type:            asset.Type,
+		Description:     asset.Description,
+		UserDescription: asset.UserDescription,
+		Providers:       asset.Providers,
+		Tags:            asset.Tags,
+		Metadata:        convert.Object(asset.Metadata),
+		Schema:          schemaFields,
+		ExternalLinks:   asset.ExternalLinks,
+		Sources:         asset.Sources,
+		Environments:    asset.Environments,
+	})
+	if err != nil {
+		addClientError(ctx, diags, "Unable to update asset columns", err)
+		return false
+	}
+	return true
+}
+
+// decodeSchemaColumns decodes the columns schema field as a list of column
+// objects, keeping every key. Numbers are kept as written.
+func decodeSchemaColumns(doc string) ([]map[string]any, error) {
+	if doc == "" {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader([]byte(doc)))
+	dec.UseNumber()
+	var columns []map[string]any
+	if err := dec.Decode(&columns); err != nil {
+		return nil, fmt.Errorf("the %s schema field isn't a list of columns: %w", columnsSchemaField, err)
+	}
+	return columns, nil
+}
+
+// indexSchemaColumns indexes columns by name. The maps are the columns
+// themselves, so changes to them change columns.
+func indexSchemaColumns(columns []map[string]any) map[string]map[string]any {
+	byName := make(map[string]map[string]any, len(columns))
+	for _, column := range columns {
+		if name, _ := column[columnKeyName].(string); name != "" {
+			byName[name] = column
+		}
+	}
+	return byName
+}
+
+// columnTags returns the tags recorded on a column.
+func columnTags(column map[string]any) []string {
+	var tags []string
+	for _, v := range convert.Array(column[columnKeyTags]) {
+		if s, ok := v.(string); ok && s != "" {
+			tags = append(tags, s)
+		}
+	}
+	return tags
+}
+
+func sortedColumnNames(columns map[string]AssetColumnModel) []string {
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedSchemaColumnNames(byName map[string]map[string]any) []string {
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
+)
+
+// assetVersionPrivateKey holds the asset's updated_at as last seen by the
+// provider. The API has no ETags or If-Match, so Update compares it with the
+// server's updated_at before writing.
+const assetVersionPrivateKey = "asset_updated_at"
+
+// assetFingerprintPrivateKey holds assetFingerprint of the asset as last seen
+// by the provider, so Update can tell whether a newer updated_at only
+// reflects changes to server_managed_fields.
+const assetFingerprintPrivateKey = "asset_fingerprint"
+
+// setAssetVersion records the asset's updated_at and fingerprint in private
+// state. skip lists metadata keys left out of the fingerprint, such as those
+// sent through sensitive_metadata.
+func setAssetVersion(ctx context.Context, private privateState, asset *marmot.Asset, managed serverManagedFields, skip []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	value, err := json.Marshal(asset.UpdatedAt)
+	if err != nil {
+		diags.AddError("Private State Error", err.Error())
+		return diags
+	}
+	diags.Append(private.SetKey(ctx, assetVersionPrivateKey, value)...)
+
+	fingerprint, err := assetFingerprint(asset, managed, skip)
+	if err == nil {
+		value, err = json.Marshal(fingerprint)
+	}
+	if err != nil {
+		diags.AddError("Private State Error", err.Error())
+		return diags
+	}
+	diags.Append(private.SetKey(ctx, assetFingerprintPrivateKey, value)...)
+	return diags
+}
+
+// checkAssetVersion reports a conflict when current was changed on the server
+// since the provider last read it, such as by an edit in the UI between plan
+// and apply. Changes confined to the attributes and metadata keys in managed
+// aren't conflicts, since those are expected to change elsewhere. State
+// written before the version was recorded isn't checked.
+func checkAssetVersion(ctx context.Context, private privateState, current *marmot.Asset, managed serverManagedFields, skip []string) diag.Diagnostics {
+	seen, diags := privateString(ctx, private, assetVersionPrivateKey)
+	if diags.HasError() || seen == "" || seen == current.UpdatedAt {
+		return diags
+	}
+
+	seenFingerprint, fpDiags := privateString(ctx, private, assetFingerprintPrivateKey)
+	diags.Append(fpDiags...)
+	if diags.HasError() {
+		return diags
+	}
+	if seenFingerprint != "" {
+		fingerprint, err := assetFingerprint(current, managed, skip)
+		if err != nil {
+			diags.AddError("Private State Error", err.Error())
+			return diags
+		}
+		if fingerprint == seenFingerprint {
+			return diags
+		}
+	}
+
+	diags.AddError(
+		"Asset Changed Outside Terraform",
+		fmt.Sprintf("The asset %q was modified in Marmot at %s, after Terraform last read it at version %s. "+
+			"Applying this plan would overwrite that change.\n\n"+
+			"Run terraform plan again to review the change. Assets that other tools also update "+
+			"should list those fields in server_managed_fields, so that changes to them alone "+
+			"aren't reported.",
+			current.Name, normalizeTimestamp(current.UpdatedAt), normalizeTimestamp(seen)),
+	)
+	return diags
+}
+
+// privateString returns the JSON string stored under key in private state,
+// or "" when the key is unset.
+func privateString(ctx context.Context, private privateState, key string) (string, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, key)
+	if diags.HasError() || len(value) == 0 {
+		return "", diags
+	}
+
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		diags.AddError("Private State Error", fmt.Sprintf("Unable to decode %s: %s", key, err))
+	}
+	return s, diags
+}
+
+// assetFingerprint hashes asset without its updated_at, the attributes and
+// metadata keys listed in managed, and the metadata keys in skip, so two
+// versions of an asset that only differ in those hash the same.
+func assetFingerprint(asset *marmot.Asset, managed serverManagedFields, skip []string) (string, error) {
+	a := *asset
+	a.UpdatedAt = ""
+	for name := range managed.fields {
+		switch name {
+		case "description":
+			a.Description = ""
+		case "user_description":
+			a.UserDescription = ""
+		case "tags":
+			a.Tags = nil
+		case "metadata":
+			a.Metadata = nil
+		case "schema":
+			a.Schema = nil
+		case "external_links":
+			a.ExternalLinks = nil
+		case "sources":
+			a.Sources = nil
+		case "environments":
+			a.Environments = nil
+		case "last_sync_at":
+			a.LastSyncAt = ""
+		case "parent_mrn":
+			a.ParentMrn = ""
+		case "query":
+			a.Query = ""
+		case "query_language":
+			a.QueryLanguage = ""
+		case "has_run_history":
+			a.HasRunHistory = false
+		case "is_stub":
+			a.IsStub = false
+		}
+	}
+	if meta := convert.CopyObject(a.Metadata); meta != nil {
+		for _, k := range managed.metadataKeys {
+			delete(meta, k)
+		}
+		for _, k := range skip {
+			delete(meta, k)
+		}
+		a.Metadata = meta
+	}
+
+	b, err := json.Marshal(a)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+type mapPrivateState map[string][]byte
+
+func (p mapPrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p mapPrivateState) SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func TestCheckAssetVersion(t *testing.T) {
+	managed := serverManagedFields{
+		fields:       map[string]bool{"schema": true},
+		metadataKeys: []string{"row_count"},
+	}
+	seen := &marmot.Asset{
+		Name:        "orders",
+		Description: "Orders",
+		UpdatedAt:   "2026-01-01T00:00:00Z",
+		Metadata:    map[string]interface{}{"owner": "sales", "row_count": float64(10), "secret": "s1"},
+	}
+
+	tests := map[string]struct {
+		managed serverManagedFields
+		edit    func(a *marmot.Asset)
+		noPrior bool
+		wantErr bool
+	}{
+		"unchanged": {
+			managed: managed,
+			edit:    func(a *marmot.Asset) {},
+		},
+		"updated_at only": {
+			edit: func(a *marmot.Asset) { a.UpdatedAt = "2026-01-02T00:00:00Z" },
+		},
+		"managed attribute": {
+			managed: managed,
+			edit: func(a *marmot.Asset) {
+				a.UpdatedAt = "2026-01-02T00:00:00Z"
+				a.Schema = map[string]string{"json": "{}"}
+			},
+		},
+		"managed metadata key": {
+			managed: managed,
+			edit: func(a *marmot.Asset) {
+				a.UpdatedAt = "2026-01-02T00:00:00Z"
+				a.Metadata = map[string]interface{}{"owner": "sales", "row_count": float64(20), "secret": "s1"}
+			},
+		},
+		"skipped metadata key": {
+			edit: func(a *marmot.Asset) {
+				a.UpdatedAt = "2026-01-02T00:00:00Z"
+				a.Metadata = map[string]interface{}{"owner": "sales", "row_count": float64(10), "secret": "s2"}
+			},
+		},
+		"unmanaged attribute": {
+			managed: managed,
+			edit: func(a *marmot.Asset) {
+				a.UpdatedAt = "2026-01-02T00:00:00Z"
+				a.Description = "Edited in the UI"
+			},
+			wantErr: true,
+		},
+		"unmanaged metadata key": {
+			managed: managed,
+			edit: func(a *marmot.Asset) {
+				a.UpdatedAt = "2026-01-02T00:00:00Z"
+				a.Metadata = map[string]interface{}{"owner": "finance", "row_count": float64(10), "secret": "s1"}
+			},
+			wantErr: true,
+		},
+		"no fingerprint recorded": {
+			managed: managed,
+			edit: func(a *marmot.Asset) {
+				a.UpdatedAt = "2026-01-02T00:00:00Z"
+				a.Schema = map[string]string{"json": "{}"}
+			},
+			noPrior: true,
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := t.Context()
+			private := mapPrivateState{}
+			if diags := setAssetVersion(ctx, private, seen, tt.managed, []string{"secret"}); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if tt.noPrior {
+				delete(private, assetFingerprintPrivateKey)
+			}
+
+			current := *seen
+			tt.edit(&current)
+			diags := checkAssetVersion(ctx, private, &current, tt.managed, []string{"secret"})
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("got error %t, want %t: %v", diags.HasError(), tt.wantErr, diags)
+			}
+		})
+	}
+
+	if diags := checkAssetVersion(t.Context(), mapPrivateState{}, seen, managed, nil); diags.HasError() {
+		t.Errorf("state without a recorded version: unexpected diagnostics: %v", diags)
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// contentHashAttributes are the marmot_asset attributes that content_hash
+// covers: everything sent to Marmot, with sensitive_metadata represented by
+// its version and documentation_file by the hash of its content.
+// Provider-only settings such as allow_adopt are left out.
+var contentHashAttributes = []string{
+	"name",
+	"type",
+	"description",
+	"user_description",
+	"services",
+	"tags",
+	"structured_tags",
+	"metadata",
+	"metadata_json",
+	"sensitive_metadata_version",
+	"schema",
+	"external_links",
+	"sources",
+	"environments",
+	"documentation_hash",
+}
+
+// assetContentHash returns the content_hash for an asset object, or an
+// unknown value when any of the attributes it covers is unknown.
+func assetContentHash(raw tftypes.Value) (types.String, error) {
+	var attrs map[string]tftypes.Value
+	if err := raw.As(&attrs); err != nil {
+		return types.StringNull(), err
+	}
+
+	content := make(map[string]interface{}, len(contentHashAttributes))
+	for _, name := range contentHashAttributes {
+		v, ok := attrs[name]
+		if !ok {
+			continue
+		}
+		if !v.IsFullyKnown() {
+			return types.StringUnknown(), nil
+		}
+		plain, err := plainValue(v)
+		if err != nil {
+			return types.StringNull(), fmt.Errorf("%s: %w", name, err)
+		}
+		content[name] = plain
+	}
+
+	// encoding/json sorts map keys, so equal content always encodes the same.
+	encoded, err := json.Marshal(content)
+	if err != nil {
+		return types.StringNull(), err
+	}
+	sum := sha256.Sum256(encoded)
+	return types.StringValue(hex.EncodeToString(sum[:])), nil
+}
+
+// plainValue converts a known Terraform value into strings, numbers, bools,
+// slices, and maps that encoding/json writes deterministically. Set elements
+// are sorted by their encoding, since sets have no order.
+func plainValue(v tftypes.Value) (interface{}, error) {
+	if v.IsNull() {
+		return nil, nil
+	}
+
+	typ := v.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		err := v.As(&s)
+		return s, err
+	case typ.Is(tftypes.Number):
+		n := new(big.Float)
+		if err := v.As(&n); err != nil {
+			return nil, err
+		}
+		return json.Number(n.Text('g', -1)), nil
+	case typ.Is(tftypes.Bool):
+		var b bool
+		err := v.As(&b)
+		return b, err
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+		out := make([]interface{}, 0, len(elems))
+		for _, e := range elems {
+			plain, err := plainValue(e)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, plain)
+		}
+		if typ.Is(tftypes.Set{}) {
+			if err := sortByEncoding(out); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var elems map[string]tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+		out := make(map[string]interface{}, len(elems))
+		for k, e := range elems {
+			plain, err := plainValue(e)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = plain
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", typ)
+}
+
+func sortByEncoding(values []interface{}) error {
+	type encodedValue struct {
+		key   string
+		value interface{}
+	}
+	pairs := make([]encodedValue, len(values))
+	for i, v := range values {
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		pairs[i] = encodedValue{key: string(encoded), value: v}
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].key < pairs[j].key })
+	for i, p := range pairs {
+		values[i] = p.value
+	}
+	return nil
+}
+
+// contentHashTarget is the plan or state that content_hash is written to.
+type contentHashTarget interface {
+	SetAttribute(ctx context.Context, p path.Path, val interface{}) diag.Diagnostics
+}
+
+// setContentHash writes the content_hash for raw to target.
+func setContentHash(ctx context.Context, target contentHashTarget, raw tftypes.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+	hash, err := assetContentHash(raw)
+	if err != nil {
+		diags.AddError("Unable to Compute content_hash", err.Error())
+		return diags
+	}
+	return target.SetAttribute(ctx, path.Root("content_hash"), hash)
+}
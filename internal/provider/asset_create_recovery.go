@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+const (
+	// createRecoveryTimeout bounds the lookup made after a create whose
+	// outcome is unknown. It runs even when the create's own context has
+	// expired, since that is usually why the outcome is unknown.
+	createRecoveryTimeout = 30 * time.Second
+
+	// createRecoveryClockSkew is how far the server's created_at may fall
+	// outside the time the create was in flight, by the provider's clock,
+	// and still be taken as that create.
+	createRecoveryClockSkew = 30 * time.Second
+)
+
+// createOutcomeUnknown reports whether a failed create may still have been
+// applied by the server: the connection failed or timed out before a
+// response arrived, or the server or a gateway in front of it failed with a
+// 5xx status. Any other API response, or a request the read-only guard never
+// sent, means the server did not create anything.
+func createOutcomeUnknown(err error) bool {
+	if errors.Is(err, errReadOnly) {
+		return false
+	}
+	status, ok := apiStatusCode(err)
+	if !ok {
+		return true
+	}
+	return status >= http.StatusInternalServerError
+}
+
+// recoverCreatedAsset looks for an asset that a create started at started
+// made even though the request failed, so Terraform records it instead of
+// creating it again on the next apply, which would fail with a conflict.
+// The API takes no idempotency key, so the asset is found by its identity
+// and only accepted if Marmot created it while the request was in flight.
+func (r *AssetResource) recoverCreatedAsset(ctx context.Context, data AssetResourceModel, services []string, started time.Time) *marmot.Asset {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), createRecoveryTimeout)
+	defer cancel()
+
+	found, err := r.findAdoptable(ctx, data, services)
+	if err != nil || found == nil {
+		return nil
+	}
+
+	created, err := time.Parse(time.RFC3339Nano, found.CreatedAt)
+	if err != nil || created.Before(started.Add(-createRecoveryClockSkew)) || created.After(time.Now().Add(createRecoveryClockSkew)) {
+		// The asset wasn't created while this create was in flight, so
+		// the create didn't make it.
+		return nil
+	}
+
+	tflog.Warn(ctx, "Asset create failed but the asset was created; recording it", map[string]interface{}{
+		"id":  found.ID,
+		"mrn": found.Mrn,
+	})
+	return found
+}
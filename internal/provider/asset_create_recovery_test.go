@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+func TestCreateOutcomeUnknown(t *testing.T) {
+	apiErr := func(status int) *marmot.APIError {
+		return &marmot.APIError{StatusCode: status, Message: "failed"}
+	}
+
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"read only":        {err: fmt.Errorf("post: %w", errReadOnly), want: false},
+		"bad request":      {err: &marmot.ValidationError{APIError: apiErr(400)}, want: false},
+		"unauthorized":     {err: &marmot.AuthError{APIError: apiErr(401)}, want: false},
+		"forbidden":        {err: &marmot.AuthError{APIError: apiErr(403)}, want: false},
+		"not found":        {err: &marmot.NotFoundError{APIError: apiErr(404)}, want: false},
+		"conflict":         {err: apiErr(409), want: false},
+		"rate limited":     {err: &marmot.RateLimitError{APIError: apiErr(429)}, want: false},
+		"wrapped":          {err: fmt.Errorf("create: %w", &marmot.ValidationError{APIError: apiErr(400)}), want: false},
+		"internal error":   {err: &marmot.ServerError{APIError: apiErr(500)}, want: true},
+		"bad gateway":      {err: &marmot.ServerError{APIError: apiErr(502)}, want: true},
+		"gateway timeout":  {err: &marmot.ServerError{APIError: apiErr(504)}, want: true},
+		"timeout":          {err: context.DeadlineExceeded, want: true},
+		"connection reset": {err: &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}, want: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := createOutcomeUnknown(tt.err); got != tt.want {
+				t.Errorf("createOutcomeUnknown(%v) = %t, want %t", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AssetDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &AssetDataSource{}
+
+func NewAssetDataSource() datasource.DataSource {
+	return &AssetDataSource{}
+}
+
+// AssetDataSource defines the data source implementation.
+type AssetDataSource struct {
+	assets    *assetLookup
+	uiBaseURL string
+}
+
+// AssetDataSourceModel describes the asset data source data model.
+type AssetDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	MRN         types.String `tfsdk:"mrn"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	Description types.String `tfsdk:"description"`
+	Services    types.Set    `tfsdk:"services"`
+	Tags        types.Set    `tfsdk:"tags"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	UpdatedAt   types.String `tfsdk:"updated_at"`
+	URL         types.String `tfsdk:"url"`
+	Views30d    types.Int64  `tfsdk:"views_last_30d"`
+}
+
+func (d *AssetDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset"
+}
+
+func (d *AssetDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a single asset by `id` or `mrn`, for referencing assets that " +
+			"are managed elsewhere, such as those created by ingestion. Exactly one of the two must " +
+			"be set.\n\n" +
+			"Lookups are cached for the rest of the plan or apply and shared between data sources. " +
+			"Many `mrn` lookups for the same type and service are resolved together through the " +
+			"search endpoint rather than one request each.\n\n" +
+			"`views_last_30d` comes from Marmot's usage metrics, which are read once per plan or " +
+			"apply for all lookups, so unused assets can be found with a condition such as " +
+			"`views_last_30d == 0`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Asset ID",
+				Optional:            true,
+				Computed:            true,
+			},
+			"mrn": schema.StringAttribute{
+				MarkdownDescription: "Marmot Resource Name of the form `mrn://<type>/<service>/<name>`",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					isMRN(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Asset name",
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Asset type",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Asset description",
+				Computed:            true,
+			},
+			"services": schema.SetAttribute{
+				MarkdownDescription: "Services associated with the asset",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Tags associated with the asset",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Creation timestamp",
+				Computed:            true,
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "Last update timestamp",
+				Computed:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "Link to the asset's page in the Marmot UI",
+				Computed:            true,
+			},
+			"views_last_30d": schema.Int64Attribute{
+				MarkdownDescription: "Times the asset was viewed in Marmot in the last 30 days. Null " +
+					"when the usage metrics can't be read, or when more than 1000 assets were viewed " +
+					"and this one isn't among the 1000 most viewed.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *AssetDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("mrn"),
+		),
+	}
+}
+
+func (d *AssetDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.assets = data.assets
+	d.uiBaseURL = data.uiBaseURL
+}
+
+func (d *AssetDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data AssetDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var asset *marmot.Asset
+	var err error
+	if !data.ID.IsNull() {
+		asset, err = d.assets.getByID(ctx, data.ID.ValueString())
+	} else {
+		asset, err = d.assets.getByMRN(ctx, data.MRN.ValueString())
+	}
+	if marmot.IsNotFound(err) || (err == nil && asset == nil) {
+		resp.Diagnostics.AddError("Asset Not Found", "No Marmot asset matches the given id or mrn.")
+		return
+	}
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to read asset", err)
+		return
+	}
+
+	services, diags := types.SetValueFrom(ctx, types.StringType, nonNilStrings(asset.Providers))
+	resp.Diagnostics.Append(diags...)
+	tags, diags := types.SetValueFrom(ctx, types.StringType, nonNilStrings(asset.Tags))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(asset.ID)
+	data.MRN = types.StringValue(asset.Mrn)
+	data.Name = types.StringValue(asset.Name)
+	data.Type = types.StringValue(asset.Type)
+	data.Description = types.StringValue(asset.Description)
+	data.Services = services
+	data.Tags = tags
+	data.CreatedAt = types.StringValue(normalizeTimestamp(asset.CreatedAt))
+	data.UpdatedAt = types.StringValue(normalizeTimestamp(asset.UpdatedAt))
+	data.URL = assetURL(d.uiBaseURL, asset.Mrn)
+
+	data.Views30d = types.Int64Null()
+	views, known, err := d.assets.viewCount(ctx, asset.ID)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Client Error", clientErrorDetail(ctx, "Unable to read asset usage metrics", err))
+	} else if known {
+		data.Views30d = types.Int64Value(views)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
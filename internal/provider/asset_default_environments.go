@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// Placeholders that default environment paths may use, filled in with the
+// asset's name and lowercased type.
+const (
+	environmentPlaceholderName = "{{name}}"
+	environmentPlaceholderType = "{{type}}"
+)
+
+func expandEnvironmentPath(path, name, assetType string) string {
+	if !strings.Contains(path, "{{") {
+		return path
+	}
+	return strings.NewReplacer(
+		environmentPlaceholderName, name,
+		environmentPlaceholderType, strings.ToLower(assetType),
+	).Replace(path)
+}
+
+// addDefaultEnvironments returns environments with the provider's
+// default_environments added for every key the asset doesn't configure
+// itself. The defaults are sent on every create and update, so they stay in
+// place on the server.
+func (r *AssetResource) addDefaultEnvironments(environments map[string]marmot.AssetEnvironment, data AssetResourceModel, diags *diag.Diagnostics) map[string]marmot.AssetEnvironment {
+	if len(r.defaultEnvironments) == 0 {
+		return environments
+	}
+
+	result := make(map[string]marmot.AssetEnvironment, len(environments)+len(r.defaultEnvironments))
+	for k, env := range environments {
+		result[k] = env
+	}
+	for k, env := range r.defaultEnvironments {
+		if _, ok := data.Environments[k]; ok {
+			continue
+		}
+		metadata, mdDiags := r.mapToDictionary(env.Metadata)
+		diags.Append(mdDiags...)
+
+		result[k] = marmot.AssetEnvironment{
+			Name:     env.Name.ValueString(),
+			Path:     expandEnvironmentPath(env.Path.ValueString(), data.Name.ValueString(), data.Type.ValueString()),
+			Metadata: metadata,
+		}
+	}
+	return result
+}
+
+// dropDefaultEnvironments removes the environments that come from the
+// provider's default_environments from model after a read, unless prior
+// configures them itself, so the defaults never show up as drift.
+func (r *AssetResource) dropDefaultEnvironments(model *AssetResourceModel, prior AssetResourceModel) {
+	if len(r.defaultEnvironments) == 0 || model.Environments == nil {
+		return
+	}
+	for k := range r.defaultEnvironments {
+		if _, ok := prior.Environments[k]; !ok {
+			delete(model.Environments, k)
+		}
+	}
+	if len(model.Environments) == 0 {
+		model.Environments = nil
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// documentationHash hashes documentation content, ignoring the whitespace
+// differences that markdownValue ignores.
+func documentationHash(content string) string {
+	sum := sha256.Sum256([]byte(normalizeMarkdown(content)))
+	return hex.EncodeToString(sum[:])
+}
+
+// readDocumentationFile returns the content of a documentation_file and its
+// hash.
+func readDocumentationFile(name string) (content, hash string, err error) {
+	raw, err := os.ReadFile(name)
+	if err != nil {
+		return "", "", err
+	}
+	content = string(raw)
+	return content, documentationHash(content), nil
+}
+
+// modifyPlanForDocumentation plans documentation_hash from the file's
+// current content, so editing the file alone plans an update.
+func modifyPlanForDocumentation(ctx context.Context, resp *resource.ModifyPlanResponse) {
+	if resp.Plan.Raw.IsNull() {
+		return
+	}
+
+	var file types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("documentation_file"), &file)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hash := types.StringNull()
+	switch {
+	case file.IsUnknown():
+		hash = types.StringUnknown()
+	case !file.IsNull():
+		_, h, err := readDocumentationFile(file.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("documentation_file"),
+				"Unable to Read Documentation File",
+				fmt.Sprintf("Unable to read %q: %s.", file.ValueString(), err),
+			)
+			return
+		}
+		hash = types.StringValue(h)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("documentation_hash"), hash)...)
+}
+
+// uploadDocumentation sends documentation_file to Marmot and records its
+// hash. The file must still match the hash in the plan, if there was one.
+// When nothing is uploaded the hash is left empty so the next plan retries.
+func (r *AssetResource) uploadDocumentation(ctx context.Context, data *AssetResourceModel, diags *diag.Diagnostics) error {
+	if data.DocumentationFile.IsNull() {
+		data.DocumentationHash = types.StringNull()
+		return nil
+	}
+
+	name := data.DocumentationFile.ValueString()
+	content, hash, err := readDocumentationFile(name)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("documentation_file"),
+			"Unable to Read Documentation File",
+			fmt.Sprintf("Unable to read %q: %s.", name, err),
+		)
+		data.DocumentationHash = types.StringValue("")
+		return nil
+	}
+	if planned := data.DocumentationHash; !planned.IsUnknown() && !planned.IsNull() && planned.ValueString() != hash {
+		diags.AddAttributeError(
+			path.Root("documentation_file"),
+			"Documentation File Changed",
+			fmt.Sprintf("%q changed after the plan was made. Run terraform plan again to upload the new content.", name),
+		)
+		data.DocumentationHash = types.StringValue("")
+		return nil
+	}
+
+	if _, err := r.api.putAssetDocumentation(ctx, data.MRN.ValueString(), content); err != nil {
+		return err
+	}
+	data.DocumentationHash = types.StringValue(hash)
+	return nil
+}
+
+// readDocumentationHash sets documentation_hash from the documentation
+// Marmot has for the asset. Assets without documentation_file aren't read.
+func (r *AssetResource) readDocumentationHash(ctx context.Context, data *AssetResourceModel) error {
+	if data.DocumentationFile.IsNull() {
+		data.DocumentationHash = types.StringNull()
+		return nil
+	}
+
+	doc, err := r.api.getAssetDocumentation(ctx, data.MRN.ValueString())
+	if marmot.IsNotFound(err) {
+		// An empty hash never matches a file's, so the next plan uploads it.
+		data.DocumentationHash = types.StringValue("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	data.DocumentationHash = types.StringValue(documentationHash(doc.Content))
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Placeholders expanded in external link URLs before they are sent, so one
+// module can stamp the same dashboard link across many assets.
+const (
+	linkPlaceholderMRN  = "{{mrn}}"
+	linkPlaceholderName = "{{name}}"
+)
+
+// expandLinkURL replaces the placeholders in a link URL with the asset's MRN
+// and name, escaped so they are safe in both paths and query strings.
+func expandLinkURL(link, mrn, name string) string {
+	if !strings.Contains(link, "{{") {
+		return link
+	}
+	return strings.NewReplacer(
+		linkPlaceholderMRN, escapeLinkValue(mrn),
+		linkPlaceholderName, escapeLinkValue(name),
+	).Replace(link)
+}
+
+func escapeLinkValue(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// linksNeedMRN reports whether any link URL uses the {{mrn}} placeholder,
+// which can only be expanded once the server has assigned the MRN.
+func linksNeedMRN(links []ExternalLinkModel) bool {
+	for _, link := range links {
+		if strings.Contains(link.URL.ValueString(), linkPlaceholderMRN) {
+			return true
+		}
+	}
+	return false
+}
+
+// restoreLinkTemplates puts the configured URL templates back into links read
+// from the API, wherever a prior link expands to exactly what the server
+// returned, so expanded URLs don't show up as drift.
+func restoreLinkTemplates(links, prior []ExternalLinkModel, mrn, name string) {
+	for i, link := range links {
+		for _, p := range prior {
+			if p.Name.ValueString() != link.Name.ValueString() || p.URL.ValueString() == link.URL.ValueString() {
+				continue
+			}
+			if expandLinkURL(p.URL.ValueString(), mrn, name) == link.URL.ValueString() {
+				links[i].URL = p.URL
+				break
+			}
+		}
+	}
+}
+
+// linkCheckTimeout bounds each external link check, redirects included.
+const linkCheckTimeout = 10 * time.Second
+
+// linkCheckClient checks external links. It is deliberately not the
+// provider's HTTP client, so Marmot credentials, headers, and proxy_url are
+// never sent to link targets; the standard proxy environment variables
+// still apply.
+var linkCheckClient = &http.Client{Timeout: linkCheckTimeout}
+
+// checkExternalLinks requests every link URL, expanded for the asset, and
+// warns about each one that can't be reached or answers with an error
+// status. The links are checked concurrently and never fail the apply.
+func checkExternalLinks(ctx context.Context, links []ExternalLinkModel, mrn, name string, diags *diag.Diagnostics) {
+	errs := make([]error, len(links))
+	var wg sync.WaitGroup
+	for i, link := range links {
+		wg.Add(1)
+		go func(i int, target string) {
+			defer wg.Done()
+			errs[i] = checkLink(ctx, target)
+		}(i, expandLinkURL(link.URL.ValueString(), mrn, name))
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		tflog.Debug(ctx, "External link check failed", map[string]interface{}{
+			"name":  links[i].Name.ValueString(),
+			"error": err.Error(),
+		})
+		diags.AddAttributeWarning(
+			path.Root("external_links"),
+			"External Link Unreachable",
+			fmt.Sprintf("The external link %q, %s, %s. The asset was saved with the link; "+
+				"fix or remove it so the catalog doesn't point at a dead page.",
+				links[i].Name.ValueString(), expandLinkURL(links[i].URL.ValueString(), mrn, name), err),
+		)
+	}
+}
+
+// checkLink sends a HEAD request to target, falling back to GET for servers
+// that don't support HEAD. Redirects are followed. 401 and 403 count as
+// reachable, since dashboards commonly answer them to anyone not signed in.
+func checkLink(ctx context.Context, target string) error {
+	status, err := requestLink(ctx, http.MethodHead, target)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = requestLink(ctx, http.MethodGet, target)
+	}
+	switch {
+	case err != nil:
+		return fmt.Errorf("could not be reached: %w", err)
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return nil
+	case status >= 400:
+		return fmt.Errorf("returned %d %s", status, http.StatusText(status))
+	}
+	return nil
+}
+
+func requestLink(ctx context.Context, method, target string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := linkCheckClient.Do(req)
+	if err != nil {
+		// The URL is already in the warning; report only the cause.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
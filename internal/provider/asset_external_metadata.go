@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
+)
+
+// externalMetadata returns the metadata on the server that the model doesn't
+// declare in metadata or metadata_json, such as keys written by ingestion
+// or by marmot_asset_metadata. Keys in exclude, such as sensitive_metadata,
+// are left out. Values that aren't strings are written as JSON.
+func externalMetadata(ctx context.Context, serverMeta map[string]interface{}, model AssetResourceModel, exclude []string, diags *diag.Diagnostics) types.Map {
+	declared := priorMetadata(model, diags)
+	for _, k := range exclude {
+		declared[k] = true
+	}
+
+	external := map[string]string{}
+	for k, v := range serverMeta {
+		if _, ok := declared[k]; ok || v == nil {
+			continue
+		}
+		if s, ok := v.(string); ok {
+			external[k] = s
+			continue
+		}
+		encoded, err := encodeCanonicalJSON(v)
+		if err != nil {
+			continue
+		}
+		external[k] = encoded
+	}
+
+	out, d := types.MapValueFrom(ctx, types.StringType, external)
+	diags.Append(d...)
+	return out
+}
+
+// declaresMetadata reports whether the model manages metadata through either
+// metadata or metadata_json.
+func declaresMetadata(model AssetResourceModel) bool {
+	return !model.Metadata.IsNull() || !model.MetadataJSON.IsNull()
+}
+
+// keepDeclaredMetadata removes from metadata the keys that prior doesn't
+// declare, so a read leaves them to external_metadata rather than reporting
+// them as drift. It does nothing when prior declares no metadata.
+func keepDeclaredMetadata(metadata map[string]interface{}, prior AssetResourceModel, diags *diag.Diagnostics) {
+	if !declaresMetadata(prior) {
+		return
+	}
+	declared := priorMetadata(prior, diags)
+	for k := range metadata {
+		if _, ok := declared[k]; !ok {
+			delete(metadata, k)
+		}
+	}
+}
+
+// mergeExternalMetadata copies into an update request the metadata keys on
+// the server that neither plan nor state declares, since the update replaces
+// the whole map and would otherwise clear keys written by other tools. Keys
+// declared in state but not in plan were removed from the configuration and
+// stay removed, as do keys in sensitiveKeys that were sent by an earlier
+// apply. When neither plan nor state declares metadata, mergeUnsetFromServer
+// already keeps the whole map.
+func mergeExternalMetadata(input *marmot.UpdateAssetInput, current *marmot.Asset, plan, state AssetResourceModel, sensitiveKeys []string, diags *diag.Diagnostics) {
+	if !declaresMetadata(plan) && !declaresMetadata(state) {
+		return
+	}
+
+	skip := priorMetadata(state, diags)
+	for k := range priorMetadata(plan, diags) {
+		skip[k] = true
+	}
+	for _, k := range sensitiveKeys {
+		skip[k] = true
+	}
+
+	for k, v := range convert.Object(current.Metadata) {
+		if _, ok := skip[k]; ok {
+			continue
+		}
+		if _, ok := input.Metadata[k]; ok {
+			continue
+		}
+		if input.Metadata == nil {
+			input.Metadata = map[string]interface{}{}
+		}
+		input.Metadata[k] = v
+	}
+}
+
+// modifyPlanForExternalMetadata marks external_metadata unknown when the
+// planned metadata differs from state, since the keys it leaves out change
+// with it. Otherwise the value from state is kept.
+func modifyPlanForExternalMetadata(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plannedMeta, priorMeta types.Map
+	var plannedJSON, priorJSON jsontypes.Normalized
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("metadata"), &plannedMeta)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("metadata"), &priorMeta)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("metadata_json"), &plannedJSON)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("metadata_json"), &priorJSON)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plannedMeta.Equal(priorMeta) || !plannedJSON.Equal(priorJSON) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("external_metadata"), types.MapUnknown(types.StringType))...)
+	}
+}
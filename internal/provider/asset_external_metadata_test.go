@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+func metadataModel(keys ...string) AssetResourceModel {
+	elems := map[string]attr.Value{}
+	for _, k := range keys {
+		elems[k] = types.StringValue("declared")
+	}
+	return AssetResourceModel{Metadata: types.MapValueMust(types.StringType, elems)}
+}
+
+func TestKeepDeclaredMetadata(t *testing.T) {
+	var diags diag.Diagnostics
+
+	server := map[string]interface{}{"owner": "a", "ingested": "b"}
+	keepDeclaredMetadata(server, metadataModel("owner"), &diags)
+	if want := map[string]interface{}{"owner": "a"}; !reflect.DeepEqual(server, want) {
+		t.Errorf("declared metadata: got %v, want %v", server, want)
+	}
+
+	server = map[string]interface{}{"owner": "a", "ingested": "b"}
+	keepDeclaredMetadata(server, AssetResourceModel{}, &diags)
+	if len(server) != 2 {
+		t.Errorf("undeclared metadata: got %v, want every key", server)
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestExternalMetadataAfterRead(t *testing.T) {
+	var diags diag.Diagnostics
+	ctx := t.Context()
+
+	server := map[string]interface{}{"owner": "a", "ingested": "b", "rows": float64(3)}
+	external := externalMetadata(ctx, server, metadataModel("owner"), nil, &diags)
+	want := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"ingested": types.StringValue("b"),
+		"rows":     types.StringValue("3"),
+	})
+	if !external.Equal(want) {
+		t.Errorf("got %v, want %v", external, want)
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestMergeExternalMetadata(t *testing.T) {
+	current := &marmot.Asset{Metadata: map[string]interface{}{
+		"owner":    "old",
+		"removed":  "x",
+		"secret":   "s",
+		"ingested": "keep",
+	}}
+
+	tests := map[string]struct {
+		plan, state AssetResourceModel
+		input       map[string]interface{}
+		want        map[string]interface{}
+	}{
+		"keeps external keys": {
+			plan:  metadataModel("owner"),
+			state: metadataModel("owner", "removed"),
+			input: map[string]interface{}{"owner": "new"},
+			want:  map[string]interface{}{"owner": "new", "ingested": "keep"},
+		},
+		"metadata removed from config": {
+			plan:  AssetResourceModel{},
+			state: metadataModel("owner", "removed"),
+			want:  map[string]interface{}{"ingested": "keep"},
+		},
+		"nothing declared": {
+			plan:  AssetResourceModel{},
+			state: AssetResourceModel{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			input := marmot.UpdateAssetInput{Metadata: tt.input}
+			mergeExternalMetadata(&input, current, tt.plan, tt.state, []string{"secret"}, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if !reflect.DeepEqual(input.Metadata, tt.want) {
+				t.Errorf("got %v, want %v", input.Metadata, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+const (
+	// assetBatchWindow is how long the first MRN lookup for a type and
+	// service waits for others to join it before they are resolved.
+	assetBatchWindow = 20 * time.Millisecond
+
+	// assetBatchMinSize is the smallest batch resolved through the search
+	// endpoint; smaller batches are looked up one by one.
+	assetBatchMinSize = 5
+
+	// assetBatchMaxPages caps the search pages read for one batch, so a
+	// large service isn't listed in full to find a few assets.
+	assetBatchMaxPages = 10
+)
+
+// assetLookup resolves assets for data sources and resources and caches them
+// for the life of the provider process, which is a single plan or apply.
+// Concurrent lookups of the same asset share one request, and MRN lookups for
+// the same type and service made within assetBatchWindow are resolved
+// together with a single paged search.
+//
+// Data sources read at plan time, so a missing asset stays missing for them
+// and "not found" is cached too. Resources use the view from forResources,
+// which neither caches nor trusts "not found", since an asset planned in the
+// same run may exist by the time the resource looks again.
+type assetLookup struct {
+	*assetCache
+
+	// cacheNotFound keeps "not found" results like any other; off in the
+	// view returned by forResources.
+	cacheNotFound bool
+}
+
+// assetCache is the state shared by an assetLookup and its forResources view.
+type assetCache struct {
+	client *marmot.Client
+
+	mu      sync.Mutex
+	byMRN   map[string]*assetLookupEntry
+	byID    map[string]*assetLookupEntry
+	batches map[string]*assetBatch
+	views   *assetViews
+}
+
+// assetLookupEntry is one cached lookup. asset and err are set before done
+// is closed.
+type assetLookupEntry struct {
+	done  chan struct{}
+	asset *marmot.Asset
+	err   error
+}
+
+func (e *assetLookupEntry) wait(ctx context.Context) (*marmot.Asset, error) {
+	select {
+	case <-e.done:
+		return e.asset, e.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// assetBatch collects MRN lookups for one type and service. found is set
+// before done is closed.
+type assetBatch struct {
+	assetType string
+	service   string
+	mrns      []string
+	done      chan struct{}
+	found     map[string]*marmot.Asset
+}
+
+func newAssetLookup(client *marmot.Client) *assetLookup {
+	return &assetLookup{
+		assetCache: &assetCache{
+			client:  client,
+			byMRN:   map[string]*assetLookupEntry{},
+			byID:    map[string]*assetLookupEntry{},
+			batches: map[string]*assetBatch{},
+		},
+		cacheNotFound: true,
+	}
+}
+
+// forResources returns a view of l for resources, sharing its cache but not
+// its "not found" results.
+func (l *assetLookup) forResources() *assetLookup {
+	return &assetLookup{assetCache: l.assetCache}
+}
+
+// cached returns the cached lookup of key, or nil when there is none. Views
+// that don't cache "not found" drop such a result and return nil, so the
+// asset is looked up again.
+func (l *assetLookup) cached(ctx context.Context, cache map[string]*assetLookupEntry, key string) *assetLookupEntry {
+	l.mu.Lock()
+	e, ok := cache[key]
+	l.mu.Unlock()
+	if !ok || l.cacheNotFound {
+		return e
+	}
+	if _, err := e.wait(ctx); !marmot.IsNotFound(err) {
+		return e
+	}
+
+	l.mu.Lock()
+	if cache[key] == e {
+		delete(cache, key)
+	}
+	l.mu.Unlock()
+	return nil
+}
+
+// getByID returns the asset with the given ID.
+func (l *assetLookup) getByID(ctx context.Context, id string) (*marmot.Asset, error) {
+	if e := l.cached(ctx, l.byID, id); e != nil {
+		return e.wait(ctx)
+	}
+
+	l.mu.Lock()
+	e := &assetLookupEntry{done: make(chan struct{})}
+	l.byID[id] = e
+	l.mu.Unlock()
+
+	asset, err := l.client.Assets.Get(ctx, id)
+	l.complete(e, l.byID, id, asset, err)
+	return asset, err
+}
+
+// getByMRN returns the asset with the given MRN.
+func (l *assetLookup) getByMRN(ctx context.Context, mrn string) (*marmot.Asset, error) {
+	assetType, service, name, err := parseMRN(mrn)
+	if err != nil {
+		return nil, err
+	}
+
+	if e := l.cached(ctx, l.byMRN, mrn); e != nil {
+		return e.wait(ctx)
+	}
+
+	l.mu.Lock()
+	e := &assetLookupEntry{done: make(chan struct{})}
+	l.byMRN[mrn] = e
+
+	key := assetType + "/" + service
+	b, joined := l.batches[key]
+	if !joined {
+		b = &assetBatch{assetType: assetType, service: service, done: make(chan struct{})}
+		l.batches[key] = b
+	}
+	b.mrns = append(b.mrns, mrn)
+	l.mu.Unlock()
+
+	if !joined {
+		l.flush(ctx, key, b)
+	}
+
+	var asset *marmot.Asset
+	select {
+	case <-b.done:
+		var found bool
+		if asset, found = b.found[mrn]; !found {
+			asset, err = l.client.Assets.Lookup(ctx, marmot.LookupInput{
+				Type:    assetType,
+				Service: service,
+				Name:    name,
+			})
+		}
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	l.complete(e, l.byMRN, mrn, asset, err)
+	return asset, err
+}
+
+// flush waits out the batch window, then resolves the batch through the
+// search endpoint if enough lookups joined it. Lookups the search didn't
+// find fall back to fetching the asset directly.
+func (l *assetLookup) flush(ctx context.Context, key string, b *assetBatch) {
+	defer close(b.done)
+
+	select {
+	case <-time.After(assetBatchWindow):
+	case <-ctx.Done():
+	}
+
+	l.mu.Lock()
+	delete(l.batches, key)
+	mrns := b.mrns
+	l.mu.Unlock()
+
+	if ctx.Err() != nil || len(mrns) < assetBatchMinSize {
+		return
+	}
+
+	found, err := l.search(ctx, b.assetType, b.service, mrns)
+	if err != nil {
+		tflog.Debug(ctx, "Batched asset lookup failed, looking assets up individually", map[string]interface{}{
+			"type":    b.assetType,
+			"service": b.service,
+			"error":   err.Error(),
+		})
+		return
+	}
+	b.found = found
+}
+
+// search pages through the assets of one type and service until every MRN
+// in mrns is found, caching every asset it reads along the way.
+func (l *assetLookup) search(ctx context.Context, assetType, service string, mrns []string) (map[string]*marmot.Asset, error) {
+	wanted := make(map[string]bool, len(mrns))
+	for _, mrn := range mrns {
+		wanted[mrn] = true
+	}
+
+	found := map[string]*marmot.Asset{}
+	opts := marmot.AssetSearchOptions{
+		Types:     []string{assetType},
+		Providers: []string{service},
+		Limit:     searchDefaultLimit,
+	}
+	for page := 0; page < assetBatchMaxPages && len(found) < len(wanted); page++ {
+		results, err := l.client.Assets.Search(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, asset := range results.Assets {
+			if asset == nil {
+				continue
+			}
+			if wanted[asset.Mrn] {
+				found[asset.Mrn] = asset
+			} else {
+				l.store(asset)
+			}
+		}
+
+		opts.Offset += int64(len(results.Assets))
+		if len(results.Assets) == 0 || opts.Offset >= results.Total {
+			break
+		}
+	}
+	return found, nil
+}
+
+// complete records the result of a lookup and wakes anyone waiting on it.
+// Failed lookups are dropped from the cache so a later lookup retries them,
+// except for "not found" in views that cache it.
+func (l *assetLookup) complete(e *assetLookupEntry, cache map[string]*assetLookupEntry, key string, asset *marmot.Asset, err error) {
+	l.mu.Lock()
+	e.asset, e.err = asset, err
+	if err != nil && (!l.cacheNotFound || !marmot.IsNotFound(err)) && cache[key] == e {
+		delete(cache, key)
+	}
+	if asset != nil {
+		l.storeLocked(asset)
+	}
+	l.mu.Unlock()
+	close(e.done)
+}
+
+// store caches an asset that was read without being asked for.
+func (l *assetLookup) store(asset *marmot.Asset) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.storeLocked(asset)
+}
+
+func (l *assetLookup) storeLocked(asset *marmot.Asset) {
+	e := &assetLookupEntry{done: make(chan struct{}), asset: asset}
+	close(e.done)
+	if _, ok := l.byID[asset.ID]; !ok && asset.ID != "" {
+		l.byID[asset.ID] = e
+	}
+	if _, ok := l.byMRN[asset.Mrn]; !ok && asset.Mrn != "" {
+		l.byMRN[asset.Mrn] = e
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+func TestAssetLookupNotFound(t *testing.T) {
+	var requests atomic.Int32
+	var exists atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if !exists.Load() {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"asset not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"a1","mrn":"mrn://table/postgres/orders"}`))
+	}))
+	defer server.Close()
+
+	client, err := marmot.NewClient(marmot.ClientOptions{Host: server.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataSources := newAssetLookup(client)
+	resources := dataSources.forResources()
+	ctx := t.Context()
+
+	if _, err := dataSources.getByID(ctx, "a1"); !marmot.IsNotFound(err) {
+		t.Fatalf("data source lookup: got %v, want not found", err)
+	}
+	if _, err := dataSources.getByID(ctx, "a1"); !marmot.IsNotFound(err) || requests.Load() != 1 {
+		t.Fatalf("data source lookup again: got %v after %d requests, want the cached not found", err, requests.Load())
+	}
+
+	exists.Store(true)
+	asset, err := resources.getByID(ctx, "a1")
+	if err != nil || asset == nil || asset.ID != "a1" {
+		t.Fatalf("resource lookup: got %v, %v, want the asset", asset, err)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("resource lookup: got %d requests, want the cached not found looked up again", requests.Load())
+	}
+
+	if _, err := dataSources.getByID(ctx, "a1"); err != nil || requests.Load() != 2 {
+		t.Errorf("data source lookup after the resource found it: got %v after %d requests, want the cached asset", err, requests.Load())
+	}
+
+	exists.Store(false)
+	for range 2 {
+		if _, err := resources.getByID(ctx, "missing"); !marmot.IsNotFound(err) {
+			t.Fatalf("resource lookup of a missing asset: got %v, want not found", err)
+		}
+	}
+	if requests.Load() != 4 {
+		t.Errorf("resource lookups of a missing asset: got %d requests, want each sent", requests.Load())
+	}
+}
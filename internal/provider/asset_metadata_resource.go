@@ -0,0 +1,335 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AssetMetadataResource{}
+var _ resource.ResourceWithConfigValidators = &AssetMetadataResource{}
+
+func NewAssetMetadataResource() resource.Resource {
+	return &AssetMetadataResource{}
+}
+
+// AssetMetadataResource defines the resource implementation.
+type AssetMetadataResource struct {
+	client *marmot.Client
+}
+
+// AssetMetadataResourceModel describes the asset metadata resource data model.
+type AssetMetadataResourceModel struct {
+	AssetMRN types.String `tfsdk:"asset_mrn"`
+	AssetID  types.String `tfsdk:"asset_id"`
+	Metadata types.Map    `tfsdk:"metadata"`
+}
+
+func (r *AssetMetadataResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset_metadata"
+}
+
+func (r *AssetMetadataResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sets metadata keys on an asset that is managed elsewhere, such as one " +
+			"created by ingestion, without managing the rest of the asset. Only the keys in " +
+			"`metadata` are written, and only those keys are removed on destroy; other keys " +
+			"on the asset are left as they are.\n\n" +
+			"Don't declare the same key in more than one place, including a `marmot_asset` " +
+			"for the same asset, or each apply will overwrite the other.",
+
+		Attributes: map[string]schema.Attribute{
+			"asset_mrn": schema.StringAttribute{
+				MarkdownDescription: "MRN of the asset, e.g. `mrn://table/postgresql/orders`. " +
+					"Conflicts with `asset_id`; computed when that is set.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					isMRN(),
+				},
+			},
+			"asset_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the asset, such as `marmot_asset.x.id`. Conflicts with " +
+					"`asset_mrn`; computed when that is set.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"metadata": schema.MapAttribute{
+				MarkdownDescription: "Metadata keys to set on the asset, as string values. A key " +
+					"removed from here is removed from the asset.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *AssetMetadataResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("asset_mrn"), path.MatchRoot("asset_id")),
+	}
+}
+
+func (r *AssetMetadataResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *AssetMetadataResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data AssetMetadataResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assetID, err := resolveAssetRefs(ctx, r.client, &data.AssetMRN, &data.AssetID)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to find asset", err)
+		return
+	}
+
+	set := mapStrings(ctx, data.Metadata, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.updateMetadata(ctx, assetID, set, nil, &resp.Diagnostics); err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to set asset metadata", err)
+		return
+	}
+
+	tflog.Info(ctx, "Asset metadata set", map[string]any{
+		"asset_id": assetID,
+		"keys":     sortedKeys(set),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AssetMetadataResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data AssetMetadataResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, err := r.client.Assets.Get(ctx, data.AssetID.ValueString())
+	if err != nil {
+		if marmot.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to read asset", err)
+		return
+	}
+
+	// Only the declared keys are read back. A key that was removed or changed
+	// on the server shows up as drift and is written again on apply.
+	serverMeta := convert.Object(asset.Metadata)
+	current := map[string]string{}
+	for k, v := range mapStrings(ctx, data.Metadata, &resp.Diagnostics) {
+		raw, ok := serverMeta[k]
+		switch {
+		case !ok || raw == nil:
+		case metadataValueMatches(v, raw):
+			current[k] = v
+		default:
+			current[k] = fmt.Sprintf("%v", raw)
+		}
+	}
+
+	metadata, diags := types.MapValueFrom(ctx, types.StringType, current)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Metadata = metadata
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AssetMetadataResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data, state AssetMetadataResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set := mapStrings(ctx, data.Metadata, &resp.Diagnostics)
+	var removed []string
+	for k := range mapStrings(ctx, state.Metadata, &resp.Diagnostics) {
+		if _, ok := set[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateMetadata(ctx, data.AssetID.ValueString(), set, removed, &resp.Diagnostics); err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to update asset metadata", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AssetMetadataResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data AssetMetadataResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	removed := sortedKeys(mapStrings(ctx, data.Metadata, &resp.Diagnostics))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.updateMetadata(ctx, data.AssetID.ValueString(), nil, removed, &resp.Diagnostics)
+	if err != nil && !marmot.IsNotFound(err) {
+		addClientError(ctx, &resp.Diagnostics, "Unable to remove asset metadata", err)
+		return
+	}
+
+	tflog.Info(ctx, "Asset metadata removed", map[string]any{
+		"asset_id": data.AssetID.ValueString(),
+		"keys":     removed,
+	})
+}
+
+// assetMetadataLocks serializes metadata and column updates per asset ID. An
+// update reads the asset, changes part of it, and writes the whole asset
+// back, so two resources writing the same asset in parallel would otherwise
+// drop each other's changes.
+var assetMetadataLocks sync.Map
+
+// updateMetadata sets the keys in set and deletes the keys in removed on the
+// asset, leaving its other metadata and fields as they are.
+func (r *AssetMetadataResource) updateMetadata(ctx context.Context, assetID string, set map[string]string, removed []string, diags *diag.Diagnostics) error {
+	mu, _ := assetMetadataLocks.LoadOrStore(assetID, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+
+	asset, err := r.client.Assets.Get(ctx, assetID)
+	if err != nil {
+		return err
+	}
+
+	metadata := convert.CopyObject(asset.Metadata)
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	for _, k := range removed {
+		delete(metadata, k)
+	}
+	for k, v := range set {
+		metadata[k] = v
+	}
+
+	if len(metadata) == 0 {
+		// The SDK leaves empty metadata out of the update, which the server
+		// reads as "unchanged".
+		diags.AddWarning(
+			"Asset Metadata Not Cleared",
+			fmt.Sprintf("Removing the last metadata keys from asset %s isn't supported by the Marmot API; "+
+				"the keys %v are left on the asset.", assetID, removed),
+		)
+		return nil
+	}
+
+	// The update replaces every field it is given, and lists that are left
+	// out are cleared, so the rest of the asset is sent back as it was read.
+	_, err = r.client.Assets.Update(ctx, assetID, marmot.UpdateAssetInput{
+		Name:            asset.Name,
+		Type:            asset.Type,
+		Description:     asset.Description,
+		UserDescription: asset.UserDescription,
+		Providers:       asset.Providers,
+		Tags:            asset.Tags,
+		Metadata:        metadata,
+		Schema:          asset.Schema,
+		ExternalLinks:   asset.ExternalLinks,
+		Sources:         asset.Sources,
+		Environments:    asset.Environments,
+	})
+	return err
+}
+
+// mapStrings returns the elements of a string map, or nil when it is null or
+// unknown.
+func mapStrings(ctx context.Context, m types.Map, diags *diag.Diagnostics) map[string]string {
+	if m.IsNull() || m.IsUnknown() {
+		return nil
+	}
+	var out map[string]string
+	diags.Append(m.ElementsAs(ctx, &out, false)...)
+	return out
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
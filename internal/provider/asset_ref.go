@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// An asset reference names an asset either by MRN or by ID. Resources that
+// point at another asset accept both and store both once resolved, and
+// imports of assets take either.
+
+// isMRNRef reports whether ref is written as an MRN rather than an asset ID.
+func isMRNRef(ref string) bool {
+	return strings.HasPrefix(ref, mrnPrefix)
+}
+
+// findAssetRef returns the asset that ref names.
+func findAssetRef(ctx context.Context, client *marmot.Client, ref string) (*marmot.Asset, error) {
+	if !isMRNRef(ref) {
+		return client.Assets.Get(ctx, ref)
+	}
+	assetType, service, name, err := parseMRN(ref)
+	if err != nil {
+		return nil, err
+	}
+	return client.Assets.Lookup(ctx, marmot.LookupInput{Type: assetType, Service: service, Name: name})
+}
+
+// resolveAssetRefs fills in whichever of mrn and id is unset from the asset
+// the other names, and returns that asset's ID.
+func resolveAssetRefs(ctx context.Context, client *marmot.Client, mrn, id *types.String) (string, error) {
+	ref := id.ValueString()
+	if !mrn.IsNull() && !mrn.IsUnknown() {
+		ref = mrn.ValueString()
+	}
+	asset, err := findAssetRef(ctx, client, ref)
+	if err != nil {
+		return "", err
+	}
+	if asset.Mrn == "" {
+		return "", fmt.Errorf("asset %s has no MRN", asset.ID)
+	}
+	*mrn = types.StringValue(asset.Mrn)
+	*id = types.StringValue(asset.ID)
+	return asset.ID, nil
+}
+
+// importAssetRef imports an asset resource by its ID or its MRN, which is
+// resolved to the ID.
+func importAssetRef(ctx context.Context, client *marmot.Client, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !isMRNRef(req.ID) {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	asset, err := findAssetRef(ctx, client, req.ID)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to find asset to import", err)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), asset.ID)...)
+}
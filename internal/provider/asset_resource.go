@@ -5,28 +5,44 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AssetResource{}
 var _ resource.ResourceWithImportState = &AssetResource{}
+var _ resource.ResourceWithModifyPlan = &AssetResource{}
+var _ resource.ResourceWithUpgradeState = &AssetResource{}
+var _ resource.ResourceWithValidateConfig = &AssetResource{}
 
 func NewAssetResource() resource.Resource {
 	return &AssetResource{}
@@ -35,6 +51,21 @@ func NewAssetResource() resource.Resource {
 // AssetResource defines the resource implementation.
 type AssetResource struct {
 	client *marmot.Client
+	api    *apiClient
+
+	// ignoreLabelCase keeps the configured spelling of services and tags
+	// that Marmot returns in a different case.
+	ignoreLabelCase bool
+
+	// uiBaseURL is where the Marmot UI is served, for the url attribute.
+	uiBaseURL string
+
+	// defaultEnvironments are the provider's default_environments, added
+	// to every asset that doesn't configure the same key.
+	defaultEnvironments map[string]AssetEnvironmentModel
+
+	// checkExternalLinks is the provider's check_external_links setting.
+	checkExternalLinks bool
 }
 
 // ExternalLink represents a link to an external resource.
@@ -46,9 +77,10 @@ type ExternalLinkModel struct {
 
 // AssetSource represents a source for an asset.
 type AssetSourceModel struct {
-	Name       types.String `tfsdk:"name"`
-	Priority   types.Int64  `tfsdk:"priority"`
-	Properties types.Map    `tfsdk:"properties"`
+	Name           types.String         `tfsdk:"name"`
+	Priority       types.Int64          `tfsdk:"priority"`
+	Properties     types.Map            `tfsdk:"properties"`
+	PropertiesJSON jsontypes.Normalized `tfsdk:"properties_json"`
 }
 
 // AssetEnvironment represents an environment for an asset.
@@ -60,17 +92,27 @@ type AssetEnvironmentModel struct {
 
 // AssetResourceModel describes the asset resource data model.
 type AssetResourceModel struct {
-	Name            types.String                     `tfsdk:"name"`
-	Type            types.String                     `tfsdk:"type"`
-	Description     types.String                     `tfsdk:"description"`
-	UserDescription types.String                     `tfsdk:"user_description"`
-	Services        types.Set                        `tfsdk:"services"`
-	Tags            types.Set                        `tfsdk:"tags"`
-	Metadata        types.Map                        `tfsdk:"metadata"`
-	Schema          types.Map                        `tfsdk:"schema"`
-	ExternalLinks   []ExternalLinkModel              `tfsdk:"external_links"`
-	Sources         []AssetSourceModel               `tfsdk:"sources"`
-	Environments    map[string]AssetEnvironmentModel `tfsdk:"environments"`
+	Name                     types.String                     `tfsdk:"name"`
+	Type                     types.String                     `tfsdk:"type"`
+	Description              markdownValue                    `tfsdk:"description"`
+	UserDescription          markdownValue                    `tfsdk:"user_description"`
+	DocumentationFile        types.String                     `tfsdk:"documentation_file"`
+	Services                 types.Set                        `tfsdk:"services"`
+	Tags                     types.Set                        `tfsdk:"tags"`
+	StructuredTags           []StructuredTagModel             `tfsdk:"structured_tags"`
+	Metadata                 types.Map                        `tfsdk:"metadata"`
+	MetadataJSON             jsontypes.Normalized             `tfsdk:"metadata_json"`
+	SensitiveMetadata        types.Map                        `tfsdk:"sensitive_metadata"`
+	SensitiveMetadataVersion types.String                     `tfsdk:"sensitive_metadata_version"`
+	Schema                   types.Map                        `tfsdk:"schema"`
+	ExternalLinks            []ExternalLinkModel              `tfsdk:"external_links"`
+	Sources                  []AssetSourceModel               `tfsdk:"sources"`
+	Environments             map[string]AssetEnvironmentModel `tfsdk:"environments"`
+	ServerManagedFields      types.Set                        `tfsdk:"server_managed_fields"`
+	AllowAdopt               types.Bool                       `tfsdk:"allow_adopt"`
+	ProtectIfDownstream      types.Bool                       `tfsdk:"protect_if_downstream"`
+	ForceDestroy             types.Bool                       `tfsdk:"force_destroy"`
+	OnDestroy                types.String                     `tfsdk:"on_destroy"`
 
 	ID            types.String `tfsdk:"id"`
 	CreatedAt     types.String `tfsdk:"created_at"`
@@ -78,11 +120,22 @@ type AssetResourceModel struct {
 	UpdatedAt     types.String `tfsdk:"updated_at"`
 	LastSyncAt    types.String `tfsdk:"last_sync_at"`
 	MRN           types.String `tfsdk:"mrn"`
+	URL           types.String `tfsdk:"url"`
 	ParentMRN     types.String `tfsdk:"parent_mrn"`
 	Query         types.String `tfsdk:"query"`
 	QueryLanguage types.String `tfsdk:"query_language"`
 	HasRunHistory types.Bool   `tfsdk:"has_run_history"`
 	IsStub        types.Bool   `tfsdk:"is_stub"`
+
+	UpstreamCount    types.Int64  `tfsdk:"upstream_count"`
+	DownstreamCount  types.Int64  `tfsdk:"downstream_count"`
+	ExternalMetadata types.Map    `tfsdk:"external_metadata"`
+	ContentHash      types.String `tfsdk:"content_hash"`
+
+	DocumentationHash types.String `tfsdk:"documentation_hash"`
+
+	UpstreamMRNs   types.Set `tfsdk:"upstream_mrns"`
+	DownstreamMRNs types.Set `tfsdk:"downstream_mrns"`
 }
 
 func (r *AssetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -91,7 +144,10 @@ func (r *AssetResource) Metadata(ctx context.Context, req resource.MetadataReque
 
 func (r *AssetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Asset resource",
+		Version: assetSchemaVersion,
+		MarkdownDescription: "Asset resource.\n\n" +
+			"Optional attributes left out of the configuration aren't managed: updates keep " +
+			"whatever value they have in Marmot, and they aren't tracked in state.",
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
@@ -109,19 +165,40 @@ func (r *AssetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"description": schema.StringAttribute{
-				MarkdownDescription: "Asset description",
-				Optional:            true,
+				MarkdownDescription: "Asset description, in Markdown. Differences in line endings " +
+					"and surrounding whitespace, such as a heredoc's trailing newline, are ignored.",
+				CustomType: markdownType{},
+				Optional:   true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtMost(1000),
 				},
 			},
 			"user_description": schema.StringAttribute{
-				MarkdownDescription: "User-provided description for the asset",
-				Optional:            true,
+				MarkdownDescription: "User-provided description for the asset, in Markdown. " +
+					"Whitespace is compared as for `description`.",
+				CustomType: markdownType{},
+				Optional:   true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtMost(2000),
 				},
 			},
+			"documentation_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a Markdown file uploaded as the asset's documentation " +
+					"page, such as `\"${path.module}/docs/orders.md\"`. The file is read at plan time " +
+					"and its hash kept in `documentation_hash`, so editing the file alone plans an " +
+					"update. Marmot can't delete documentation, so removing this attribute leaves the " +
+					"last upload in place.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"documentation_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 of the documentation uploaded from `documentation_file`, " +
+					"ignoring whitespace differences as for `description`. Edits made to the " +
+					"documentation in Marmot change it, which plans an upload that restores the file.",
+				Computed: true,
+			},
 			"services": schema.SetAttribute{
 				MarkdownDescription: "Services associated with the asset",
 				Required:            true,
@@ -136,22 +213,73 @@ func (r *AssetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:            true,
 				ElementType:         types.StringType,
 				Validators: []validator.Set{
-					setvalidator.ValueStringsAre(stringvalidator.LengthBetween(1, 100)),
+					setvalidator.ValueStringsAre(stringvalidator.LengthBetween(1, maxTagLength)),
+				},
+			},
+			"structured_tags": schema.SetNestedAttribute{
+				MarkdownDescription: "Key/value tags associated with the asset, such as a cost center " +
+					"or data tier. Each is stored in Marmot as a `key=value` tag alongside `tags`, and " +
+					"plans show changes per key and value. Tags read back from Marmot are taken for " +
+					"structured tags when their key is one set here.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							MarkdownDescription: "Tag key. May not contain `=`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "Tag value",
+							Required:            true,
+						},
+					},
 				},
 			},
 			"metadata": schema.MapAttribute{
-				MarkdownDescription: "Metadata associated with the asset",
-				Optional:            true,
-				ElementType:         types.StringType,
+				MarkdownDescription: "Metadata associated with the asset, as string values. Use " +
+					"`metadata_json` instead when values are booleans, numbers, lists, or objects.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.ConflictsWith(path.MatchRoot("metadata_json")),
+				},
+			},
+			"metadata_json": schema.StringAttribute{
+				MarkdownDescription: "Metadata associated with the asset as a JSON object, keeping " +
+					"booleans, numbers, lists, and nested objects as typed values. Use `jsonencode()` " +
+					"to build it from HCL. Conflicts with `metadata`.",
+				Optional:   true,
+				CustomType: jsontypes.NormalizedType{},
+			},
+			"sensitive_metadata": schema.MapAttribute{
+				MarkdownDescription: "Metadata sent to Marmot alongside `metadata` but never kept in " +
+					"state or shown in plans, for values such as connection strings with embedded " +
+					"credentials. Write-only, so it needs Terraform 1.11 or newer. Keys must not also " +
+					"appear in `metadata`. Bump `sensitive_metadata_version` to push changed values.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				ElementType: types.StringType,
+			},
+			"sensitive_metadata_version": schema.StringAttribute{
+				MarkdownDescription: "A version marker for `sensitive_metadata`. Terraform can't diff a " +
+					"write-only value, so changing this is what triggers an update that sends the " +
+					"current `sensitive_metadata`.",
+				Optional: true,
 			},
 			"schema": schema.MapAttribute{
 				MarkdownDescription: "Schema associated with the asset",
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
-			"external_links": schema.ListNestedAttribute{
-				MarkdownDescription: "External links associated with the asset",
-				Optional:            true,
+			"external_links": schema.SetNestedAttribute{
+				MarkdownDescription: "External links associated with the asset. URLs may use the " +
+					"`{{mrn}}` and `{{name}}` placeholders, which are replaced with the asset's MRN and " +
+					"name, URL-escaped, before the links are sent.",
+				Optional: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"icon": schema.StringAttribute{
@@ -170,12 +298,13 @@ func (r *AssetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 							Required:            true,
 							Validators: []validator.String{
 								stringvalidator.LengthBetween(1, 2048),
+								linkURLValidator{},
 							},
 						},
 					},
 				},
 			},
-			"sources": schema.ListNestedAttribute{
+			"sources": schema.SetNestedAttribute{
 				MarkdownDescription: "Sources associated with the asset",
 				Optional:            true,
 				NestedObject: schema.NestedAttributeObject{
@@ -192,16 +321,28 @@ func (r *AssetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 							Optional:            true,
 						},
 						"properties": schema.MapAttribute{
-							MarkdownDescription: "Properties of the source",
-							Optional:            true,
-							ElementType:         types.StringType,
+							MarkdownDescription: "Properties of the source, as string values. Use " +
+								"`properties_json` instead when values are booleans, numbers, lists, or objects.",
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.Map{
+								mapvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("properties_json")),
+							},
+						},
+						"properties_json": schema.StringAttribute{
+							MarkdownDescription: "Properties of the source as a JSON object, keeping typed " +
+								"and nested values such as those written by ingestion connectors. Use " +
+								"`jsonencode()` to build it from HCL. Conflicts with `properties`.",
+							Optional:   true,
+							CustomType: jsontypes.NormalizedType{},
 						},
 					},
 				},
 			},
 			"environments": schema.MapNestedAttribute{
-				MarkdownDescription: "Environments associated with the asset",
-				Optional:            true,
+				MarkdownDescription: "Environments associated with the asset. The provider's " +
+					"`default_environments` are added for keys not set here.",
+				Optional: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"name": schema.StringAttribute{
@@ -226,6 +367,52 @@ func (r *AssetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					},
 				},
 			},
+			"allow_adopt": schema.BoolAttribute{
+				MarkdownDescription: "Take over an existing asset instead of failing when Marmot " +
+					"reports that one with the same type, service, and name already exists, for " +
+					"example because an ingestion plugin created it. The existing asset is updated " +
+					"with the configured fields. Only affects create.",
+				Optional: true,
+			},
+			"protect_if_downstream": schema.BoolAttribute{
+				MarkdownDescription: "Refuse to delete the asset while lineage shows other assets " +
+					"consuming it downstream. The error lists the consumers. Set `force_destroy` to " +
+					"delete anyway.",
+				Optional: true,
+			},
+			"force_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Delete the asset even when `protect_if_downstream` is set and it " +
+					"has downstream consumers. Like other settings, it must be applied before the " +
+					"destroy for it to take effect.",
+				Optional: true,
+			},
+			"on_destroy": schema.StringAttribute{
+				MarkdownDescription: "What destroying the resource does to the asset: `delete` (the " +
+					"default) deletes it, and `archive` leaves it and its lineage in Marmot, tagged " +
+					"`" + assetArchivedTag + "`, and only stops managing it. Marmot has no archived " +
+					"state of its own, so the tag is what marks the asset. `protect_if_downstream` " +
+					"doesn't apply to archiving. Like other settings, it must be applied before the " +
+					"destroy for it to take effect.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(assetOnDestroyDelete, assetOnDestroyArchive),
+				},
+			},
+			"server_managed_fields": schema.SetAttribute{
+				MarkdownDescription: "Attributes whose changes on the server should never show up as " +
+					"drift, for assets that scanners or ingestion plugins also enrich. Takes attribute " +
+					"names such as `description`, `tags`, or `last_sync_at`, and single metadata keys " +
+					"as `metadata.<key>`, e.g. `metadata.row_count`. Listed attributes keep the value " +
+					"last applied, and when left unset in configuration, updates send the server's " +
+					"current value instead of clearing it. An update still fails when the asset was " +
+					"edited outside Terraform since it was last read, unless only listed attributes " +
+					"changed.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(serverManagedFieldValidator()),
+				},
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Asset ID",
 				Computed:            true,
@@ -268,6 +455,14 @@ func (r *AssetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "Link to the asset's page in the Marmot UI, derived from the " +
+					"provider's `host` and `base_path`",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"parent_mrn": schema.StringAttribute{
 				MarkdownDescription: "Parent asset's Marmot Resource Name",
 				Computed:            true,
@@ -288,6 +483,54 @@ func (r *AssetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "Whether the asset is a stub",
 				Computed:            true,
 			},
+			"upstream_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of assets lineage shows feeding directly into this one",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"downstream_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of assets lineage shows reading directly from this one",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"upstream_mrns": schema.SetAttribute{
+				MarkdownDescription: "MRNs of the assets lineage shows feeding directly into this one",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"downstream_mrns": schema.SetAttribute{
+				MarkdownDescription: "MRNs of the assets lineage shows reading directly from this one",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"content_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 of the attributes this resource sends to Marmot, known at " +
+					"plan time. It changes whenever one of them does, so other resources can use it " +
+					"in `replace_triggered_by` or `triggers`. `sensitive_metadata` is covered " +
+					"through `sensitive_metadata_version`.",
+				Computed: true,
+			},
+			"external_metadata": schema.MapAttribute{
+				MarkdownDescription: "Metadata on the asset that this resource doesn't declare, such " +
+					"as keys written by ingestion or `marmot_asset_metadata`. It is read-only: " +
+					"these keys are never changed or removed by this resource. Values that " +
+					"aren't strings are JSON-encoded.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -297,19 +540,26 @@ func (r *AssetResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*marmot.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *marmot.Client, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.api = data.api
+	r.ignoreLabelCase = data.ignoreLabelCase
+	r.uiBaseURL = data.uiBaseURL
+	r.defaultEnvironments = data.defaultEnvironments
+	r.checkExternalLinks = data.checkExternalLinks
 }
 
 func (r *AssetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data AssetResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -319,13 +569,61 @@ func (r *AssetResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	input, diags := r.toCreateRequest(ctx, data)
 	resp.Diagnostics.Append(diags...)
+	input.Environments = r.addDefaultEnvironments(input.Environments, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sensitive, diags := r.sensitiveMetadata(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	input.Metadata, diags = mergeSensitiveMetadata(input.Metadata, sensitive)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withSensitiveLogValues(ctx, sensitiveValues(sensitive)...)
 
+	started := time.Now()
 	asset, err := r.client.Assets.Create(ctx, input)
+	if err != nil && createOutcomeUnknown(err) {
+		if created := r.recoverCreatedAsset(ctx, data, input.Providers, started); created != nil {
+			asset, err = created, nil
+		}
+	}
+	if err != nil && data.AllowAdopt.ValueBool() && isConflict(err) {
+		// An asset with this identity already exists, typically created by
+		// an ingestion plugin; take it over with the configured fields.
+		existing, findErr := r.findAdoptable(ctx, data, input.Providers)
+		if findErr != nil {
+			addClientError(ctx, &resp.Diagnostics, "Unable to find existing asset to adopt", findErr)
+			return
+		}
+		if existing != nil {
+			update, diags := r.toUpdateRequest(ctx, data)
+			resp.Diagnostics.Append(diags...)
+			update.Metadata, diags = mergeSensitiveMetadata(update.Metadata, sensitive)
+			resp.Diagnostics.Append(diags...)
+			managed := parseServerManagedFields(ctx, data.ServerManagedFields, &resp.Diagnostics)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			managed.preserveServerValues(&update, existing, data)
+			if !managed.fields["environments"] {
+				update.Environments = r.addDefaultEnvironments(update.Environments, data, &resp.Diagnostics)
+			}
+
+			tflog.Info(ctx, "Adopting existing asset", map[string]interface{}{
+				"id":  existing.ID,
+				"mrn": existing.Mrn,
+			})
+			asset, err = r.client.Assets.Update(ctx, existing.ID, update)
+		}
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create asset: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to create asset", err)
 		return
 	}
 
@@ -334,7 +632,44 @@ func (r *AssetResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	// Links using {{mrn}} can only be expanded once the server has assigned
+	// the MRN, so send them again now.
+	if linksNeedMRN(data.ExternalLinks) {
+		data.MRN = types.StringValue(asset.Mrn)
+		relinked, err := r.updateExternalLinks(ctx, asset, data, sensitive, &resp.Diagnostics)
+		if err != nil {
+			// The asset exists; keep it in state so it isn't orphaned.
+			applyComputedFields(&data, asset)
+			data.ExternalMetadata = externalMetadata(ctx, convert.CopyObject(asset.Metadata), data, sortedKeys(sensitive), &resp.Diagnostics)
+			data.DocumentationHash = types.StringValue("")
+			data.URL = assetURL(r.uiBaseURL, data.MRN.ValueString())
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			resp.Diagnostics.Append(setContentHash(ctx, &resp.State, resp.State.Raw)...)
+			addClientError(ctx, &resp.Diagnostics, "Unable to set asset external links", err)
+			return
+		}
+		asset = relinked
+	}
+
 	applyComputedFields(&data, asset)
+	data.ExternalMetadata = externalMetadata(ctx, convert.CopyObject(asset.Metadata), data, sortedKeys(sensitive), &resp.Diagnostics)
+	resp.Diagnostics.Append(setSensitiveKeys(ctx, resp.Private, sensitiveMetadataPrivateKey, sensitive)...)
+	managed := parseServerManagedFields(ctx, data.ServerManagedFields, &resp.Diagnostics)
+	resp.Diagnostics.Append(setAssetVersion(ctx, resp.Private, asset, managed, sortedKeys(sensitive))...)
+	data.URL = assetURL(r.uiBaseURL, data.MRN.ValueString())
+	r.applyLineageCounts(ctx, &data, &resp.Diagnostics, true)
+	if err := r.uploadDocumentation(ctx, &data, &resp.Diagnostics); err != nil {
+		// The asset exists; keep it in state so the upload is retried.
+		data.DocumentationHash = types.StringValue("")
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		resp.Diagnostics.Append(setContentHash(ctx, &resp.State, resp.State.Raw)...)
+		addClientError(ctx, &resp.Diagnostics, "Unable to upload asset documentation", err)
+		return
+	}
+
+	if r.checkExternalLinks {
+		checkExternalLinks(ctx, data.ExternalLinks, data.MRN.ValueString(), data.Name.ValueString(), &resp.Diagnostics)
+	}
 
 	tflog.Info(ctx, "Asset created", map[string]interface{}{
 		"id":   data.ID.ValueString(),
@@ -342,9 +677,12 @@ func (r *AssetResource) Create(ctx context.Context, req resource.CreateRequest,
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setContentHash(ctx, &resp.State, resp.State.Raw)...)
 }
 
 func (r *AssetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data AssetResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -359,20 +697,71 @@ func (r *AssetResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	asset, err := r.client.Assets.Get(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read asset: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to read asset", err)
 		return
 	}
 
-	diags := r.updateModelFromResponse(ctx, &data, asset)
+	// Keys sent through sensitive_metadata come back in the asset's metadata;
+	// drop them so they neither reach state nor show up as drift.
+	sensitiveKeys, diags := recordedSensitiveKeys(ctx, req.Private, sensitiveMetadataPrivateKey)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	metaMap := convert.Object(asset.Metadata)
+	for _, k := range sensitiveKeys {
+		delete(metaMap, k)
+	}
+
+	prior := data
+	serverMeta := convert.CopyObject(asset.Metadata)
+	managed := parseServerManagedFields(ctx, data.ServerManagedFields, &resp.Diagnostics)
+	resp.Diagnostics.Append(setAssetVersion(ctx, resp.Private, asset, managed, sensitiveKeys)...)
+	managed.filterMetadata(asset, prior, &resp.Diagnostics)
+	keepDeclaredMetadata(metaMap, prior, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.updateModelFromResponse(ctx, &data, asset)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	restoreLinkTemplates(data.ExternalLinks, prior.ExternalLinks, data.MRN.ValueString(), data.Name.ValueString())
+	r.dropDefaultEnvironments(&data, prior)
+	if r.ignoreLabelCase {
+		data.Services = keepLabelCase(ctx, data.Services, prior.Services, &resp.Diagnostics)
+		data.Tags = keepLabelCase(ctx, data.Tags, prior.Tags, &resp.Diagnostics)
+		data.StructuredTags = keepStructuredTagCase(data.StructuredTags, prior.StructuredTags)
+	}
+	managed.keepPrior(&data, prior)
+
+	// An import starts from an ID alone and reads every attribute. Otherwise,
+	// optional attributes left out of the configuration stay unset, since
+	// updates leave their server values alone.
+	if !prior.Name.IsNull() {
+		keepUnsetAttributes(&data, prior)
+	}
+	data.ExternalMetadata = externalMetadata(ctx, serverMeta, data, nil, &resp.Diagnostics)
+	if err := r.readDocumentationHash(ctx, &data); err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to read asset documentation", err)
+		return
+	}
+
+	data.URL = assetURL(r.uiBaseURL, data.MRN.ValueString())
+	r.applyLineageCounts(ctx, &data, &resp.Diagnostics, false)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setContentHash(ctx, &resp.State, resp.State.Raw)...)
 }
 
 func (r *AssetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data AssetResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -392,13 +781,77 @@ func (r *AssetResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	// Updates replace the whole asset, so sensitive_metadata is sent every
+	// time rather than only when sensitive_metadata_version changes.
+	sensitive, diags := r.sensitiveMetadata(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	input.Metadata, diags = mergeSensitiveMetadata(input.Metadata, sensitive)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withSensitiveLogValues(ctx, sensitiveValues(sensitive)...)
+
+	managed := parseServerManagedFields(ctx, data.ServerManagedFields, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API only offers a full replace, so read the asset and carry over
+	// whatever the configuration doesn't manage rather than clearing it.
+	current, err := r.client.Assets.Get(ctx, state.ID.ValueString())
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to read asset", err)
+		return
+	}
+	sentKeys, diags := recordedSensitiveKeys(ctx, req.Private, sensitiveMetadataPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(checkAssetVersion(ctx, req.Private, current, managed, sentKeys)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	mergeUnsetFromServer(&input, current, data, state)
+	mergeExternalMetadata(&input, current, data, state, sentKeys, &resp.Diagnostics)
+	managed.preserveServerValues(&input, current, data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !managed.fields["environments"] {
+		input.Environments = r.addDefaultEnvironments(input.Environments, data, &resp.Diagnostics)
+	}
+
 	asset, err := r.client.Assets.Update(ctx, state.ID.ValueString(), input)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update asset: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to update asset", err)
 		return
 	}
 
+	planned := data
 	applyComputedFields(&data, asset)
+	managed.keepPrior(&data, planned)
+	if data.ExternalMetadata.IsUnknown() {
+		data.ExternalMetadata = externalMetadata(ctx, convert.CopyObject(asset.Metadata), data, sortedKeys(sensitive), &resp.Diagnostics)
+	}
+	resp.Diagnostics.Append(setSensitiveKeys(ctx, resp.Private, sensitiveMetadataPrivateKey, sensitive)...)
+	resp.Diagnostics.Append(setAssetVersion(ctx, resp.Private, asset, managed, sortedKeys(sensitive))...)
+	data.URL = assetURL(r.uiBaseURL, data.MRN.ValueString())
+	r.applyLineageCounts(ctx, &data, &resp.Diagnostics, true)
+	if data.DocumentationHash.IsUnknown() || !data.DocumentationHash.Equal(state.DocumentationHash) {
+		if err := r.uploadDocumentation(ctx, &data, &resp.Diagnostics); err != nil {
+			data.DocumentationHash = types.StringValue("")
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			resp.Diagnostics.Append(setContentHash(ctx, &resp.State, resp.State.Raw)...)
+			addClientError(ctx, &resp.Diagnostics, "Unable to upload asset documentation", err)
+			return
+		}
+	}
+
+	if r.checkExternalLinks {
+		checkExternalLinks(ctx, data.ExternalLinks, data.MRN.ValueString(), data.Name.ValueString(), &resp.Diagnostics)
+	}
 
 	tflog.Info(ctx, "Asset updated", map[string]interface{}{
 		"id":   data.ID.ValueString(),
@@ -406,9 +859,41 @@ func (r *AssetResource) Update(ctx context.Context, req resource.UpdateRequest,
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setContentHash(ctx, &resp.State, resp.State.Raw)...)
 }
 
+func (r *AssetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var tags, structuredTags types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("structured_tags"), &structuredTags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateStructuredTags(ctx, tags, structuredTags, &resp.Diagnostics)
+}
+
+// ModifyPlan keeps computed attributes listed in server_managed_fields at
+// their prior value, marks external_metadata for refresh when the declared
+// metadata changes, and plans documentation_hash and content_hash.
+func (r *AssetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanForServerManaged(ctx, req, resp)
+	modifyPlanForExternalMetadata(ctx, req, resp)
+	modifyPlanForDocumentation(ctx, resp)
+	if !resp.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(setContentHash(ctx, &resp.Plan, resp.Plan.Raw)...)
+	}
+}
+
+// on_destroy values, and the tag an archived asset is given.
+const (
+	assetOnDestroyDelete  = "delete"
+	assetOnDestroyArchive = "archive"
+	assetArchivedTag      = "archived"
+)
+
 func (r *AssetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data AssetResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -416,8 +901,40 @@ func (r *AssetResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	if data.OnDestroy.ValueString() == assetOnDestroyArchive {
+		// The asset stays, so lineage through it is kept; it is only tagged
+		// and removed from state.
+		err := r.client.Assets.AddTag(ctx, data.ID.ValueString(), assetArchivedTag)
+		if err != nil && !isConflict(err) && !marmot.IsNotFound(err) {
+			addClientError(ctx, &resp.Diagnostics, "Unable to archive asset", err)
+			return
+		}
+
+		tflog.Info(ctx, "Asset archived", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		return
+	}
+
+	if data.ProtectIfDownstream.ValueBool() && !data.ForceDestroy.ValueBool() {
+		_, consumers, err := r.directLineage(ctx, data)
+		if err != nil {
+			addClientError(ctx, &resp.Diagnostics, "Unable to read asset lineage", err)
+			return
+		}
+		if len(consumers) > 0 {
+			resp.Diagnostics.AddError(
+				"Asset Has Downstream Consumers",
+				fmt.Sprintf("Asset %s has protect_if_downstream set and %d downstream consumers:\n\n  - %s\n\n"+
+					"Remove their lineage first, or set force_destroy = true and apply before destroying.",
+					data.MRN.ValueString(), len(consumers), strings.Join(consumers, "\n  - ")),
+			)
+			return
+		}
+	}
+
 	if err := r.client.Assets.Delete(ctx, data.ID.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete asset: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to delete asset", err)
 		return
 	}
 
@@ -426,8 +943,107 @@ func (r *AssetResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	})
 }
 
+// ImportState imports an asset by its ID or its MRN.
 func (r *AssetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importAssetRef(ctx, r.client, req, resp)
+}
+
+// directLineage returns the sorted MRNs of the assets that lineage shows
+// directly upstream and downstream of the asset.
+func (r *AssetResource) directLineage(ctx context.Context, data AssetResourceModel) (upstream, downstream []string, err error) {
+	lineage, err := r.client.Lineage.Get(ctx, data.ID.ValueString(), marmot.LineageOptions{Direction: "both"})
+	if err != nil {
+		if marmot.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	mrn := data.MRN.ValueString()
+	seenUp, seenDown := map[string]bool{}, map[string]bool{}
+	for _, edge := range lineage.Edges {
+		switch {
+		case edge == nil || edge.Source == edge.Target:
+		case edge.Target == mrn && !seenUp[edge.Source]:
+			seenUp[edge.Source] = true
+			upstream = append(upstream, edge.Source)
+		case edge.Source == mrn && !seenDown[edge.Target]:
+			seenDown[edge.Target] = true
+			downstream = append(downstream, edge.Target)
+		}
+	}
+	sort.Strings(upstream)
+	sort.Strings(downstream)
+	return upstream, downstream, nil
+}
+
+// applyLineageCounts sets the upstream and downstream attributes from the
+// asset's current lineage. After an apply, failing to read it only warns,
+// since the asset change itself went through; the attributes are then left
+// null until the next refresh.
+func (r *AssetResource) applyLineageCounts(ctx context.Context, model *AssetResourceModel, diags *diag.Diagnostics, warnOnly bool) {
+	model.UpstreamCount = types.Int64Null()
+	model.DownstreamCount = types.Int64Null()
+	model.UpstreamMRNs = types.SetNull(types.StringType)
+	model.DownstreamMRNs = types.SetNull(types.StringType)
+
+	upstream, downstream, err := r.directLineage(ctx, *model)
+	if err != nil {
+		if warnOnly {
+			diags.AddWarning("Client Error", clientErrorDetail(ctx, "Unable to read asset lineage", err))
+			return
+		}
+		addClientError(ctx, diags, "Unable to read asset lineage", err)
+		return
+	}
+
+	var d diag.Diagnostics
+	model.UpstreamCount = types.Int64Value(int64(len(upstream)))
+	model.DownstreamCount = types.Int64Value(int64(len(downstream)))
+	model.UpstreamMRNs, d = types.SetValueFrom(ctx, types.StringType, nonNilStrings(upstream))
+	diags.Append(d...)
+	model.DownstreamMRNs, d = types.SetValueFrom(ctx, types.StringType, nonNilStrings(downstream))
+	diags.Append(d...)
+}
+
+// updateExternalLinks re-sends a just-created asset with its external links
+// expanded against its MRN, carrying over everything else as created.
+func (r *AssetResource) updateExternalLinks(ctx context.Context, asset *marmot.Asset, data AssetResourceModel, sensitive map[string]string, diags *diag.Diagnostics) (*marmot.Asset, error) {
+	input, d := r.toUpdateRequest(ctx, data)
+	diags.Append(d...)
+	input.Metadata, d = mergeSensitiveMetadata(input.Metadata, sensitive)
+	diags.Append(d...)
+	managed := parseServerManagedFields(ctx, data.ServerManagedFields, diags)
+	if diags.HasError() {
+		return asset, nil
+	}
+	mergeUnsetFromServer(&input, asset, data, data)
+	managed.preserveServerValues(&input, asset, data)
+
+	return r.client.Assets.Update(ctx, asset.ID, input)
+}
+
+// findAdoptable returns the existing asset with the configured type and name
+// under any of the configured services, or nil when there is none.
+func (r *AssetResource) findAdoptable(ctx context.Context, data AssetResourceModel, services []string) (*marmot.Asset, error) {
+	for _, service := range services {
+		found, err := r.client.Assets.Find(ctx, marmot.LookupInput{
+			Type:    data.Type.ValueString(),
+			Service: service,
+			Name:    data.Name.ValueString(),
+		})
+		if err != nil || found != nil {
+			return found, err
+		}
+	}
+	return nil, nil
+}
+
+// isConflict reports whether err is the API's 409 response, as returned when
+// an asset with the same identity already exists.
+func isConflict(err error) bool {
+	var apiErr *marmot.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
 }
 
 func (r *AssetResource) toCreateRequest(ctx context.Context, data AssetResourceModel) (marmot.CreateAssetInput, diag.Diagnostics) {
@@ -437,18 +1053,14 @@ func (r *AssetResource) toCreateRequest(ctx context.Context, data AssetResourceM
 	diags.Append(data.Services.ElementsAs(ctx, &services, false)...)
 	sort.Strings(services)
 
-	var tags []string
-	if !data.Tags.IsNull() && !data.Tags.IsUnknown() {
-		diags.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
-		sort.Strings(tags)
-	}
+	tags := requestTags(ctx, data, &diags)
 
-	metadata, metadataDiags := r.mapToDictionary(data.Metadata)
+	metadata, metadataDiags := r.requestMetadata(data)
 	diags.Append(metadataDiags...)
 
 	schema := r.mapToStringMap(data.Schema)
 
-	externalLinks := r.convertExternalLinks(data.ExternalLinks)
+	externalLinks := r.convertExternalLinks(data.ExternalLinks, data.MRN.ValueString(), data.Name.ValueString())
 	sources := r.convertSources(data.Sources, &diags)
 	environments := r.convertEnvironments(data.Environments, &diags)
 
@@ -473,18 +1085,14 @@ func (r *AssetResource) toUpdateRequest(ctx context.Context, data AssetResourceM
 	diags.Append(data.Services.ElementsAs(ctx, &services, false)...)
 	sort.Strings(services)
 
-	var tags []string
-	if !data.Tags.IsNull() && !data.Tags.IsUnknown() {
-		diags.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
-		sort.Strings(tags)
-	}
+	tags := requestTags(ctx, data, &diags)
 
-	metadata, metadataDiags := r.mapToDictionary(data.Metadata)
+	metadata, metadataDiags := r.requestMetadata(data)
 	diags.Append(metadataDiags...)
 
 	schema := r.mapToStringMap(data.Schema)
 
-	externalLinks := r.convertExternalLinks(data.ExternalLinks)
+	externalLinks := r.convertExternalLinks(data.ExternalLinks, data.MRN.ValueString(), data.Name.ValueString())
 	sources := r.convertSources(data.Sources, &diags)
 	environments := r.convertEnvironments(data.Environments, &diags)
 
@@ -508,7 +1116,226 @@ func (r *AssetResource) toUpdateRequest(ctx context.Context, data AssetResourceM
 	}, diags
 }
 
-func (r *AssetResource) convertExternalLinks(links []ExternalLinkModel) []*marmot.AssetExternalLink {
+// mergeUnsetFromServer copies the server's current value into input for each
+// optional attribute that is unset in both plan and state, so an update only
+// replaces what the configuration manages. An attribute that was set in state
+// and is now unset was removed from the configuration, so it keeps the empty
+// value from the plan.
+func mergeUnsetFromServer(input *marmot.UpdateAssetInput, current *marmot.Asset, plan, state AssetResourceModel) {
+	if plan.Description.IsNull() && state.Description.IsNull() {
+		input.Description = current.Description
+	}
+	if plan.UserDescription.IsNull() && state.UserDescription.IsNull() {
+		input.UserDescription = current.UserDescription
+	}
+	if plan.Tags.IsNull() && state.Tags.IsNull() {
+		input.Tags = withServerTags(input.Tags, current.Tags, structuredTagKeys(plan.StructuredTags, state.StructuredTags))
+	}
+	if plan.Metadata.IsNull() && plan.MetadataJSON.IsNull() && state.Metadata.IsNull() && state.MetadataJSON.IsNull() {
+		merged := map[string]interface{}{}
+		for k, v := range convert.Object(current.Metadata) {
+			merged[k] = v
+		}
+		// All input holds here is sensitive_metadata, which still applies.
+		for k, v := range input.Metadata {
+			merged[k] = v
+		}
+		if len(merged) > 0 {
+			input.Metadata = merged
+		}
+	}
+	if plan.Schema.IsNull() && state.Schema.IsNull() {
+		input.Schema = current.Schema
+	}
+	if plan.ExternalLinks == nil && state.ExternalLinks == nil {
+		input.ExternalLinks = current.ExternalLinks
+	}
+	if plan.Sources == nil && state.Sources == nil {
+		input.Sources = current.Sources
+	}
+	if plan.Environments == nil && state.Environments == nil {
+		input.Environments = current.Environments
+	}
+}
+
+// keepUnsetAttributes sets the optional attributes that were unset in prior
+// back to unset after a read, so values that only exist on the server, and
+// that updates leave in place, don't show up as drift.
+func keepUnsetAttributes(model *AssetResourceModel, prior AssetResourceModel) {
+	if prior.Description.IsNull() {
+		model.Description = prior.Description
+	}
+	if prior.UserDescription.IsNull() {
+		model.UserDescription = prior.UserDescription
+	}
+	if prior.Tags.IsNull() {
+		model.Tags = prior.Tags
+	}
+	if prior.Metadata.IsNull() && prior.MetadataJSON.IsNull() {
+		model.Metadata = prior.Metadata
+		model.MetadataJSON = prior.MetadataJSON
+	}
+	if prior.Schema.IsNull() {
+		model.Schema = prior.Schema
+	}
+	if prior.ExternalLinks == nil {
+		model.ExternalLinks = nil
+	}
+	if prior.Sources == nil {
+		model.Sources = nil
+	}
+	if prior.Environments == nil {
+		model.Environments = nil
+	}
+}
+
+// keepLabelCase returns current with each value spelled as in prior wherever
+// the two differ only in case, so a configured "Kafka" that Marmot stores as
+// "kafka" doesn't show up as drift.
+func keepLabelCase(ctx context.Context, current, prior types.Set, diags *diag.Diagnostics) types.Set {
+	if current.IsNull() || current.IsUnknown() || prior.IsNull() || prior.IsUnknown() {
+		return current
+	}
+
+	spelling := map[string]string{}
+	for _, v := range setStrings(ctx, prior, diags) {
+		spelling[strings.ToLower(v)] = v
+	}
+
+	values := setStrings(ctx, current, diags)
+	present := make(map[string]bool, len(values))
+	for _, v := range values {
+		present[v] = true
+	}
+
+	changed := false
+	for i, v := range values {
+		if p, ok := spelling[strings.ToLower(v)]; ok && p != v && !present[p] {
+			values[i] = p
+			present[p] = true
+			changed = true
+		}
+	}
+	if !changed {
+		return current
+	}
+
+	set, d := types.SetValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
+	return set
+}
+
+// requestMetadata returns the asset metadata to send, taken from
+// metadata_json when it is set and from the string metadata map otherwise.
+func (r *AssetResource) requestMetadata(data AssetResourceModel) (map[string]interface{}, diag.Diagnostics) {
+	if data.MetadataJSON.IsNull() || data.MetadataJSON.IsUnknown() {
+		return r.mapToDictionary(data.Metadata)
+	}
+
+	var metadata map[string]interface{}
+	diags := data.MetadataJSON.Unmarshal(&metadata)
+	if len(metadata) == 0 {
+		return nil, diags
+	}
+	return metadata, diags
+}
+
+// sensitiveMetadataPrivateKey is the private state key holding the names of
+// the metadata keys that were sent through sensitive_metadata.
+const sensitiveMetadataPrivateKey = "sensitive_metadata_keys"
+
+// sensitiveMetadata reads sensitive_metadata from the config. Write-only
+// values are always null in the plan and state.
+func (r *AssetResource) sensitiveMetadata(ctx context.Context, config tfsdk.Config) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var tfMap types.Map
+	diags.Append(config.GetAttribute(ctx, path.Root("sensitive_metadata"), &tfMap)...)
+	if diags.HasError() || tfMap.IsNull() || tfMap.IsUnknown() {
+		return nil, diags
+	}
+
+	result := make(map[string]string, len(tfMap.Elements()))
+	diags.Append(tfMap.ElementsAs(ctx, &result, false)...)
+	return result, diags
+}
+
+// mergeSensitiveMetadata adds the sensitive values to the request metadata,
+// refusing keys that are already set through metadata.
+func mergeSensitiveMetadata(metadata map[string]interface{}, sensitive map[string]string) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if len(sensitive) == 0 {
+		return metadata, diags
+	}
+
+	if metadata == nil {
+		metadata = make(map[string]interface{}, len(sensitive))
+	}
+	for k, v := range sensitive {
+		if _, ok := metadata[k]; ok {
+			diags.AddAttributeError(
+				path.Root("sensitive_metadata").AtMapKey(k),
+				"Duplicate Metadata Key",
+				fmt.Sprintf("The key %q is set in both metadata and sensitive_metadata. Set it in only one of them.", k),
+			)
+			continue
+		}
+		metadata[k] = v
+	}
+	return metadata, diags
+}
+
+// privateState is the subset of the framework's private state data used to
+// track which keys were sent as sensitive values.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// sensitiveValues returns the values of a sensitive map, for masking in logs.
+func sensitiveValues(sensitive map[string]string) []string {
+	values := make([]string, 0, len(sensitive))
+	for _, v := range sensitive {
+		values = append(values, v)
+	}
+	return values
+}
+
+// setSensitiveKeys records under privateKey which keys came from a
+// write-only sensitive map, such as sensitive_metadata, so Read can leave
+// them out of state.
+func setSensitiveKeys(ctx context.Context, private privateState, privateKey string, sensitive map[string]string) diag.Diagnostics {
+	keys := make([]string, 0, len(sensitive))
+	for k := range sensitive {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	value, err := json.Marshal(keys)
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Private State Error", err.Error())}
+	}
+	return private.SetKey(ctx, privateKey, value)
+}
+
+// recordedSensitiveKeys returns the keys recorded under privateKey by
+// setSensitiveKeys. Imported resources have none.
+func recordedSensitiveKeys(ctx context.Context, private privateState, privateKey string) ([]string, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, privateKey)
+	if diags.HasError() || len(value) == 0 {
+		return nil, diags
+	}
+
+	var keys []string
+	if err := json.Unmarshal(value, &keys); err != nil {
+		diags.AddError("Private State Error", fmt.Sprintf("Unable to decode %s: %s", privateKey, err))
+	}
+	return keys, diags
+}
+
+// convertExternalLinks builds the API links, expanding URL placeholders with
+// mrn and name.
+func (r *AssetResource) convertExternalLinks(links []ExternalLinkModel, mrn, name string) []*marmot.AssetExternalLink {
 	if len(links) == 0 {
 		return nil
 	}
@@ -523,7 +1350,7 @@ func (r *AssetResource) convertExternalLinks(links []ExternalLinkModel) []*marmo
 		result[i] = &marmot.AssetExternalLink{
 			Icon: icon,
 			Name: link.Name.ValueString(),
-			URL:  link.URL.ValueString(),
+			URL:  expandLinkURL(link.URL.ValueString(), mrn, name),
 		}
 	}
 	return result
@@ -536,7 +1363,13 @@ func (r *AssetResource) convertSources(sources []AssetSourceModel, diags *diag.D
 
 	result := make([]*marmot.AssetSource, len(sources))
 	for i, source := range sources {
-		props, propDiags := r.mapToDictionary(source.Properties)
+		var props map[string]interface{}
+		var propDiags diag.Diagnostics
+		if source.PropertiesJSON.IsNull() || source.PropertiesJSON.IsUnknown() {
+			props, propDiags = r.mapToDictionary(source.Properties)
+		} else {
+			propDiags = source.PropertiesJSON.Unmarshal(&props)
+		}
 		diags.Append(propDiags...)
 
 		priority := int64(0)
@@ -647,6 +1480,16 @@ func normalizeTimestamp(timestamp string) string {
 	return t.Format("2006-01-02T15:04:05.000000Z07:00")
 }
 
+// assetURL returns the asset's page in the Marmot UI, or null if unknown.
+func assetURL(uiBaseURL, mrn string) types.String {
+	assetType, service, name, err := parseMRN(mrn)
+	if err != nil || uiBaseURL == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(uiBaseURL + "/discover/" + url.PathEscape(assetType) + "/" +
+		url.PathEscape(service) + "/" + url.PathEscape(name))
+}
+
 // applyComputedFields copies the server-generated (read-only) attributes from an
 // API response onto the model, leaving every configured attribute untouched.
 // Create and Update use this so plan values, including nulls, are saved to state
@@ -689,9 +1532,9 @@ func (r *AssetResource) updateModelFromResponse(ctx context.Context, model *Asse
 	model.Name = types.StringValue(asset.Name)
 	model.Type = types.StringValue(asset.Type)
 	if asset.Description != "" {
-		model.Description = types.StringValue(asset.Description)
+		model.Description = newMarkdownValue(asset.Description)
 	} else {
-		model.Description = types.StringNull()
+		model.Description = newMarkdownNull()
 	}
 	model.CreatedAt = types.StringValue(normalizeTimestamp(asset.CreatedAt))
 	model.CreatedBy = types.StringValue(asset.CreatedBy)
@@ -700,9 +1543,9 @@ func (r *AssetResource) updateModelFromResponse(ctx context.Context, model *Asse
 
 	// New fields
 	if asset.UserDescription != "" {
-		model.UserDescription = types.StringValue(asset.UserDescription)
+		model.UserDescription = newMarkdownValue(asset.UserDescription)
 	} else {
-		model.UserDescription = types.StringNull()
+		model.UserDescription = newMarkdownNull()
 	}
 
 	if asset.ParentMrn != "" {
@@ -746,20 +1589,35 @@ func (r *AssetResource) updateModelFromResponse(ctx context.Context, model *Asse
 		model.Services = services
 	}
 
-	if len(asset.Tags) > 0 {
-		sortedTags := make([]string, len(asset.Tags))
-		copy(sortedTags, asset.Tags)
-		sort.Strings(sortedTags)
-
-		tags, diag := types.SetValueFrom(ctx, types.StringType, sortedTags)
+	// Tags with a key structured_tags manages are read into it, the rest
+	// into tags.
+	plainTags, structuredTags := splitServerTags(asset.Tags, structuredTagKeys(model.StructuredTags))
+	model.StructuredTags = structuredTags
+	if len(plainTags) > 0 {
+		tags, diag := types.SetValueFrom(ctx, types.StringType, plainTags)
 		diags.Append(diag...)
 		model.Tags = tags
 	} else {
 		model.Tags = types.SetNull(types.StringType)
 	}
 
-	if metaMap, ok := asset.Metadata.(map[string]interface{}); ok && metaMap != nil && len(metaMap) > 0 {
-		sortedMeta := r.convertMapToStringMapSorted(metaMap)
+	// Assets managed through metadata_json keep typed values; everything else,
+	// including imports, reads metadata back as strings.
+	metaMap := convert.Object(asset.Metadata)
+	if !model.MetadataJSON.IsNull() {
+		model.Metadata = types.MapNull(types.StringType)
+		if len(metaMap) > 0 {
+			encoded, err := json.Marshal(metaMap)
+			if err != nil {
+				diags.AddError("Metadata Error", fmt.Sprintf("Unable to encode asset metadata: %s", err))
+				return diags
+			}
+			model.MetadataJSON = jsontypes.NewNormalizedValue(string(encoded))
+		} else {
+			model.MetadataJSON = jsontypes.NewNormalizedValue("{}")
+		}
+	} else if len(metaMap) > 0 {
+		sortedMeta := convert.NonEmptyStringMap(metaMap)
 		metadata, diag := types.MapValueFrom(ctx, types.StringType, sortedMeta)
 		diags.Append(diag...)
 		model.Metadata = metadata
@@ -782,58 +1640,22 @@ func (r *AssetResource) updateModelFromResponse(ctx context.Context, model *Asse
 	}
 
 	if len(asset.Sources) > 0 {
-		model.Sources = r.convertModelSources(ctx, asset.Sources, &diags)
+		model.Sources = r.convertModelSources(ctx, asset.Sources, model.Sources, &diags)
 	} else {
 		model.Sources = nil
 	}
 
+	// Environments that still match the prior state keep their prior value,
+	// so a change to one key plans as a change to that key alone.
 	if len(asset.Environments) > 0 {
-		model.Environments = r.convertModelEnvironments(ctx, asset.Environments, &diags)
-	} else {
+		model.Environments = r.environmentsFromResponse(ctx, model.Environments, asset.Environments, &diags)
+	} else if len(model.Environments) > 0 {
 		model.Environments = nil
 	}
 
 	return diags
 }
 
-func (r *AssetResource) convertMapToStringMapSorted(m map[string]interface{}) map[string]string {
-	if m == nil {
-		return make(map[string]string)
-	}
-
-	result := make(map[string]string)
-
-	var keys []string
-	for k := range m {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-
-	for _, k := range keys {
-		v := m[k]
-		if v != nil {
-			switch val := v.(type) {
-			case string:
-				if val != "" {
-					result[k] = val
-				}
-			case int, int32, int64:
-				result[k] = fmt.Sprintf("%d", val)
-			case float32, float64:
-				result[k] = fmt.Sprintf("%.6f", val)
-			case bool:
-				result[k] = fmt.Sprintf("%t", val)
-			default:
-				strVal := fmt.Sprintf("%v", val)
-				if strVal != "" {
-					result[k] = strVal
-				}
-			}
-		}
-	}
-	return result
-}
-
 func (r *AssetResource) convertModelExternalLinks(links []*marmot.AssetExternalLink) []ExternalLinkModel {
 	if len(links) == 0 {
 		return []ExternalLinkModel{}
@@ -855,32 +1677,128 @@ func (r *AssetResource) convertModelExternalLinks(links []*marmot.AssetExternalL
 	return result
 }
 
-func (r *AssetResource) convertModelSources(ctx context.Context, sources []*marmot.AssetSource, diags *diag.Diagnostics) []AssetSourceModel {
+func (r *AssetResource) convertModelSources(ctx context.Context, sources []*marmot.AssetSource, prior []AssetSourceModel, diags *diag.Diagnostics) []AssetSourceModel {
 	if len(sources) == 0 {
 		return []AssetSourceModel{}
 	}
 
+	priorJSON := map[string]bool{}
+	for _, p := range prior {
+		priorJSON[p.Name.ValueString()] = !p.PropertiesJSON.IsNull()
+	}
+
 	result := make([]AssetSourceModel, len(sources))
 	for i, source := range sources {
-		var properties types.Map
+		result[i] = AssetSourceModel{
+			Name:           types.StringValue(source.Name),
+			Priority:       types.Int64Value(source.Priority),
+			Properties:     types.MapNull(types.StringType),
+			PropertiesJSON: jsontypes.NewNormalizedNull(),
+		}
 
-		if props, ok := source.Properties.(map[string]interface{}); ok && props != nil && len(props) > 0 {
-			propsMap, diag := types.MapValueFrom(ctx, types.StringType, r.convertMapToStringMapSorted(props))
+		// Sources managed through properties_json keep typed values, as do
+		// sources without prior state whose properties can't be read back as
+		// strings, such as those written by ingestion connectors.
+		props := convert.Object(source.Properties)
+		useJSON, known := priorJSON[source.Name]
+		if !known {
+			useJSON = hasNestedValues(props)
+		}
+		switch {
+		case useJSON:
+			encoded := []byte("{}")
+			if len(props) > 0 {
+				var err error
+				if encoded, err = json.Marshal(props); err != nil {
+					diags.AddError("Source Properties Error", fmt.Sprintf("Unable to encode properties of source %q: %s", source.Name, err))
+					continue
+				}
+			}
+			result[i].PropertiesJSON = jsontypes.NewNormalizedValue(string(encoded))
+		case len(props) > 0:
+			propsMap, diag := types.MapValueFrom(ctx, types.StringType, convert.NonEmptyStringMap(props))
 			diags.Append(diag...)
-			properties = propsMap
-		} else {
-			properties = types.MapNull(types.StringType)
+			result[i].Properties = propsMap
 		}
+	}
+	return result
+}
 
-		result[i] = AssetSourceModel{
-			Name:       types.StringValue(source.Name),
-			Priority:   types.Int64Value(source.Priority),
-			Properties: properties,
+// hasNestedValues reports whether any value in m is a list or an object, which
+// a map of strings can't hold without losing its structure.
+func hasNestedValues(m map[string]interface{}) bool {
+	for _, v := range m {
+		switch v.(type) {
+		case map[string]interface{}, []interface{}:
+			return true
+		}
+	}
+	return false
+}
+
+// environmentsFromResponse converts the API environments like
+// convertModelEnvironments, except that an environment semantically equal to
+// its entry in prior is kept exactly as it was, including a null versus empty
+// metadata map and number formatting within metadata values.
+func (r *AssetResource) environmentsFromResponse(ctx context.Context, prior map[string]AssetEnvironmentModel, environments map[string]marmot.AssetEnvironment, diags *diag.Diagnostics) map[string]AssetEnvironmentModel {
+	result := r.convertModelEnvironments(ctx, environments, diags)
+	for k, env := range environments {
+		p, ok := prior[k]
+		if !ok {
+			continue
+		}
+		if p.Name.ValueString() == env.Name && p.Path.ValueString() == env.Path && metadataMatches(p.Metadata, env.Metadata) {
+			result[k] = p
 		}
 	}
 	return result
 }
 
+// metadataMatches reports whether the string map from state holds the same
+// keys and values as metadata returned by the API.
+func metadataMatches(prior types.Map, raw interface{}) bool {
+	// Empty and null values are skipped, as in convert.NonEmptyStringMap.
+	meta := map[string]interface{}{}
+	for k, v := range convert.Object(raw) {
+		if v != nil && v != "" {
+			meta[k] = v
+		}
+	}
+	if prior.IsNull() || prior.IsUnknown() {
+		return len(meta) == 0
+	}
+
+	elements := prior.Elements()
+	if len(elements) != len(meta) {
+		return false
+	}
+	for k, v := range elements {
+		strVal, ok := v.(basetypes.StringValue)
+		rawVal, found := meta[k]
+		if !ok || !found || !metadataValueMatches(strVal.ValueString(), rawVal) {
+			return false
+		}
+	}
+	return true
+}
+
+// metadataValueMatches compares a metadata value as written in configuration
+// with the typed value the API returned for it.
+func metadataValueMatches(configured string, raw interface{}) bool {
+	switch val := raw.(type) {
+	case string:
+		return configured == val
+	case bool:
+		b, err := strconv.ParseBool(configured)
+		return err == nil && b == val
+	case float64:
+		f, err := strconv.ParseFloat(configured, 64)
+		return err == nil && f == val
+	default:
+		return configured == fmt.Sprintf("%v", val)
+	}
+}
+
 func (r *AssetResource) convertModelEnvironments(ctx context.Context, environments map[string]marmot.AssetEnvironment, diags *diag.Diagnostics) map[string]AssetEnvironmentModel {
 	if len(environments) == 0 {
 		return make(map[string]AssetEnvironmentModel)
@@ -890,8 +1808,8 @@ func (r *AssetResource) convertModelEnvironments(ctx context.Context, environmen
 	for k, env := range environments {
 		var metadata types.Map
 
-		if meta, ok := env.Metadata.(map[string]interface{}); ok && meta != nil && len(meta) > 0 {
-			metaMap, diag := types.MapValueFrom(ctx, types.StringType, r.convertMapToStringMapSorted(meta))
+		if meta := convert.Object(env.Metadata); len(meta) > 0 {
+			metaMap, diag := types.MapValueFrom(ctx, types.StringType, convert.NonEmptyStringMap(meta))
 			diags.Append(diag...)
 			metadata = metaMap
 		} else {
@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build acceptance
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/acctest"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
+)
+
+func TestAccAssetResource(t *testing.T) {
+	name := acctest.RandomName("asset")
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssetsDestroyed(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAssetConfig(name, "Orders placed through the shop"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("marmot_asset.test", "name", name),
+					resource.TestCheckResourceAttr("marmot_asset.test", "type", "Table"),
+					resource.TestCheckResourceAttr("marmot_asset.test", "description", "Orders placed through the shop"),
+					resource.TestCheckResourceAttr("marmot_asset.test", "metadata.owner", "data-team"),
+					resource.TestCheckResourceAttrSet("marmot_asset.test", "mrn"),
+					testAccStoreID("marmot_asset.test", &id),
+				),
+			},
+			{
+				Config: testAccAssetConfig(name, "Orders placed through the shop and its apps"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("marmot_asset.test", "description", "Orders placed through the shop and its apps"),
+					resource.TestCheckResourceAttrPtr("marmot_asset.test", "id", &id),
+				),
+			},
+			{
+				ResourceName:      "marmot_asset.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Imports read everything on the server, and a created asset
+				// only differs from its configuration in what isn't set.
+				ImportStateVerifyIgnore: []string{"updated_at", "documentation_hash", "content_hash"},
+			},
+			{
+				// Keys written by other tools are reported in
+				// external_metadata and survive updates.
+				PreConfig: func() {
+					err := testAccUpdateAsset(t.Context(), acctest.Client(t), id, func(input *marmot.UpdateAssetInput) {
+						input.Metadata["ingested_by"] = "tfacc"
+					})
+					if err != nil {
+						t.Fatalf("adding metadata out of band: %s", err)
+					}
+				},
+				Config: testAccAssetConfig(name, "Orders placed through the shop and its apps"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("marmot_asset.test", "external_metadata.ingested_by", "tfacc"),
+					resource.TestCheckNoResourceAttr("marmot_asset.test", "metadata.ingested_by"),
+				),
+			},
+			testAccDriftStep(t, testAccAssetConfig(name, "Orders placed through the shop and its apps"), func(ctx context.Context, client *marmot.Client) error {
+				return testAccUpdateAsset(ctx, client, id, func(input *marmot.UpdateAssetInput) {
+					input.Description = "Changed outside Terraform"
+				})
+			}),
+		},
+	})
+}
+
+// testAccUpdateAsset changes the asset with id through the API, carrying
+// over everything edit leaves alone.
+func testAccUpdateAsset(ctx context.Context, client *marmot.Client, id string, edit func(input *marmot.UpdateAssetInput)) error {
+	current, err := client.Assets.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	input := marmot.UpdateAssetInput{
+		Name:            current.Name,
+		Type:            current.Type,
+		Description:     current.Description,
+		UserDescription: current.UserDescription,
+		Providers:       current.Providers,
+		Tags:            current.Tags,
+		Metadata:        convert.CopyObject(current.Metadata),
+		Schema:          current.Schema,
+		ExternalLinks:   current.ExternalLinks,
+		Sources:         current.Sources,
+		Environments:    current.Environments,
+	}
+	if input.Metadata == nil {
+		input.Metadata = map[string]any{}
+	}
+	edit(&input)
+	_, err = client.Assets.Update(ctx, id, input)
+	return err
+}
+
+func testAccCheckAssetsDestroyed(t *testing.T) func(*terraform.State) error {
+	return testAccCheckDestroyed(t, "marmot_asset", func(ctx context.Context, client *marmot.Client, id string) error {
+		_, err := client.Assets.Get(ctx, id)
+		return err
+	})
+}
+
+func testAccAssetConfig(name, description string) string {
+	return testAccProviderConfig + fmt.Sprintf(`
+resource "marmot_asset" "test" {
+  name        = %q
+  type        = "Table"
+  description = %q
+  services    = ["PostgreSQL"]
+
+  tags = ["tfacc"]
+
+  metadata = {
+    owner = "data-team"
+  }
+}
+`, name, description)
+}
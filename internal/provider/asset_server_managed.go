@@ -0,0 +1,246 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
+)
+
+// serverManagedMetadataPrefix selects a single metadata key in
+// server_managed_fields, as in "metadata.row_count".
+const serverManagedMetadataPrefix = "metadata."
+
+// serverManagedConfigurable are the configurable asset attributes that can
+// be listed in server_managed_fields.
+var serverManagedConfigurable = []string{
+	"description", "user_description", "tags", "metadata", "schema",
+	"external_links", "sources", "environments",
+}
+
+// serverManagedComputed are the computed asset attributes that can be listed
+// in server_managed_fields.
+var serverManagedComputed = []string{
+	"updated_at", "last_sync_at", "parent_mrn", "query", "query_language",
+	"has_run_history", "is_stub",
+}
+
+// serverManagedFieldValidator accepts the attribute names above and
+// "metadata.<key>".
+func serverManagedFieldValidator() validator.String {
+	names := append(append([]string{}, serverManagedConfigurable...), serverManagedComputed...)
+	return stringvalidator.Any(
+		stringvalidator.OneOf(names...),
+		stringvalidator.RegexMatches(
+			regexp.MustCompile(`^`+regexp.QuoteMeta(serverManagedMetadataPrefix)+`.+$`),
+			"must be a metadata key prefixed with \"metadata.\"",
+		),
+	)
+}
+
+// serverManagedFields is the parsed form of server_managed_fields.
+type serverManagedFields struct {
+	fields       map[string]bool
+	metadataKeys []string
+}
+
+func parseServerManagedFields(ctx context.Context, set types.Set, diags *diag.Diagnostics) serverManagedFields {
+	managed := serverManagedFields{fields: map[string]bool{}}
+	for _, name := range setStrings(ctx, set, diags) {
+		if key, ok := strings.CutPrefix(name, serverManagedMetadataPrefix); ok {
+			managed.metadataKeys = append(managed.metadataKeys, key)
+			continue
+		}
+		managed.fields[name] = true
+	}
+	sort.Strings(managed.metadataKeys)
+	return managed
+}
+
+func (m serverManagedFields) empty() bool {
+	return len(m.fields) == 0 && len(m.metadataKeys) == 0
+}
+
+// keepPrior sets the listed attributes of model back to their values in
+// prior, so changes made on the server never surface as drift; individual
+// metadata keys are handled by filterMetadata. Read passes the prior
+// state; Update passes the plan, whose computed values ModifyPlan already
+// took from state.
+func (m serverManagedFields) keepPrior(model *AssetResourceModel, prior AssetResourceModel) {
+	if m.fields["description"] {
+		model.Description = prior.Description
+	}
+	if m.fields["user_description"] {
+		model.UserDescription = prior.UserDescription
+	}
+	if m.fields["tags"] {
+		model.Tags = prior.Tags
+		model.StructuredTags = prior.StructuredTags
+	}
+	if m.fields["metadata"] {
+		model.Metadata = prior.Metadata
+		model.MetadataJSON = prior.MetadataJSON
+	}
+	if m.fields["schema"] {
+		model.Schema = prior.Schema
+	}
+	if m.fields["external_links"] {
+		model.ExternalLinks = prior.ExternalLinks
+	}
+	if m.fields["sources"] {
+		model.Sources = prior.Sources
+	}
+	if m.fields["environments"] {
+		model.Environments = prior.Environments
+	}
+	if m.fields["updated_at"] {
+		model.UpdatedAt = prior.UpdatedAt
+	}
+	if m.fields["last_sync_at"] {
+		model.LastSyncAt = prior.LastSyncAt
+	}
+	if m.fields["parent_mrn"] {
+		model.ParentMRN = prior.ParentMRN
+	}
+	if m.fields["query"] {
+		model.Query = prior.Query
+	}
+	if m.fields["query_language"] {
+		model.QueryLanguage = prior.QueryLanguage
+	}
+	if m.fields["has_run_history"] {
+		model.HasRunHistory = prior.HasRunHistory
+	}
+	if m.fields["is_stub"] {
+		model.IsStub = prior.IsStub
+	}
+}
+
+// filterMetadata rewrites the listed keys of the metadata returned by the
+// API to what prior held for them: the prior value when it had the key,
+// otherwise nothing. Run it before the response is copied onto the model.
+func (m serverManagedFields) filterMetadata(asset *marmot.Asset, prior AssetResourceModel, diags *diag.Diagnostics) {
+	if len(m.metadataKeys) == 0 || m.fields["metadata"] {
+		return
+	}
+	metaMap := convert.Object(asset.Metadata)
+	if metaMap == nil {
+		return
+	}
+
+	priorMeta := priorMetadata(prior, diags)
+	for _, k := range m.metadataKeys {
+		if v, ok := priorMeta[k]; ok {
+			metaMap[k] = v
+		} else {
+			delete(metaMap, k)
+		}
+	}
+}
+
+// priorMetadata returns the metadata held in a state or plan model, from
+// metadata_json or the string map, whichever is set.
+func priorMetadata(model AssetResourceModel, diags *diag.Diagnostics) map[string]interface{} {
+	out := map[string]interface{}{}
+	if !model.MetadataJSON.IsNull() && !model.MetadataJSON.IsUnknown() {
+		diags.Append(model.MetadataJSON.Unmarshal(&out)...)
+		return out
+	}
+	if !model.Metadata.IsNull() && !model.Metadata.IsUnknown() {
+		for k, v := range model.Metadata.Elements() {
+			if s, ok := v.(types.String); ok && !s.IsNull() {
+				out[k] = s.ValueString()
+			}
+		}
+	}
+	return out
+}
+
+// preserveServerValues fills an update request with the server's current
+// value for every listed attribute, and every listed metadata key, that the
+// plan leaves unset, since an update otherwise replaces them with nothing.
+func (m serverManagedFields) preserveServerValues(input *marmot.UpdateAssetInput, current *marmot.Asset, plan AssetResourceModel) {
+	if m.fields["description"] && plan.Description.IsNull() {
+		input.Description = current.Description
+	}
+	if m.fields["user_description"] && plan.UserDescription.IsNull() {
+		input.UserDescription = current.UserDescription
+	}
+	if m.fields["tags"] && plan.Tags.IsNull() {
+		input.Tags = withServerTags(input.Tags, current.Tags, structuredTagKeys(plan.StructuredTags))
+	}
+	if m.fields["metadata"] && plan.Metadata.IsNull() && plan.MetadataJSON.IsNull() {
+		input.Metadata = convert.Object(current.Metadata)
+	}
+	if m.fields["schema"] && plan.Schema.IsNull() {
+		input.Schema = current.Schema
+	}
+	if m.fields["external_links"] && plan.ExternalLinks == nil {
+		input.ExternalLinks = current.ExternalLinks
+	}
+	if m.fields["sources"] && plan.Sources == nil {
+		input.Sources = current.Sources
+	}
+	if m.fields["environments"] && plan.Environments == nil {
+		input.Environments = current.Environments
+	}
+
+	serverMeta := convert.Object(current.Metadata)
+	for _, k := range m.metadataKeys {
+		v, onServer := serverMeta[k]
+		if !onServer {
+			continue
+		}
+		if _, configured := input.Metadata[k]; configured {
+			continue
+		}
+		if input.Metadata == nil {
+			input.Metadata = map[string]interface{}{}
+		}
+		input.Metadata[k] = v
+	}
+}
+
+// modifyPlanForServerManaged keeps the prior state value of listed computed
+// attributes in the plan, where they would otherwise be unknown after any
+// change to the asset.
+func modifyPlanForServerManaged(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var set types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("server_managed_fields"), &set)...)
+	if resp.Diagnostics.HasError() || set.IsUnknown() {
+		return
+	}
+	managed := parseServerManagedFields(ctx, set, &resp.Diagnostics)
+
+	for _, name := range serverManagedComputed {
+		if !managed.fields[name] {
+			continue
+		}
+		switch name {
+		case "has_run_history", "is_stub":
+			var v types.Bool
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(name), &v)...)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), v)...)
+		default:
+			var v types.String
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(name), &v)...)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), v)...)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// assetSchemaVersion is bumped whenever a marmot_asset schema change needs
+// existing state rewritten, with a matching upgrader in UpgradeState.
+//
+//   - 0: external_links and sources were lists.
+//   - 1: external_links and sources are sets.
+const assetSchemaVersion = 1
+
+// UpgradeState migrates state written by earlier versions of the provider, so
+// existing assets keep working without being re-imported. Each upgrader goes
+// straight to the current schema version.
+func (r *AssetResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeAssetStateV0},
+	}
+}
+
+// upgradeAssetStateV0 turns the external_links and sources lists into sets.
+// Both encode as JSON arrays, so the raw state only needs duplicate elements
+// dropped; attributes added since version 0 decode as null and are filled in
+// by the next refresh.
+func upgradeAssetStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Asset State",
+			"The prior state has no JSON representation. Remove the asset from state and import it again.",
+		)
+		return
+	}
+
+	var state map[string]json.RawMessage
+	if err := json.Unmarshal(req.RawState.JSON, &state); err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Asset State", "Could not decode the prior state: "+err.Error())
+		return
+	}
+
+	for _, attr := range []string{"external_links", "sources"} {
+		deduped, err := dedupeJSONArray(state[attr])
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Upgrade Asset State", "Could not decode "+attr+" in the prior state: "+err.Error())
+			return
+		}
+		if deduped != nil {
+			state[attr] = deduped
+		}
+	}
+
+	upgraded, err := json.Marshal(state)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Asset State", "Could not encode the upgraded state: "+err.Error())
+		return
+	}
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+}
+
+// dedupeJSONArray drops repeated elements from a JSON array, comparing them by
+// their canonical encoding. It returns nil when raw is absent or null.
+func dedupeJSONArray(raw json.RawMessage) (json.RawMessage, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var elems []any
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&elems); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(elems))
+	unique := make([]any, 0, len(elems))
+	for _, elem := range elems {
+		key, err := json.Marshal(elem)
+		if err != nil {
+			return nil, err
+		}
+		if seen[string(key)] {
+			continue
+		}
+		seen[string(key)] = true
+		unique = append(unique, elem)
+	}
+	return json.Marshal(unique)
+}
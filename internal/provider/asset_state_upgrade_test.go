@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDedupeJSONArray(t *testing.T) {
+	tests := map[string]struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		"empty":             {raw: "", want: ""},
+		"null":              {raw: "null", want: ""},
+		"no duplicates":     {raw: `["a","b"]`, want: `["a","b"]`},
+		"keeps first order": {raw: `["b","a","b","a"]`, want: `["b","a"]`},
+		"objects by value":  {raw: `[{"a":1,"b":2},{"b":2,"a":1},{"a":2}]`, want: `[{"a":1,"b":2},{"a":2}]`},
+		"numbers kept":      {raw: `[1,1.0,12345678901234567890,12345678901234567890]`, want: `[1,1.0,12345678901234567890]`},
+		"not an array":      {raw: `{"a":1}`, wantErr: true},
+		"invalid":           {raw: `[`, wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := dedupeJSONArray(json.RawMessage(tt.raw))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error: got %v, want error %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
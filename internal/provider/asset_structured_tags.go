@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Marmot tags are plain strings. A structured tag is stored as a single
+// "key=value" tag, and a tag read back is taken for a structured tag when
+// its key is one structured_tags already manages, so plain tags that happen
+// to contain the separator are left alone.
+
+// structuredTagSeparator joins a structured tag's key and value.
+const structuredTagSeparator = "="
+
+// StructuredTagModel is a key/value tag on an asset.
+type StructuredTagModel struct {
+	Key   types.String `tfsdk:"key"`
+	Value types.String `tfsdk:"value"`
+}
+
+func (t StructuredTagModel) tag() string {
+	return t.Key.ValueString() + structuredTagSeparator + t.Value.ValueString()
+}
+
+// splitStructuredTag returns the key and value of a tag written as
+// key=value. The value may itself contain the separator.
+func splitStructuredTag(tag string) (key, value string, ok bool) {
+	key, value, ok = strings.Cut(tag, structuredTagSeparator)
+	return key, value, ok && key != ""
+}
+
+// structuredTagKeys returns the keys, lowercased, that structured_tags
+// manages in any of the given models.
+func structuredTagKeys(models ...[]StructuredTagModel) map[string]bool {
+	keys := map[string]bool{}
+	for _, tags := range models {
+		for _, t := range tags {
+			if !t.Key.IsNull() && !t.Key.IsUnknown() {
+				keys[strings.ToLower(t.Key.ValueString())] = true
+			}
+		}
+	}
+	return keys
+}
+
+// requestTags returns the tags to send for the asset: its plain tags and its
+// structured tags, sorted and without duplicates.
+func requestTags(ctx context.Context, data AssetResourceModel, diags *diag.Diagnostics) []string {
+	tags := setStrings(ctx, data.Tags, diags)
+	for _, t := range data.StructuredTags {
+		tags = append(tags, t.tag())
+	}
+	return sortedUniqueTags(tags)
+}
+
+// withServerTags adds to tags the server's tags that neither tags nor
+// structured_tags manage, for an update that leaves plain tags unset. Server
+// tags whose key is in keys belong to structured_tags and are not kept, so
+// a structured tag removed from the configuration is removed from Marmot.
+func withServerTags(tags, server []string, keys map[string]bool) []string {
+	out := append([]string{}, tags...)
+	for _, tag := range server {
+		if key, _, ok := splitStructuredTag(tag); ok && keys[strings.ToLower(key)] {
+			continue
+		}
+		out = append(out, tag)
+	}
+	return sortedUniqueTags(out)
+}
+
+func sortedUniqueTags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	sort.Strings(tags)
+	out := tags[:1]
+	for _, t := range tags[1:] {
+		if t != out[len(out)-1] {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// splitServerTags separates the tags Marmot returned into plain tags and the
+// structured tags whose keys are in keys, each sorted. structured is nil when
+// keys is empty.
+func splitServerTags(tags []string, keys map[string]bool) (plain []string, structured []StructuredTagModel) {
+	if len(keys) > 0 {
+		structured = []StructuredTagModel{}
+	}
+	for _, tag := range tags {
+		key, value, ok := splitStructuredTag(tag)
+		if !ok || !keys[strings.ToLower(key)] {
+			plain = append(plain, tag)
+			continue
+		}
+		structured = append(structured, StructuredTagModel{
+			Key:   types.StringValue(key),
+			Value: types.StringValue(value),
+		})
+	}
+	sort.Strings(plain)
+	sort.Slice(structured, func(i, j int) bool {
+		return structured[i].tag() < structured[j].tag()
+	})
+	return plain, structured
+}
+
+// keepStructuredTagCase is keepLabelCase for structured tags: a tag that
+// differs from one in prior only in case keeps prior's spelling.
+func keepStructuredTagCase(current, prior []StructuredTagModel) []StructuredTagModel {
+	spelling := make(map[string]StructuredTagModel, len(prior))
+	for _, t := range prior {
+		spelling[strings.ToLower(t.tag())] = t
+	}
+	for i, t := range current {
+		if p, ok := spelling[strings.ToLower(t.tag())]; ok {
+			current[i] = p
+		}
+	}
+	return current
+}
+
+// validateStructuredTags checks that structured tag keys don't contain the
+// separator, that each tag fits in a Marmot tag, and that no plain tag uses a
+// structured tag's key, which would be read back as a structured tag. Values
+// not yet known are skipped.
+func validateStructuredTags(ctx context.Context, tags, structuredTags types.Set, diags *diag.Diagnostics) {
+	if structuredTags.IsNull() || structuredTags.IsUnknown() {
+		return
+	}
+
+	var structured []StructuredTagModel
+	for _, elem := range structuredTags.Elements() {
+		if elem.IsUnknown() {
+			continue
+		}
+		var t StructuredTagModel
+		obj, ok := elem.(types.Object)
+		if !ok {
+			continue
+		}
+		diags.Append(obj.As(ctx, &t, basetypes.ObjectAsOptions{})...)
+		if t.Key.IsUnknown() {
+			continue
+		}
+		structured = append(structured, t)
+		if strings.Contains(t.Key.ValueString(), structuredTagSeparator) {
+			diags.AddAttributeError(
+				path.Root("structured_tags"),
+				"Invalid Structured Tag Key",
+				fmt.Sprintf("The key %q contains %q, which separates keys from values in Marmot tags.", t.Key.ValueString(), structuredTagSeparator),
+			)
+		}
+		if !t.Value.IsUnknown() && utf8.RuneCountInString(t.tag()) > maxTagLength {
+			diags.AddAttributeError(
+				path.Root("structured_tags"),
+				"Structured Tag Too Long",
+				fmt.Sprintf("The tag %q is longer than %d characters once its key and value are joined.", t.tag(), maxTagLength),
+			)
+		}
+	}
+
+	keys := structuredTagKeys(structured)
+	if len(keys) == 0 || tags.IsUnknown() {
+		return
+	}
+	for _, elem := range tags.Elements() {
+		tag, ok := elem.(types.String)
+		if !ok || tag.IsUnknown() {
+			continue
+		}
+		if key, _, ok := splitStructuredTag(tag.ValueString()); ok && keys[strings.ToLower(key)] {
+			diags.AddAttributeError(
+				path.Root("tags"),
+				"Tag Conflicts With Structured Tag",
+				fmt.Sprintf("The tag %q uses the key %q, which structured_tags also sets. "+
+					"Move it to structured_tags.", tag.ValueString(), key),
+			)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AssetTagsResource{}
+var _ resource.ResourceWithConfigValidators = &AssetTagsResource{}
+
+func NewAssetTagsResource() resource.Resource {
+	return &AssetTagsResource{}
+}
+
+// AssetTagsResource defines the resource implementation.
+type AssetTagsResource struct {
+	client *marmot.Client
+}
+
+// AssetTagsResourceModel describes the asset tags resource data model.
+type AssetTagsResourceModel struct {
+	AssetMRN types.String `tfsdk:"asset_mrn"`
+	AssetID  types.String `tfsdk:"asset_id"`
+	Tags     types.Set    `tfsdk:"tags"`
+}
+
+func (r *AssetTagsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset_tags"
+}
+
+func (r *AssetTagsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Adds tags to an existing asset without managing the rest of it, so " +
+			"several configurations can each tag the same asset. Only the tags in `tags` are " +
+			"added, and only those are removed on destroy.\n\n" +
+			"Don't also set `tags` on a `marmot_asset` for the same asset, as that resource " +
+			"replaces the asset's whole tag list.",
+
+		Attributes: map[string]schema.Attribute{
+			"asset_mrn": schema.StringAttribute{
+				MarkdownDescription: "MRN of the asset, e.g. `mrn://table/postgresql/orders`. " +
+					"Conflicts with `asset_id`; computed when that is set.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					isMRN(),
+				},
+			},
+			"asset_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the asset, such as `marmot_asset.x.id`. Conflicts with " +
+					"`asset_mrn`; computed when that is set.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Tags to add to the asset. A tag removed from here is removed " +
+					"from the asset.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *AssetTagsResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("asset_mrn"), path.MatchRoot("asset_id")),
+	}
+}
+
+func (r *AssetTagsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *AssetTagsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data AssetTagsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assetID, err := resolveAssetRefs(ctx, r.client, &data.AssetMRN, &data.AssetID)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to find asset", err)
+		return
+	}
+
+	tags := setStrings(ctx, data.Tags, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sort.Strings(tags)
+	if err := r.addTags(ctx, assetID, tags); err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to tag asset", err)
+		return
+	}
+
+	tflog.Info(ctx, "Asset tags added", map[string]any{
+		"asset_id": assetID,
+		"tags":     tags,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AssetTagsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data AssetTagsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, err := r.client.Assets.Get(ctx, data.AssetID.ValueString())
+	if err != nil {
+		if marmot.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to read asset", err)
+		return
+	}
+
+	// Only the declared tags are read back, spelled as configured since
+	// Marmot lowercases them. A tag removed on the server shows up as drift
+	// and is added again on apply.
+	present := []string{}
+	for _, tag := range setStrings(ctx, data.Tags, &resp.Diagnostics) {
+		for _, t := range asset.Tags {
+			if strings.EqualFold(t, tag) {
+				present = append(present, tag)
+				break
+			}
+		}
+	}
+
+	tags, diags := types.SetValueFrom(ctx, types.StringType, present)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Tags = tags
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AssetTagsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data, state AssetTagsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned := setStrings(ctx, data.Tags, &resp.Diagnostics)
+	prior := setStrings(ctx, state.Tags, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	added := tagsMissingFrom(planned, prior)
+	removed := tagsMissingFrom(prior, planned)
+
+	assetID := data.AssetID.ValueString()
+	if err := r.removeTags(ctx, assetID, removed); err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to remove asset tags", err)
+		return
+	}
+	if err := r.addTags(ctx, assetID, added); err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to tag asset", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AssetTagsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data AssetTagsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tags := setStrings(ctx, data.Tags, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sort.Strings(tags)
+	if err := r.removeTags(ctx, data.AssetID.ValueString(), tags); err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to remove asset tags", err)
+		return
+	}
+
+	tflog.Info(ctx, "Asset tags removed", map[string]any{
+		"asset_id": data.AssetID.ValueString(),
+		"tags":     tags,
+	})
+}
+
+// addTags adds each tag to the asset, treating a tag it already carries as
+// added.
+func (r *AssetTagsResource) addTags(ctx context.Context, assetID string, tags []string) error {
+	for _, tag := range tags {
+		if err := r.client.Assets.AddTag(ctx, assetID, tag); err != nil && !isConflict(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeTags removes each tag from the asset, treating a tag or asset that
+// is already gone as removed.
+func (r *AssetTagsResource) removeTags(ctx context.Context, assetID string, tags []string) error {
+	for _, tag := range tags {
+		if err := r.client.Assets.RemoveTag(ctx, assetID, tag); err != nil && !marmot.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// tagsMissingFrom returns the tags in a that aren't in b, compared
+// case-insensitively, in sorted order.
+func tagsMissingFrom(a, b []string) []string {
+	missing := []string{}
+	for _, tag := range a {
+		found := false
+		for _, t := range b {
+			if strings.EqualFold(t, tag) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, tag)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
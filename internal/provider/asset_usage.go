@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+const (
+	// assetUsageWindow is the period asset view counts cover.
+	assetUsageWindow = 30 * 24 * time.Hour
+
+	// assetUsageLimit is how many of the most viewed assets are read. An
+	// asset missing from a shorter list had no views; one missing from a
+	// full list may have had some, so its count is unknown.
+	assetUsageLimit = 1000
+)
+
+// assetViews holds the view counts of the most viewed assets over the last
+// assetUsageWindow, keyed by asset ID. counts and err are set before done
+// is closed.
+type assetViews struct {
+	done     chan struct{}
+	counts   map[string]int64
+	complete bool
+	err      error
+}
+
+// viewCount returns how often the asset with the given ID was viewed in the
+// last assetUsageWindow. known is false when the asset isn't among the
+// assetUsageLimit most viewed and the list was full. The counts are read
+// once and shared by every lookup; a failed read is retried by the next.
+func (l *assetLookup) viewCount(ctx context.Context, id string) (count int64, known bool, err error) {
+	l.mu.Lock()
+	v := l.views
+	if v == nil {
+		v = &assetViews{done: make(chan struct{})}
+		l.views = v
+		l.mu.Unlock()
+		l.readViews(ctx, v)
+	} else {
+		l.mu.Unlock()
+	}
+
+	select {
+	case <-v.done:
+	case <-ctx.Done():
+		return 0, false, ctx.Err()
+	}
+	if v.err != nil {
+		return 0, false, v.err
+	}
+	if count, ok := v.counts[id]; ok {
+		return count, true, nil
+	}
+	return 0, v.complete, nil
+}
+
+func (l *assetLookup) readViews(ctx context.Context, v *assetViews) {
+	defer close(v.done)
+
+	end := time.Now().UTC()
+	top, err := l.client.Metrics.TopAssets(ctx, marmot.TopOptions{
+		Start: end.Add(-assetUsageWindow).Format(time.RFC3339),
+		End:   end.Format(time.RFC3339),
+		Limit: assetUsageLimit,
+	})
+	if err != nil {
+		v.err = err
+		l.mu.Lock()
+		l.views = nil
+		l.mu.Unlock()
+		return
+	}
+
+	v.counts = make(map[string]int64, len(top))
+	for _, asset := range top {
+		if asset != nil && asset.AssetID != "" {
+			v.counts[asset.AssetID] += asset.Count
+		}
+	}
+	v.complete = len(top) < assetUsageLimit
+}
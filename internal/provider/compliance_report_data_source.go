@@ -0,0 +1,291 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ComplianceReportDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &ComplianceReportDataSource{}
+
+func NewComplianceReportDataSource() datasource.DataSource {
+	return &ComplianceReportDataSource{}
+}
+
+// ComplianceReportDataSource defines the data source implementation.
+type ComplianceReportDataSource struct {
+	client *marmot.Client
+}
+
+// ComplianceReportDataSourceModel describes the compliance report data source
+// data model.
+type ComplianceReportDataSourceModel struct {
+	Query                types.String `tfsdk:"query"`
+	Types                types.Set    `tfsdk:"types"`
+	Services             types.Set    `tfsdk:"services"`
+	Tags                 types.Set    `tfsdk:"tags"`
+	RequiredTags         types.Set    `tfsdk:"required_tags"`
+	RequiredMetadataKeys types.Set    `tfsdk:"required_metadata_keys"`
+
+	AssetsChecked types.Int64                `tfsdk:"assets_checked"`
+	Compliant     types.Bool                 `tfsdk:"compliant"`
+	NonCompliant  []ComplianceViolationModel `tfsdk:"non_compliant"`
+}
+
+// ComplianceViolationModel describes one asset missing required tags or
+// metadata.
+type ComplianceViolationModel struct {
+	ID                  types.String `tfsdk:"id"`
+	MRN                 types.String `tfsdk:"mrn"`
+	Name                types.String `tfsdk:"name"`
+	Type                types.String `tfsdk:"type"`
+	MissingTags         types.List   `tfsdk:"missing_tags"`
+	MissingMetadataKeys types.List   `tfsdk:"missing_metadata_keys"`
+}
+
+func (d *ComplianceReportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_compliance_report"
+}
+
+func (d *ComplianceReportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Checks every asset matching a filter for required tags and metadata " +
+			"keys, and lists the assets missing any of them. Add a `postcondition` on " +
+			"`compliant` to fail the plan when catalog hygiene regresses.",
+
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				MarkdownDescription: "Full-text search query selecting the assets to check. Leave " +
+					"unset to check every asset that passes the filters.",
+				Optional: true,
+			},
+			"types": schema.SetAttribute{
+				MarkdownDescription: "Only check assets of these types",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"services": schema.SetAttribute{
+				MarkdownDescription: "Only check assets from these services",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Only check assets carrying these tags",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"required_tags": schema.SetAttribute{
+				MarkdownDescription: "Tags every checked asset must carry. Compared case-insensitively.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"required_metadata_keys": schema.SetAttribute{
+				MarkdownDescription: "Metadata keys every checked asset must have set to a non-empty value",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"assets_checked": schema.Int64Attribute{
+				MarkdownDescription: "Number of assets that matched the filter and were checked",
+				Computed:            true,
+			},
+			"compliant": schema.BoolAttribute{
+				MarkdownDescription: "Whether every checked asset has all the required tags and metadata",
+				Computed:            true,
+			},
+			"non_compliant": schema.ListNestedAttribute{
+				MarkdownDescription: "Assets missing a required tag or metadata key, ordered by MRN",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Asset ID",
+							Computed:            true,
+						},
+						"mrn": schema.StringAttribute{
+							MarkdownDescription: "Marmot Resource Name",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Asset name",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Asset type",
+							Computed:            true,
+						},
+						"missing_tags": schema.ListAttribute{
+							MarkdownDescription: "Required tags the asset doesn't carry",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"missing_metadata_keys": schema.ListAttribute{
+							MarkdownDescription: "Required metadata keys the asset is missing or has empty",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ComplianceReportDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.AtLeastOneOf(
+			path.MatchRoot("required_tags"),
+			path.MatchRoot("required_metadata_keys"),
+		),
+	}
+}
+
+func (d *ComplianceReportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *ComplianceReportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data ComplianceReportDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := marmot.AssetSearchOptions{
+		Query:     data.Query.ValueString(),
+		Types:     setStrings(ctx, data.Types, &resp.Diagnostics),
+		Providers: setStrings(ctx, data.Services, &resp.Diagnostics),
+		Tags:      setStrings(ctx, data.Tags, &resp.Diagnostics),
+		Limit:     searchDefaultLimit,
+	}
+	requiredTags := setStrings(ctx, data.RequiredTags, &resp.Diagnostics)
+	requiredKeys := setStrings(ctx, data.RequiredMetadataKeys, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sort.Strings(requiredTags)
+	sort.Strings(requiredKeys)
+
+	// Every matching asset has to be checked, so page through all of them
+	// rather than stopping at a limit.
+	var assets []*marmot.Asset
+	for {
+		opts.Offset = int64(len(assets))
+
+		page, err := d.client.Assets.Search(ctx, opts)
+		if err != nil {
+			addClientError(ctx, &resp.Diagnostics, "Unable to search assets", err)
+			return
+		}
+		for _, asset := range page.Assets {
+			if asset != nil {
+				assets = append(assets, asset)
+			}
+		}
+		if len(page.Assets) == 0 || int64(len(assets)) >= page.Total {
+			break
+		}
+	}
+
+	violations := []ComplianceViolationModel{}
+	for _, asset := range assets {
+		missingTags := missingAssetTags(asset, requiredTags)
+		missingKeys := missingMetadataKeys(asset, requiredKeys)
+		if len(missingTags) == 0 && len(missingKeys) == 0 {
+			continue
+		}
+
+		tags, diags := types.ListValueFrom(ctx, types.StringType, missingTags)
+		resp.Diagnostics.Append(diags...)
+		keys, diags := types.ListValueFrom(ctx, types.StringType, missingKeys)
+		resp.Diagnostics.Append(diags...)
+
+		violations = append(violations, ComplianceViolationModel{
+			ID:                  types.StringValue(asset.ID),
+			MRN:                 types.StringValue(asset.Mrn),
+			Name:                types.StringValue(asset.Name),
+			Type:                types.StringValue(asset.Type),
+			MissingTags:         tags,
+			MissingMetadataKeys: keys,
+		})
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		return violations[i].MRN.ValueString() < violations[j].MRN.ValueString()
+	})
+
+	data.AssetsChecked = types.Int64Value(int64(len(assets)))
+	data.Compliant = types.BoolValue(len(violations) == 0)
+	data.NonCompliant = violations
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// missingAssetTags returns the required tags the asset doesn't carry. Marmot
+// lowercases tags, so they are compared case-insensitively.
+func missingAssetTags(asset *marmot.Asset, required []string) []string {
+	missing := []string{}
+	for _, tag := range required {
+		found := false
+		for _, t := range asset.Tags {
+			if strings.EqualFold(t, tag) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, tag)
+		}
+	}
+	return missing
+}
+
+// missingMetadataKeys returns the required metadata keys that are absent from
+// the asset or set to null or an empty string.
+func missingMetadataKeys(asset *marmot.Asset, required []string) []string {
+	metadata := convert.Object(asset.Metadata)
+
+	missing := []string{}
+	for _, key := range required {
+		switch v := metadata[key].(type) {
+		case nil:
+			missing = append(missing, key)
+		case string:
+			if strings.TrimSpace(v) == "" {
+				missing = append(missing, key)
+			}
+		}
+	}
+	return missing
+}
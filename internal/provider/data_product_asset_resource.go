@@ -9,11 +9,14 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	marmot "github.com/marmotdata/marmot/sdk/go"
@@ -22,6 +25,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &DataProductAssetResource{}
 var _ resource.ResourceWithImportState = &DataProductAssetResource{}
+var _ resource.ResourceWithConfigValidators = &DataProductAssetResource{}
 
 func NewDataProductAssetResource() resource.Resource {
 	return &DataProductAssetResource{}
@@ -36,6 +40,7 @@ type DataProductAssetResource struct {
 type DataProductAssetResourceModel struct {
 	DataProductID types.String `tfsdk:"data_product_id"`
 	AssetID       types.String `tfsdk:"asset_id"`
+	AssetMRN      types.String `tfsdk:"asset_mrn"`
 }
 
 func (r *DataProductAssetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -56,34 +61,61 @@ func (r *DataProductAssetResource) Schema(ctx context.Context, req resource.Sche
 				},
 			},
 			"asset_id": schema.StringAttribute{
-				MarkdownDescription: "ID of the asset to add to the data product",
-				Required:            true,
+				MarkdownDescription: "ID of the asset to add to the data product. Conflicts with " +
+					"`asset_mrn`; computed when that is set.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"asset_mrn": schema.StringAttribute{
+				MarkdownDescription: "MRN of the asset to add, e.g. `mrn://table/postgresql/orders`. " +
+					"Conflicts with `asset_id`; computed when that is set.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					isMRN(),
+				},
 			},
 		},
 	}
 }
 
+func (r *DataProductAssetResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("asset_id"), path.MatchRoot("asset_mrn")),
+	}
+}
+
 func (r *DataProductAssetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*marmot.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *marmot.Client, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func (r *DataProductAssetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data DataProductAssetResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -91,9 +123,15 @@ func (r *DataProductAssetResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
-	err := r.client.DataProducts.AddAssets(ctx, data.DataProductID.ValueString(), []string{data.AssetID.ValueString()})
+	assetID, err := resolveAssetRefs(ctx, r.client, &data.AssetMRN, &data.AssetID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add asset to data product: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to find asset", err)
+		return
+	}
+
+	err = r.client.DataProducts.AddAssets(ctx, data.DataProductID.ValueString(), []string{assetID})
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to add asset to data product", err)
 		return
 	}
 
@@ -106,6 +144,8 @@ func (r *DataProductAssetResource) Create(ctx context.Context, req resource.Crea
 }
 
 func (r *DataProductAssetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data DataProductAssetResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -119,7 +159,7 @@ func (r *DataProductAssetResource) Read(ctx context.Context, req resource.ReadRe
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read data product assets: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to read data product assets", err)
 		return
 	}
 	if !found {
@@ -127,17 +167,29 @@ func (r *DataProductAssetResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	// State saved before asset_mrn existed only has the ID.
+	if data.AssetMRN.IsNull() {
+		asset, err := r.client.Assets.Get(ctx, data.AssetID.ValueString())
+		if err != nil {
+			addClientError(ctx, &resp.Diagnostics, "Unable to read asset", err)
+			return
+		}
+		data.AssetMRN = types.StringValue(asset.Mrn)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *DataProductAssetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	resp.Diagnostics.AddError(
 		"Update Not Supported",
-		"Data product asset resources cannot be updated. Changes to data_product_id or asset_id require replacement.",
+		"Data product asset resources cannot be updated. Changes to data_product_id, asset_id, or asset_mrn require replacement.",
 	)
 }
 
 func (r *DataProductAssetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data DataProductAssetResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -147,7 +199,7 @@ func (r *DataProductAssetResource) Delete(ctx context.Context, req resource.Dele
 
 	err := r.client.DataProducts.RemoveAsset(ctx, data.DataProductID.ValueString(), data.AssetID.ValueString())
 	if err != nil && !marmot.IsNotFound(err) {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove asset from data product: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to remove asset from data product", err)
 		return
 	}
 
@@ -157,19 +209,29 @@ func (r *DataProductAssetResource) Delete(ctx context.Context, req resource.Dele
 	})
 }
 
-// ImportState imports a membership with the composite ID "<data_product_id>/<asset_id>".
+// ImportState imports a membership with the composite ID
+// "<data_product_id>/<asset>", where the asset is given by ID or MRN.
 func (r *DataProductAssetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	productID, assetID, ok := strings.Cut(req.ID, "/")
-	if !ok || productID == "" || assetID == "" {
+	ctx = withAPIErrorCapture(ctx)
+
+	productID, ref, ok := strings.Cut(req.ID, "/")
+	if !ok || productID == "" || ref == "" {
 		resp.Diagnostics.AddError(
 			"Invalid Import ID",
-			fmt.Sprintf("Expected import ID in the format 'data_product_id/asset_id', got: %s", req.ID),
+			fmt.Sprintf("Expected import ID in the format 'data_product_id/asset_id' or 'data_product_id/asset_mrn', got: %s", req.ID),
 		)
 		return
 	}
 
+	asset, err := findAssetRef(ctx, r.client, ref)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to find asset to import", err)
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("data_product_id"), productID)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("asset_id"), assetID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("asset_id"), asset.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("asset_mrn"), asset.Mrn)...)
 }
 
 // assetIsMember pages through the manually added assets of a data product and
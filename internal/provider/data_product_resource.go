@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -32,19 +33,22 @@ func NewDataProductResource() resource.Resource {
 // DataProductResource defines the resource implementation.
 type DataProductResource struct {
 	client *marmot.Client
+
+	// failOnUnknownEnums fails on owner types the provider doesn't know.
+	failOnUnknownEnums bool
 }
 
 // DataProductResourceModel describes the data product resource data model.
 type DataProductResourceModel struct {
-	Name         types.String `tfsdk:"name"`
-	Description  types.String `tfsdk:"description"`
-	Tags         types.Set    `tfsdk:"tags"`
-	OwnerTeamIDs types.Set    `tfsdk:"owner_team_ids"`
-	OwnerUserIDs types.Set    `tfsdk:"owner_user_ids"`
-	Metadata     types.Map    `tfsdk:"metadata"`
-	ID           types.String `tfsdk:"id"`
-	CreatedAt    types.String `tfsdk:"created_at"`
-	UpdatedAt    types.String `tfsdk:"updated_at"`
+	Name         types.String  `tfsdk:"name"`
+	Description  markdownValue `tfsdk:"description"`
+	Tags         types.Set     `tfsdk:"tags"`
+	OwnerTeamIDs types.Set     `tfsdk:"owner_team_ids"`
+	OwnerUserIDs types.Set     `tfsdk:"owner_user_ids"`
+	Metadata     types.Map     `tfsdk:"metadata"`
+	ID           types.String  `tfsdk:"id"`
+	CreatedAt    types.String  `tfsdk:"created_at"`
+	UpdatedAt    types.String  `tfsdk:"updated_at"`
 }
 
 func (r *DataProductResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -65,8 +69,11 @@ func (r *DataProductResource) Schema(ctx context.Context, req resource.SchemaReq
 				},
 			},
 			"description": schema.StringAttribute{
-				MarkdownDescription: "Description of the data product",
-				Optional:            true,
+				MarkdownDescription: "Description of the data product, in Markdown. Differences in " +
+					"line endings and surrounding whitespace, such as a heredoc's trailing newline, " +
+					"are ignored.",
+				CustomType: markdownType{},
+				Optional:   true,
 			},
 			"tags": schema.SetAttribute{
 				MarkdownDescription: "Tags associated with the data product",
@@ -120,19 +127,22 @@ func (r *DataProductResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*marmot.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *marmot.Client, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.failOnUnknownEnums = data.failOnUnknownEnums
 }
 
 func (r *DataProductResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data DataProductResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -148,7 +158,7 @@ func (r *DataProductResource) Create(ctx context.Context, req resource.CreateReq
 		Metadata:    dataProductMetadata(data.Metadata),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create data product: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to create data product", err)
 		return
 	}
 
@@ -158,7 +168,7 @@ func (r *DataProductResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	applyDataProductComputedFields(&data, product)
-	setDataProductOwnerSets(ctx, &data, product, &resp.Diagnostics)
+	setDataProductOwnerSets(ctx, &data, product, r.failOnUnknownEnums, &resp.Diagnostics)
 
 	tflog.Info(ctx, "Data product created", map[string]any{
 		"id":   data.ID.ValueString(),
@@ -169,6 +179,8 @@ func (r *DataProductResource) Create(ctx context.Context, req resource.CreateReq
 }
 
 func (r *DataProductResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data DataProductResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -187,7 +199,7 @@ func (r *DataProductResource) Read(ctx context.Context, req resource.ReadRequest
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read data product: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to read data product", err)
 		return
 	}
 
@@ -201,6 +213,8 @@ func (r *DataProductResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *DataProductResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data DataProductResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -214,20 +228,43 @@ func (r *DataProductResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	// A configured owner list replaces the current one, so owners of types
+	// the provider doesn't map are sent back as they are.
+	owners := dataProductOwners(ctx, data.OwnerTeamIDs, data.OwnerUserIDs, &resp.Diagnostics)
+	if owners != nil {
+		current, err := r.client.DataProducts.Get(ctx, state.ID.ValueString())
+		if err != nil {
+			addClientError(ctx, &resp.Diagnostics, "Unable to read data product owners", err)
+			return
+		}
+		for _, owner := range current.Owners {
+			if owner == nil || knownOwnerType(owner.Type) {
+				continue
+			}
+			if r.failOnUnknownEnums {
+				addUnknownOwnerType(&resp.Diagnostics, true, "data product "+current.ID, owner.ID, owner.Type)
+			}
+			owners = append(owners, marmot.ProductOwner{ID: owner.ID, Type: owner.Type})
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	product, err := r.client.DataProducts.Update(ctx, state.ID.ValueString(), marmot.UpdateDataProductInput{
 		Name:        data.Name.ValueString(),
 		Description: data.Description.ValueString(),
 		Tags:        dataProductTagsOrEmpty(ctx, data.Tags, &resp.Diagnostics),
-		Owners:      dataProductOwners(ctx, data.OwnerTeamIDs, data.OwnerUserIDs, &resp.Diagnostics),
+		Owners:      owners,
 		Metadata:    dataProductMetadata(data.Metadata),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update data product: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to update data product", err)
 		return
 	}
 
 	applyDataProductComputedFields(&data, product)
-	setDataProductOwnerSets(ctx, &data, product, &resp.Diagnostics)
+	setDataProductOwnerSets(ctx, &data, product, r.failOnUnknownEnums, &resp.Diagnostics)
 
 	tflog.Info(ctx, "Data product updated", map[string]any{
 		"id":   data.ID.ValueString(),
@@ -238,6 +275,8 @@ func (r *DataProductResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *DataProductResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data DataProductResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -246,7 +285,7 @@ func (r *DataProductResource) Delete(ctx context.Context, req resource.DeleteReq
 	}
 
 	if err := r.client.DataProducts.Delete(ctx, data.ID.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete data product: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to delete data product", err)
 		return
 	}
 
@@ -285,15 +324,21 @@ func dataProductTagsOrEmpty(ctx context.Context, set types.Set, diags *diag.Diag
 
 // setDataProductOwnerSets populates the team and user owner sets from an API
 // response. Owners are server-controlled (an unset owner list defaults to the
-// calling user), so both sets are always taken from the response.
-func setDataProductOwnerSets(ctx context.Context, model *DataProductResourceModel, product *marmot.DataProduct, diags *diag.Diagnostics) {
+// calling user), so both sets are always taken from the response. Owners of
+// other types are reported, failing when strict is set.
+func setDataProductOwnerSets(ctx context.Context, model *DataProductResourceModel, product *marmot.DataProduct, strict bool, diags *diag.Diagnostics) {
 	var teamIDs, userIDs []string
 	for _, owner := range product.Owners {
+		if owner == nil {
+			continue
+		}
 		switch owner.Type {
-		case "team":
+		case ownerTypeTeam:
 			teamIDs = append(teamIDs, owner.ID)
-		case "user":
+		case ownerTypeUser:
 			userIDs = append(userIDs, owner.ID)
+		default:
+			addUnknownOwnerType(diags, strict, "data product "+product.ID, owner.ID, owner.Type)
 		}
 	}
 	model.OwnerTeamIDs = stringsToSet(ctx, teamIDs, diags)
@@ -305,10 +350,10 @@ func setDataProductOwnerSets(ctx context.Context, model *DataProductResourceMode
 func dataProductOwners(ctx context.Context, teamIDs, userIDs types.Set, diags *diag.Diagnostics) []marmot.ProductOwner {
 	var out []marmot.ProductOwner
 	for _, id := range setStrings(ctx, teamIDs, diags) {
-		out = append(out, marmot.ProductOwner{ID: id, Type: "team"})
+		out = append(out, marmot.ProductOwner{ID: id, Type: ownerTypeTeam})
 	}
 	for _, id := range setStrings(ctx, userIDs, diags) {
-		out = append(out, marmot.ProductOwner{ID: id, Type: "user"})
+		out = append(out, marmot.ProductOwner{ID: id, Type: ownerTypeUser})
 	}
 	return out
 }
@@ -351,9 +396,9 @@ func (r *DataProductResource) updateModelFromResponse(ctx context.Context, model
 	model.UpdatedAt = types.StringValue(product.UpdatedAt)
 
 	if product.Description != "" {
-		model.Description = types.StringValue(product.Description)
+		model.Description = newMarkdownValue(product.Description)
 	} else {
-		model.Description = types.StringNull()
+		model.Description = newMarkdownNull()
 	}
 
 	if len(product.Tags) > 0 {
@@ -368,17 +413,9 @@ func (r *DataProductResource) updateModelFromResponse(ctx context.Context, model
 		model.Tags = types.SetNull(types.StringType)
 	}
 
-	setDataProductOwnerSets(ctx, model, product, &diags)
+	setDataProductOwnerSets(ctx, model, product, r.failOnUnknownEnums, &diags)
 
-	if metaMap, ok := product.Metadata.(map[string]any); ok && len(metaMap) > 0 {
-		strMap := make(map[string]string)
-		for k, v := range metaMap {
-			if strVal, ok := v.(string); ok {
-				strMap[k] = strVal
-			} else {
-				strMap[k] = fmt.Sprintf("%v", v)
-			}
-		}
+	if strMap := convert.StringMap(product.Metadata); strMap != nil {
 		metadata, diag := types.MapValueFrom(ctx, types.StringType, strMap)
 		diags.Append(diag...)
 		model.Metadata = metadata
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build acceptance
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/acctest"
+)
+
+func TestAccDataProductResource(t *testing.T) {
+	name := acctest.RandomName("product")
+	team := acctest.RandomName("product-team")
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed(t, "marmot_data_product", func(ctx context.Context, client *marmot.Client, id string) error {
+			_, err := client.DataProducts.Get(ctx, id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataProductConfig(name, team, "Order events"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("marmot_data_product.test", "name", name),
+					resource.TestCheckResourceAttr("marmot_data_product.test", "description", "Order events"),
+					resource.TestCheckResourceAttr("marmot_data_product.test", "metadata.domain", "commerce"),
+					resource.TestCheckResourceAttr("marmot_data_product.test", "owner_team_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("marmot_data_product.test", "owner_team_ids.*", "marmot_team.owner", "id"),
+					testAccStoreID("marmot_data_product.test", &id),
+				),
+			},
+			{
+				Config: testAccDataProductConfig(name, team, "Order events and the tables derived from them"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("marmot_data_product.test", "description", "Order events and the tables derived from them"),
+					resource.TestCheckResourceAttrPtr("marmot_data_product.test", "id", &id),
+				),
+			},
+			{
+				ResourceName:            "marmot_data_product.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"updated_at"},
+			},
+			testAccDriftStep(t, testAccDataProductConfig(name, team, "Order events and the tables derived from them"), func(ctx context.Context, client *marmot.Client) error {
+				current, err := client.DataProducts.Get(ctx, id)
+				if err != nil {
+					return err
+				}
+				_, err = client.DataProducts.Update(ctx, id, marmot.UpdateDataProductInput{
+					Name:        current.Name,
+					Description: "Changed outside Terraform",
+					Metadata:    map[string]any{"domain": "commerce"},
+					Tags:        current.Tags,
+				})
+				return err
+			}),
+		},
+	})
+}
+
+func testAccDataProductConfig(name, team, description string) string {
+	return testAccProviderConfig + fmt.Sprintf(`
+resource "marmot_team" "owner" {
+  name = %q
+}
+
+resource "marmot_data_product" "test" {
+  name        = %q
+  description = %q
+
+  tags = ["tfacc"]
+
+  owner_team_ids = [marmot_team.owner.id]
+
+  metadata = {
+    domain = "commerce"
+  }
+}
+`, team, name, description)
+}
@@ -5,6 +5,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -26,6 +27,7 @@ import (
 var _ resource.Resource = &DataProductRuleResource{}
 var _ resource.ResourceWithImportState = &DataProductRuleResource{}
 var _ resource.ResourceWithValidateConfig = &DataProductRuleResource{}
+var _ resource.ResourceWithModifyPlan = &DataProductRuleResource{}
 
 func NewDataProductRuleResource() resource.Resource {
 	return &DataProductRuleResource{}
@@ -34,6 +36,9 @@ func NewDataProductRuleResource() resource.Resource {
 // DataProductRuleResource defines the resource implementation.
 type DataProductRuleResource struct {
 	client *marmot.Client
+
+	// validateOnPlan previews rules during plan.
+	validateOnPlan bool
 }
 
 // DataProductRuleResourceModel describes the data product rule resource data model.
@@ -204,19 +209,77 @@ func (r *DataProductRuleResource) Configure(ctx context.Context, req resource.Co
 		return
 	}
 
-	client, ok := req.ProviderData.(*marmot.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *marmot.Client, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.validateOnPlan = data.validateOnPlan
+}
+
+// ModifyPlan previews a new or changed rule when the provider sets
+// validate_on_plan, so an expression Marmot can't evaluate fails the plan
+// instead of the apply.
+func (r *DataProductRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !r.validateOnPlan || req.Plan.Raw.IsNull() {
+		return
+	}
+	ctx = withAPIErrorCapture(ctx)
+
+	var data DataProductRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, v := range []types.String{data.Name, data.Type, data.QueryExpression, data.MetadataField, data.PatternType, data.PatternValue} {
+		if v.IsUnknown() {
+			return
+		}
+	}
+	if !req.State.Raw.IsNull() {
+		var state DataProductRuleResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if data.Type.Equal(state.Type) && data.QueryExpression.Equal(state.QueryExpression) &&
+			data.MetadataField.Equal(state.MetadataField) && data.PatternType.Equal(state.PatternType) &&
+			data.PatternValue.Equal(state.PatternValue) {
+			return
+		}
+	}
+
+	attr := path.Root("pattern_value")
+	if data.Type.ValueString() == "query" {
+		attr = path.Root("query_expression")
+	}
+
+	preview, err := r.client.DataProducts.PreviewRule(ctx, r.toRuleInput(data), 1)
+	var validationErr *marmot.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		resp.Diagnostics.AddAttributeError(attr, "Invalid Data Product Rule", clientErrorDetail(ctx, "Marmot rejected the rule", err))
+		return
+	case err != nil:
+		resp.Diagnostics.AddWarning(
+			"Unable to Validate Data Product Rule",
+			clientErrorDetail(ctx, "Unable to preview the rule; it is checked again on apply", err),
+		)
+		return
+	}
+	for _, msg := range preview.Errors {
+		resp.Diagnostics.AddAttributeError(attr, "Invalid Data Product Rule", "Marmot rejected the rule: "+msg)
+	}
 }
 
 func (r *DataProductRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data DataProductRuleResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -226,7 +289,7 @@ func (r *DataProductRuleResource) Create(ctx context.Context, req resource.Creat
 
 	rule, err := r.client.DataProducts.CreateRule(ctx, data.DataProductID.ValueString(), r.toRuleInput(data))
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create data product rule: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to create data product rule", err)
 		return
 	}
 
@@ -247,6 +310,8 @@ func (r *DataProductRuleResource) Create(ctx context.Context, req resource.Creat
 }
 
 func (r *DataProductRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data DataProductRuleResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -260,7 +325,7 @@ func (r *DataProductRuleResource) Read(ctx context.Context, req resource.ReadReq
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read data product rules: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to read data product rules", err)
 		return
 	}
 
@@ -282,6 +347,8 @@ func (r *DataProductRuleResource) Read(ctx context.Context, req resource.ReadReq
 }
 
 func (r *DataProductRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data DataProductRuleResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -297,7 +364,7 @@ func (r *DataProductRuleResource) Update(ctx context.Context, req resource.Updat
 
 	rule, err := r.client.DataProducts.UpdateRule(ctx, state.DataProductID.ValueString(), state.ID.ValueString(), r.toRuleInput(data))
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update data product rule: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to update data product rule", err)
 		return
 	}
 
@@ -313,6 +380,8 @@ func (r *DataProductRuleResource) Update(ctx context.Context, req resource.Updat
 }
 
 func (r *DataProductRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data DataProductRuleResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -324,7 +393,7 @@ func (r *DataProductRuleResource) Delete(ctx context.Context, req resource.Delet
 		if marmot.IsNotFound(err) {
 			return
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete data product rule: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to delete data product rule", err)
 		return
 	}
 
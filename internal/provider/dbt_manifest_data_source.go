@@ -0,0 +1,393 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DbtManifestDataSource{}
+
+func NewDbtManifestDataSource() datasource.DataSource {
+	return &DbtManifestDataSource{}
+}
+
+// DbtManifestDataSource defines the data source implementation. It reads a
+// local file only, so it needs no API client.
+type DbtManifestDataSource struct{}
+
+// DbtManifestDataSourceModel describes the dbt manifest data source data model.
+type DbtManifestDataSourceModel struct {
+	Path        types.String              `tfsdk:"path"`
+	ProjectName types.String              `tfsdk:"project_name"`
+	DbtVersion  types.String              `tfsdk:"dbt_version"`
+	Models      map[string]DbtModelModel  `tfsdk:"models"`
+	Sources     map[string]DbtSourceModel `tfsdk:"sources"`
+	Edges       []DbtEdgeModel            `tfsdk:"edges"`
+}
+
+// DbtModelModel describes one dbt model.
+type DbtModelModel struct {
+	Name            types.String     `tfsdk:"name"`
+	Description     types.String     `tfsdk:"description"`
+	Database        types.String     `tfsdk:"database"`
+	Schema          types.String     `tfsdk:"schema"`
+	Alias           types.String     `tfsdk:"alias"`
+	RelationName    types.String     `tfsdk:"relation_name"`
+	Materialization types.String     `tfsdk:"materialization"`
+	Tags            []string         `tfsdk:"tags"`
+	Columns         []DbtColumnModel `tfsdk:"columns"`
+	DependsOn       []string         `tfsdk:"depends_on"`
+}
+
+// DbtSourceModel describes one dbt source table.
+type DbtSourceModel struct {
+	Name         types.String     `tfsdk:"name"`
+	SourceName   types.String     `tfsdk:"source_name"`
+	Description  types.String     `tfsdk:"description"`
+	Database     types.String     `tfsdk:"database"`
+	Schema       types.String     `tfsdk:"schema"`
+	Identifier   types.String     `tfsdk:"identifier"`
+	RelationName types.String     `tfsdk:"relation_name"`
+	Tags         []string         `tfsdk:"tags"`
+	Columns      []DbtColumnModel `tfsdk:"columns"`
+}
+
+// DbtColumnModel describes one documented column of a model or source.
+type DbtColumnModel struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	DataType    types.String `tfsdk:"data_type"`
+}
+
+// DbtEdgeModel is one dependency between two nodes, by unique ID.
+type DbtEdgeModel struct {
+	Source types.String `tfsdk:"source"`
+	Target types.String `tfsdk:"target"`
+}
+
+// dbtManifest holds the parts of a dbt manifest.json the data source reads.
+type dbtManifest struct {
+	Metadata struct {
+		DbtVersion  string `json:"dbt_version"`
+		ProjectName string `json:"project_name"`
+	} `json:"metadata"`
+	Nodes   map[string]dbtNode `json:"nodes"`
+	Sources map[string]dbtNode `json:"sources"`
+}
+
+// dbtNode covers both manifest nodes and sources; fields that only one of
+// them carries are left empty on the other.
+type dbtNode struct {
+	ResourceType string               `json:"resource_type"`
+	Name         string               `json:"name"`
+	SourceName   string               `json:"source_name"`
+	Description  string               `json:"description"`
+	Database     string               `json:"database"`
+	Schema       string               `json:"schema"`
+	Alias        string               `json:"alias"`
+	Identifier   string               `json:"identifier"`
+	RelationName string               `json:"relation_name"`
+	Tags         []string             `json:"tags"`
+	Columns      map[string]dbtColumn `json:"columns"`
+	Config       struct {
+		Materialized string `json:"materialized"`
+	} `json:"config"`
+	DependsOn struct {
+		Nodes []string `json:"nodes"`
+	} `json:"depends_on"`
+}
+
+type dbtColumn struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	DataType    string `json:"data_type"`
+}
+
+func (d *DbtManifestDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dbt_manifest"
+}
+
+func (d *DbtManifestDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	columns := schema.ListNestedAttribute{
+		MarkdownDescription: "Documented columns, sorted by name",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Column name",
+					Computed:            true,
+				},
+				"description": schema.StringAttribute{
+					MarkdownDescription: "Column description",
+					Computed:            true,
+				},
+				"data_type": schema.StringAttribute{
+					MarkdownDescription: "Declared data type; null when the project doesn't set one",
+					Computed:            true,
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads a dbt `manifest.json` from disk and exposes its models, sources, " +
+			"columns, and dependencies, so a module can create a `marmot_asset` per model or source " +
+			"and a `marmot_lineage` per edge with `for_each`. Makes no API calls.",
+
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path to the manifest, usually `target/manifest.json` in the dbt project",
+				Required:            true,
+			},
+			"project_name": schema.StringAttribute{
+				MarkdownDescription: "dbt project name recorded in the manifest",
+				Computed:            true,
+			},
+			"dbt_version": schema.StringAttribute{
+				MarkdownDescription: "dbt version that wrote the manifest",
+				Computed:            true,
+			},
+			"models": schema.MapNestedAttribute{
+				MarkdownDescription: "Models, keyed by unique ID such as `model.shop.orders`",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Model name",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Model description",
+							Computed:            true,
+						},
+						"database": schema.StringAttribute{
+							MarkdownDescription: "Database the model builds into",
+							Computed:            true,
+						},
+						"schema": schema.StringAttribute{
+							MarkdownDescription: "Schema the model builds into",
+							Computed:            true,
+						},
+						"alias": schema.StringAttribute{
+							MarkdownDescription: "Name of the built relation",
+							Computed:            true,
+						},
+						"relation_name": schema.StringAttribute{
+							MarkdownDescription: "Fully qualified relation name; null when dbt doesn't record one",
+							Computed:            true,
+						},
+						"materialization": schema.StringAttribute{
+							MarkdownDescription: "Materialization, such as `table`, `view`, or `incremental`",
+							Computed:            true,
+						},
+						"tags": schema.ListAttribute{
+							MarkdownDescription: "dbt tags",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"columns": columns,
+						"depends_on": schema.ListAttribute{
+							MarkdownDescription: "Unique IDs of the models and sources this model reads from",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+			"sources": schema.MapNestedAttribute{
+				MarkdownDescription: "Sources, keyed by unique ID such as `source.shop.raw.orders`",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Source table name",
+							Computed:            true,
+						},
+						"source_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the source the table belongs to",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Source table description",
+							Computed:            true,
+						},
+						"database": schema.StringAttribute{
+							MarkdownDescription: "Database of the table",
+							Computed:            true,
+						},
+						"schema": schema.StringAttribute{
+							MarkdownDescription: "Schema of the table",
+							Computed:            true,
+						},
+						"identifier": schema.StringAttribute{
+							MarkdownDescription: "Table name in the warehouse",
+							Computed:            true,
+						},
+						"relation_name": schema.StringAttribute{
+							MarkdownDescription: "Fully qualified relation name; null when dbt doesn't record one",
+							Computed:            true,
+						},
+						"tags": schema.ListAttribute{
+							MarkdownDescription: "dbt tags",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"columns": columns,
+					},
+				},
+			},
+			"edges": schema.ListNestedAttribute{
+				MarkdownDescription: "Dependencies between models and sources, sorted by target then " +
+					"source. Edges from tests, seeds, snapshots, and other node types are left out.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source": schema.StringAttribute{
+							MarkdownDescription: "Unique ID of the upstream model or source",
+							Computed:            true,
+						},
+						"target": schema.StringAttribute{
+							MarkdownDescription: "Unique ID of the downstream model",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DbtManifestDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DbtManifestDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	raw, err := os.ReadFile(data.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("path"),
+			"Unable to Read dbt Manifest",
+			err.Error(),
+		)
+		return
+	}
+
+	var manifest dbtManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("path"),
+			"Invalid dbt Manifest",
+			fmt.Sprintf("%s is not a dbt manifest.json: %s", data.Path.ValueString(), err),
+		)
+		return
+	}
+
+	data.ProjectName = types.StringValue(manifest.Metadata.ProjectName)
+	data.DbtVersion = types.StringValue(manifest.Metadata.DbtVersion)
+
+	data.Sources = make(map[string]DbtSourceModel, len(manifest.Sources))
+	for id, node := range manifest.Sources {
+		data.Sources[id] = DbtSourceModel{
+			Name:         types.StringValue(node.Name),
+			SourceName:   types.StringValue(node.SourceName),
+			Description:  types.StringValue(node.Description),
+			Database:     types.StringValue(node.Database),
+			Schema:       types.StringValue(node.Schema),
+			Identifier:   types.StringValue(node.Identifier),
+			RelationName: optionalString(node.RelationName),
+			Tags:         nonNilStrings(node.Tags),
+			Columns:      dbtColumns(node.Columns),
+		}
+	}
+
+	data.Models = map[string]DbtModelModel{}
+	for id, node := range manifest.Nodes {
+		if node.ResourceType == "model" {
+			data.Models[id] = DbtModelModel{
+				Name:            types.StringValue(node.Name),
+				Description:     types.StringValue(node.Description),
+				Database:        types.StringValue(node.Database),
+				Schema:          types.StringValue(node.Schema),
+				Alias:           types.StringValue(node.Alias),
+				RelationName:    optionalString(node.RelationName),
+				Materialization: types.StringValue(node.Config.Materialized),
+				Tags:            nonNilStrings(node.Tags),
+				Columns:         dbtColumns(node.Columns),
+			}
+		}
+	}
+
+	// Keep only dependencies between the models and sources exposed above,
+	// so every edge can be mapped onto a pair of catalog assets.
+	data.Edges = []DbtEdgeModel{}
+	for id, model := range data.Models {
+		dependsOn := []string{}
+		for _, upstream := range manifest.Nodes[id].DependsOn.Nodes {
+			_, isModel := data.Models[upstream]
+			_, isSource := data.Sources[upstream]
+			if isModel || isSource {
+				dependsOn = append(dependsOn, upstream)
+			}
+		}
+		sort.Strings(dependsOn)
+		model.DependsOn = dependsOn
+		data.Models[id] = model
+
+		for _, upstream := range dependsOn {
+			data.Edges = append(data.Edges, DbtEdgeModel{
+				Source: types.StringValue(upstream),
+				Target: types.StringValue(id),
+			})
+		}
+	}
+	sort.Slice(data.Edges, func(i, j int) bool {
+		a, b := data.Edges[i], data.Edges[j]
+		if a.Target.ValueString() != b.Target.ValueString() {
+			return a.Target.ValueString() < b.Target.ValueString()
+		}
+		return a.Source.ValueString() < b.Source.ValueString()
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// dbtColumns converts a node's column map into a list sorted by name.
+func dbtColumns(columns map[string]dbtColumn) []DbtColumnModel {
+	out := make([]DbtColumnModel, 0, len(columns))
+	for key, col := range columns {
+		name := col.Name
+		if name == "" {
+			name = key
+		}
+		out = append(out, DbtColumnModel{
+			Name:        types.StringValue(name),
+			Description: types.StringValue(col.Description),
+			DataType:    optionalString(col.DataType),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name.ValueString() < out[j].Name.ValueString() })
+	return out
+}
+
+// optionalString returns s as a string value, or null when it is empty.
+func optionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
@@ -0,0 +1,265 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// maxErrorBodyBytes bounds how much of a failed response body is kept for
+// diagnostics.
+const maxErrorBodyBytes = 64 << 10
+
+// requestIDHeaders are the response headers checked, in order, for an ID the
+// server or a gateway in front of it assigned to the request.
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "X-Trace-Id"}
+
+// apiFailure records the details of the failed response to the latest API
+// request sent under a context returned by withAPIErrorCapture. The SDK's
+// typed errors keep only the server's message, so the transport fills this
+// in to give diagnostics the status, request ID, and field-level errors as
+// well. Each request clears it when sent, so a failure is never reported
+// with the details of an earlier one.
+type apiFailure struct {
+	mu        sync.Mutex
+	method    string
+	path      string
+	status    int
+	requestID string
+	body      []byte
+}
+
+type apiFailureKey struct{}
+
+// clear forgets the recorded failure.
+func (f *apiFailure) clear() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.method, f.path, f.status, f.requestID, f.body = "", "", 0, "", nil
+}
+
+// withAPIErrorCapture returns a context under which failed API responses are
+// recorded for addClientError. Resource and data source methods wrap their
+// context with it before calling the client.
+func withAPIErrorCapture(ctx context.Context) context.Context {
+	return context.WithValue(ctx, apiFailureKey{}, &apiFailure{})
+}
+
+// errorCaptureTransport copies the status, request ID, and body of failed
+// responses into the apiFailure carried by the request context, if any. The
+// body is restored so the SDK can still decode it.
+type errorCaptureTransport struct {
+	base http.RoundTripper
+}
+
+func (t *errorCaptureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	failure, ok := req.Context().Value(apiFailureKey{}).(*apiFailure)
+	if ok {
+		failure.clear()
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode < 400 || !ok {
+		return resp, err
+	}
+
+	body, readErr := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	rest := resp.Body
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), rest), rest}
+	if readErr != nil {
+		body = nil
+	}
+
+	failure.mu.Lock()
+	defer failure.mu.Unlock()
+	failure.method = req.Method
+	failure.path = req.URL.Path
+	failure.status = resp.StatusCode
+	failure.body = body
+	failure.requestID = ""
+	for _, h := range requestIDHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			failure.requestID = v
+			break
+		}
+	}
+
+	return resp, nil
+}
+
+// apiStatusCode returns the HTTP status of one of the SDK's API errors. The
+// typed errors embed *marmot.APIError without unwrapping to it, so each is
+// matched on its own.
+func apiStatusCode(err error) (int, bool) {
+	var (
+		validation *marmot.ValidationError
+		auth       *marmot.AuthError
+		notFound   *marmot.NotFoundError
+		rateLimit  *marmot.RateLimitError
+		server     *marmot.ServerError
+		apiErr     *marmot.APIError
+	)
+	switch {
+	case errors.As(err, &validation):
+		return validation.StatusCode, true
+	case errors.As(err, &auth):
+		return auth.StatusCode, true
+	case errors.As(err, &notFound):
+		return notFound.StatusCode, true
+	case errors.As(err, &rateLimit):
+		return rateLimit.StatusCode, true
+	case errors.As(err, &server):
+		return server.StatusCode, true
+	case errors.As(err, &apiErr):
+		return apiErr.StatusCode, true
+	}
+	return 0, false
+}
+
+// rotationAdvice is added to diagnostics for 401 responses, which mid-run
+// usually mean the API key was rotated or revoked.
+const rotationAdvice = "Marmot rejected the credential. If the API key was rotated, set api_key or " +
+	"MARMOT_API_KEY to the new key and run again. To rotate without failing runs, set " +
+	"api_key_secondary to the new key first and revoke the old key once every configuration " +
+	"uses it."
+
+// apiErrorBody is the subset of the Marmot error payload the provider reads.
+// Validation failures list per-field problems under "details" or "errors",
+// either as {field, message} objects or as a field-to-message map.
+type apiErrorBody struct {
+	Error   string          `json:"error"`
+	Message string          `json:"message"`
+	Details json.RawMessage `json:"details"`
+	Errors  json.RawMessage `json:"errors"`
+}
+
+type apiFieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// fieldErrors decodes per-field validation errors from either supported shape.
+func fieldErrors(raw json.RawMessage) []apiFieldError {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var list []apiFieldError
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list
+	}
+
+	var byField map[string]string
+	if err := json.Unmarshal(raw, &byField); err == nil {
+		for field, msg := range byField {
+			list = append(list, apiFieldError{Field: field, Message: msg})
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Field < list[j].Field })
+		return list
+	}
+
+	return nil
+}
+
+// addClientError adds a "Client Error" diagnostic for a failed client call.
+// The detail starts with action and the SDK error, then adds whatever the
+// transport captured about the failed response: HTTP status, request ID, and
+// field-level validation errors, falling back to the raw body when it isn't
+// the usual JSON error shape.
+func addClientError(ctx context.Context, diags *diag.Diagnostics, action string, err error) {
+	diags.AddError("Client Error", clientErrorDetail(ctx, action, err))
+}
+
+// addItemClientError is addClientError for one item of a bulk operation,
+// reported at the item's path so each failure names the item it belongs to.
+// The captured response is cleared afterwards, so the next item's failure
+// isn't reported with this one's status and body.
+func addItemClientError(ctx context.Context, diags *diag.Diagnostics, itemPath path.Path, action string, err error) {
+	diags.AddAttributeError(itemPath, "Client Error", clientErrorDetail(ctx, action, err))
+
+	if failure, ok := ctx.Value(apiFailureKey{}).(*apiFailure); ok {
+		failure.clear()
+	}
+}
+
+// clientErrorDetail builds the detail text used by addClientError. The
+// captured response is only added when err is the SDK error for it, so an
+// error that never got a response, or one the provider raised itself, isn't
+// reported with the details of another request.
+func clientErrorDetail(ctx context.Context, action string, err error) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s", action, err)
+
+	failure, ok := ctx.Value(apiFailureKey{}).(*apiFailure)
+	if !ok {
+		return b.String()
+	}
+
+	failure.mu.Lock()
+	defer failure.mu.Unlock()
+	if status, ok := apiStatusCode(err); failure.status == 0 || !ok || status != failure.status {
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "\n\nRequest: %s %s", failure.method, failure.path)
+	fmt.Fprintf(&b, "\nHTTP status: %d %s", failure.status, http.StatusText(failure.status))
+	if failure.requestID != "" {
+		fmt.Fprintf(&b, "\nRequest ID: %s", failure.requestID)
+	}
+	if failure.status == http.StatusUnauthorized {
+		b.WriteString("\n\n" + rotationAdvice)
+	}
+
+	body := bytes.TrimSpace(failure.body)
+	if len(body) == 0 {
+		return b.String()
+	}
+
+	var parsed apiErrorBody
+	if jsonErr := json.Unmarshal(body, &parsed); jsonErr != nil {
+		fmt.Fprintf(&b, "\nResponse body: %s", body)
+		return b.String()
+	}
+
+	msg := parsed.Message
+	if msg == "" {
+		msg = parsed.Error
+	}
+	if msg != "" && !strings.Contains(err.Error(), msg) {
+		fmt.Fprintf(&b, "\nServer message: %s", msg)
+	}
+
+	fields := fieldErrors(parsed.Details)
+	if fields == nil {
+		fields = fieldErrors(parsed.Errors)
+	}
+	if len(fields) > 0 {
+		b.WriteString("\nField errors:")
+		for _, f := range fields {
+			if f.Field == "" {
+				fmt.Fprintf(&b, "\n  - %s", f.Message)
+				continue
+			}
+			fmt.Fprintf(&b, "\n  - %s: %s", f.Field, f.Message)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+func TestClientErrorDetailCapture(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ok" {
+			return
+		}
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"error":"invalid asset","details":[{"field":"name","message":"is required"}]}`)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &errorCaptureTransport{base: http.DefaultTransport}}
+	send := func(ctx context.Context, path string) {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	validation := &marmot.ValidationError{APIError: &marmot.APIError{StatusCode: 400, Message: "invalid asset"}}
+
+	tests := map[string]struct {
+		paths    []string
+		err      error
+		contains []string
+		excludes []string
+	}{
+		"matching error": {
+			paths:    []string{"/fail"},
+			err:      validation,
+			contains: []string{"Request: POST /fail", "HTTP status: 400", "Request ID: req-1", "name: is required"},
+		},
+		"later request succeeded": {
+			paths:    []string{"/fail", "/ok"},
+			err:      validation,
+			excludes: []string{"HTTP status", "Request ID"},
+		},
+		"different status": {
+			paths:    []string{"/fail"},
+			err:      &marmot.NotFoundError{APIError: &marmot.APIError{StatusCode: 404}},
+			excludes: []string{"HTTP status", "Request ID"},
+		},
+		"not an API error": {
+			paths:    []string{"/fail"},
+			err:      errors.New("asset has no MRN"),
+			excludes: []string{"HTTP status", "Request ID"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := withAPIErrorCapture(t.Context())
+			for _, p := range tt.paths {
+				send(ctx, p)
+			}
+			detail := clientErrorDetail(ctx, "Unable to create asset", tt.err)
+			for _, s := range tt.contains {
+				if !strings.Contains(detail, s) {
+					t.Errorf("detail doesn't contain %q:\n%s", s, detail)
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(detail, s) {
+					t.Errorf("detail contains %q:\n%s", s, detail)
+				}
+			}
+		})
+	}
+}
+
+func TestFieldErrors(t *testing.T) {
+	tests := map[string]struct {
+		raw  string
+		want []apiFieldError
+	}{
+		"empty": {raw: ""},
+		"list": {
+			raw:  `[{"field":"name","message":"is required"},{"field":"type","message":"is invalid"}]`,
+			want: []apiFieldError{{Field: "name", Message: "is required"}, {Field: "type", Message: "is invalid"}},
+		},
+		"map sorted by field": {
+			raw:  `{"type":"is invalid","name":"is required"}`,
+			want: []apiFieldError{{Field: "name", Message: "is required"}, {Field: "type", Message: "is invalid"}},
+		},
+		"unsupported shape": {raw: `"name is required"`},
+		"invalid":           {raw: `{`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := fieldErrors([]byte(tt.raw)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
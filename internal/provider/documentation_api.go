@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// assetDocumentation mirrors the Marmot asset documentation payload: a
+// Markdown page attached to an asset by MRN, tagged with the source that
+// wrote it.
+type assetDocumentation struct {
+	ID        string `json:"id,omitempty"`
+	MRN       string `json:"mrn,omitempty"`
+	Content   string `json:"content"`
+	Source    string `json:"source,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// documentationSource is the source recorded on documentation this
+// provider uploads.
+const documentationSource = "terraform"
+
+func (c *apiClient) getAssetDocumentation(ctx context.Context, mrn string) (*assetDocumentation, error) {
+	var out assetDocumentation
+	if err := c.do(ctx, http.MethodGet, "/assets/documentation/"+url.PathEscape(mrn), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// putAssetDocumentation creates or replaces the asset's documentation.
+func (c *apiClient) putAssetDocumentation(ctx context.Context, mrn, content string) (*assetDocumentation, error) {
+	var out assetDocumentation
+	in := assetDocumentation{MRN: mrn, Content: content, Source: documentationSource}
+	if err := c.do(ctx, http.MethodPost, "/assets/documentation", nil, in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
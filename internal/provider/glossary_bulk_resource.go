@@ -0,0 +1,585 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GlossaryBulkResource{}
+var _ resource.ResourceWithValidateConfig = &GlossaryBulkResource{}
+var _ resource.ResourceWithModifyPlan = &GlossaryBulkResource{}
+
+func NewGlossaryBulkResource() resource.Resource {
+	return &GlossaryBulkResource{}
+}
+
+// GlossaryBulkResource defines the resource implementation.
+type GlossaryBulkResource struct {
+	client *marmot.Client
+}
+
+// GlossaryBulkResourceModel describes the bulk glossary resource data model.
+type GlossaryBulkResourceModel struct {
+	Document types.String `tfsdk:"document"`
+	Format   types.String `tfsdk:"format"`
+	TermIDs  types.Map    `tfsdk:"term_ids"`
+	Checksum types.String `tfsdk:"checksum"`
+}
+
+func (r *GlossaryBulkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_glossary"
+}
+
+func (r *GlossaryBulkResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a whole glossary tree from one YAML or CSV document, so hundreds " +
+			"of terms don't need one `marmot_glossary_term` resource each. Every apply reads the " +
+			"glossary once and only creates, updates, or deletes the terms that differ from the " +
+			"document. Terms the document doesn't list are left alone.\n\n" +
+			"A YAML document is a list of terms, each with `name`, `definition`, and optionally " +
+			"`description`, `metadata`, `key`, and `children`. A CSV document has a header row " +
+			"with `path` (names from the root, joined with `/`), `definition`, and optionally " +
+			"`description`, `key`, and `metadata.<key>` columns.\n\n" +
+			"Terms are identified by their path, or by `key` when it is set, so setting `key` lets " +
+			"a term be renamed or moved without being recreated. Terms that already exist at a " +
+			"path the document lists are taken over rather than duplicated.\n\n" +
+			"A term that fails to be written is reported against its `term_ids` entry while the " +
+			"other terms are still applied and kept in state, so the next apply only retries the " +
+			"failures. Children of a term that couldn't be created are skipped until it is.\n\n" +
+			"Don't manage the same term here and in `marmot_glossary_term`.",
+
+		Attributes: map[string]schema.Attribute{
+			"document": schema.StringAttribute{
+				MarkdownDescription: "The glossary, in the format given by `format`, typically read " +
+					"with `file()`",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"format": schema.StringAttribute{
+				MarkdownDescription: "Format of `document`: `yaml` (the default) or `csv`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(glossaryFormatYAML),
+				Validators: []validator.String{
+					stringvalidator.OneOf(glossaryFormatYAML, glossaryFormatCSV),
+				},
+			},
+			"term_ids": schema.MapAttribute{
+				MarkdownDescription: "Glossary term IDs, keyed by each term's `key`, or its path " +
+					"when it has none",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"checksum": schema.StringAttribute{
+				MarkdownDescription: "Hash of the managed terms. It differs from the document's " +
+					"whenever a term was changed or deleted outside Terraform, which plans an update " +
+					"that restores it.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *GlossaryBulkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+// ValidateConfig parses the document so malformed terms fail at validate
+// time rather than halfway through an apply.
+func (r *GlossaryBulkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data GlossaryBulkResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if isEmptyString(data.Document) || data.Format.IsUnknown() {
+		return
+	}
+
+	if _, err := parseGlossaryDocument(data.Document.ValueString(), data.Format.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("document"), "Invalid Glossary Document", err.Error())
+	}
+}
+
+// ModifyPlan plans the checksum of the document. When it differs from the
+// checksum read from the server, term_ids is marked unknown since terms may
+// be created.
+func (r *GlossaryBulkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan GlossaryBulkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Document.IsUnknown() || plan.Format.IsUnknown() {
+		return
+	}
+
+	terms, err := parseGlossaryDocument(plan.Document.ValueString(), plan.Format.ValueString())
+	if err != nil {
+		// ValidateConfig has already reported it.
+		return
+	}
+	checksum := types.StringValue(glossaryChecksum(glossaryDocContents(terms)))
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("checksum"), checksum)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var state GlossaryBulkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !checksum.Equal(state.Checksum) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("term_ids"), types.MapUnknown(types.StringType))...)
+	}
+}
+
+func (r *GlossaryBulkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data GlossaryBulkResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.reconcile(ctx, &data, map[string]string{}, &resp.State, &resp.Diagnostics)
+}
+
+func (r *GlossaryBulkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data GlossaryBulkResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	terms, err := listGlossaryTerms(ctx, r.client)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to list glossary terms", err)
+		return
+	}
+	byID := liveGlossaryTerms(terms)
+
+	// Terms deleted outside Terraform drop out of term_ids, which changes the
+	// checksum, so the next plan recreates them.
+	ids := idMap(data.TermIDs)
+	for key, id := range ids {
+		if _, ok := byID[id]; !ok {
+			delete(ids, key)
+		}
+	}
+	if len(ids) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Checksum = types.StringValue(glossaryChecksum(glossaryServerContents(ids, byID)))
+	resp.Diagnostics.Append(setGlossaryTermIDs(ctx, &data, ids)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GlossaryBulkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data GlossaryBulkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state GlossaryBulkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.reconcile(ctx, &data, idMap(state.TermIDs), &resp.State, &resp.Diagnostics)
+}
+
+func (r *GlossaryBulkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data GlossaryBulkResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	terms, err := listGlossaryTerms(ctx, r.client)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to list glossary terms", err)
+		return
+	}
+
+	ids := idMap(data.TermIDs)
+	removed := make([]string, 0, len(ids))
+	for key := range ids {
+		removed = append(removed, key)
+	}
+	if err := r.deleteTerms(ctx, liveGlossaryTerms(terms), ids, removed); err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to delete glossary terms", err)
+		return
+	}
+
+	tflog.Info(ctx, "Glossary terms deleted", map[string]interface{}{
+		"terms": len(removed),
+	})
+}
+
+// glossaryStateSetter is the state Create and Update write to.
+type glossaryStateSetter interface {
+	Set(ctx context.Context, val interface{}) diag.Diagnostics
+}
+
+// reconcile makes the server's terms match the document, starting from the
+// terms recorded in ids, and writes the result to state. A term that fails
+// to be written is reported at its path and the other terms are still
+// written, except for the children of a term that couldn't be created. On
+// failure the terms written so far are kept in state with an empty checksum,
+// so the next plan finishes the job.
+func (r *GlossaryBulkResource) reconcile(ctx context.Context, data *GlossaryBulkResourceModel, ids map[string]string, state glossaryStateSetter, diags *diag.Diagnostics) {
+	desired, err := parseGlossaryDocument(data.Document.ValueString(), data.Format.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("document"), "Invalid Glossary Document", err.Error())
+		return
+	}
+
+	terms, err := listGlossaryTerms(ctx, r.client)
+	if err != nil {
+		addClientError(ctx, diags, "Unable to list glossary terms", err)
+		return
+	}
+	byID := liveGlossaryTerms(terms)
+
+	// Drop terms deleted outside Terraform, so they are created again.
+	for key, id := range ids {
+		if _, ok := byID[id]; !ok {
+			delete(ids, key)
+		}
+	}
+
+	setPartial := func() {
+		partial := *data
+		partial.Checksum = types.StringValue("")
+		diags.Append(setGlossaryTermIDs(ctx, &partial, ids)...)
+		diags.Append(state.Set(ctx, &partial)...)
+	}
+
+	managed := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		managed[id] = true
+	}
+
+	var created, updated, adopted, failed int
+	planned := make(map[string]bool, len(desired))
+	for _, term := range desired {
+		planned[term.Key] = true
+		parentID := ""
+		if term.ParentKey != "" {
+			parentID = ids[term.ParentKey]
+			if parentID == "" {
+				diags.AddAttributeError(
+					glossaryTermPath(term.Key),
+					"Glossary Term Not Written",
+					fmt.Sprintf("The glossary term %q was skipped because its parent could not be created.", term.Path),
+				)
+				failed++
+				continue
+			}
+		}
+
+		id, ok := ids[term.Key]
+		if !ok {
+			// Take over a term that is already at this path, such as one
+			// created in the UI or by marmot_glossary_term.
+			if existing := findGlossaryChild(byID, parentID, term.Name, managed); existing != nil {
+				id, ok = existing.ID, true
+				ids[term.Key] = id
+				managed[id] = true
+				adopted++
+				tflog.Info(ctx, "Adopting existing glossary term", map[string]interface{}{
+					"id":   id,
+					"path": term.Path,
+				})
+			}
+		}
+
+		if !ok {
+			result, err := r.client.Glossary.Create(ctx, marmot.CreateTermInput{
+				Name:         term.Name,
+				Definition:   term.Definition,
+				Description:  term.Description,
+				ParentTermID: parentID,
+				Metadata:     glossaryDocMetadata(term.Metadata),
+			})
+			if err != nil {
+				addItemClientError(ctx, diags, glossaryTermPath(term.Key), fmt.Sprintf("Unable to create glossary term %q", term.Path), err)
+				failed++
+				continue
+			}
+			ids[term.Key] = result.ID
+			managed[result.ID] = true
+			byID[result.ID] = result
+			created++
+			continue
+		}
+
+		current := glossaryServerContent(term.Key, byID[id], ids, byID)
+		if current.equal(term.content()) {
+			continue
+		}
+		result, err := r.client.Glossary.Update(ctx, id, marmot.UpdateTermInput{
+			Name:         term.Name,
+			Definition:   term.Definition,
+			Description:  term.Description,
+			ParentTermID: parentID,
+			Owners:       glossaryTermOwners(byID[id]),
+			Metadata:     glossaryDocMetadata(term.Metadata),
+		})
+		if err != nil {
+			addItemClientError(ctx, diags, glossaryTermPath(term.Key), fmt.Sprintf("Unable to update glossary term %q", term.Path), err)
+			failed++
+			continue
+		}
+		byID[id] = result
+		updated++
+	}
+
+	var removed []string
+	for key := range ids {
+		if !planned[key] {
+			removed = append(removed, key)
+		}
+	}
+	if err := r.deleteTerms(ctx, byID, ids, removed); err != nil {
+		setPartial()
+		addClientError(ctx, diags, "Unable to delete glossary terms", err)
+		return
+	}
+	if failed > 0 {
+		tflog.Warn(ctx, "Glossary partially reconciled", map[string]interface{}{
+			"created": created,
+			"updated": updated,
+			"adopted": adopted,
+			"failed":  failed,
+		})
+		setPartial()
+		return
+	}
+
+	// Every term now matches the document, so the checksum is the planned
+	// one; the next refresh recomputes it from the server.
+	data.Checksum = types.StringValue(glossaryChecksum(glossaryDocContents(desired)))
+	diags.Append(setGlossaryTermIDs(ctx, data, ids)...)
+
+	tflog.Info(ctx, "Glossary reconciled", map[string]interface{}{
+		"created": created,
+		"updated": updated,
+		"adopted": adopted,
+		"deleted": len(removed),
+	})
+
+	diags.Append(state.Set(ctx, data)...)
+}
+
+// deleteTerms deletes the terms under the given keys, children before their
+// parents, removing each from ids as it goes. It refuses to delete a term
+// that still has children the document doesn't manage.
+func (r *GlossaryBulkResource) deleteTerms(ctx context.Context, byID map[string]*marmot.GlossaryTerm, ids map[string]string, keys []string) error {
+	deleting := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		deleting[ids[key]] = true
+	}
+
+	var blocked []string
+	for _, term := range byID {
+		if !deleting[term.ID] && deleting[term.ParentTermID] {
+			blocked = append(blocked, fmt.Sprintf("%s (%s)", term.Name, term.ID))
+		}
+	}
+	if len(blocked) > 0 {
+		sort.Strings(blocked)
+		return fmt.Errorf("terms removed from the document still have children that it doesn't manage: %s; "+
+			"delete or move them first", strings.Join(blocked, ", "))
+	}
+
+	depth := func(id string) int {
+		n := 0
+		for term := byID[id]; term != nil && term.ParentTermID != ""; term = byID[term.ParentTermID] {
+			n++
+		}
+		return n
+	}
+	sort.SliceStable(keys, func(i, j int) bool { return depth(ids[keys[i]]) > depth(ids[keys[j]]) })
+
+	for _, key := range keys {
+		if err := r.client.Glossary.Delete(ctx, ids[key]); err != nil && !marmot.IsNotFound(err) {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		delete(byID, ids[key])
+		delete(ids, key)
+	}
+	return nil
+}
+
+// listGlossaryTerms returns every glossary term, following pagination.
+func listGlossaryTerms(ctx context.Context, client *marmot.Client) ([]*marmot.GlossaryTerm, error) {
+	var terms []*marmot.GlossaryTerm
+	for offset := int64(0); ; offset += glossaryPageSize {
+		page, err := client.Glossary.List(ctx, marmot.GlossaryListOptions{Limit: glossaryPageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, page.Terms...)
+		if len(page.Terms) < glossaryPageSize || int64(len(terms)) >= page.Total {
+			return terms, nil
+		}
+	}
+}
+
+// liveGlossaryTerms indexes the terms that aren't deleted by ID.
+func liveGlossaryTerms(terms []*marmot.GlossaryTerm) map[string]*marmot.GlossaryTerm {
+	byID := make(map[string]*marmot.GlossaryTerm, len(terms))
+	for _, term := range terms {
+		if term != nil && term.ID != "" && term.DeletedAt == "" {
+			byID[term.ID] = term
+		}
+	}
+	return byID
+}
+
+// findGlossaryChild returns the unmanaged term named name under parentID,
+// or nil when there is none.
+func findGlossaryChild(byID map[string]*marmot.GlossaryTerm, parentID, name string, managed map[string]bool) *marmot.GlossaryTerm {
+	for _, term := range byID {
+		if term.Name == name && term.ParentTermID == parentID && !managed[term.ID] {
+			return term
+		}
+	}
+	return nil
+}
+
+func glossaryDocContents(terms []glossaryDocTerm) []glossaryTermContent {
+	out := make([]glossaryTermContent, len(terms))
+	for i, term := range terms {
+		out[i] = term.content()
+	}
+	return out
+}
+
+// glossaryServerContents returns the content of every managed term as the
+// server has it.
+func glossaryServerContents(ids map[string]string, byID map[string]*marmot.GlossaryTerm) []glossaryTermContent {
+	out := make([]glossaryTermContent, 0, len(ids))
+	for key, id := range ids {
+		if term, ok := byID[id]; ok {
+			out = append(out, glossaryServerContent(key, term, ids, byID))
+		}
+	}
+	return out
+}
+
+// glossaryServerContent returns a term's content as the server has it. A
+// parent outside the document is written by ID, so it never matches.
+func glossaryServerContent(key string, term *marmot.GlossaryTerm, ids map[string]string, byID map[string]*marmot.GlossaryTerm) glossaryTermContent {
+	parentKey := ""
+	if term.ParentTermID != "" {
+		parentKey = "id:" + term.ParentTermID
+		for k, id := range ids {
+			if id == term.ParentTermID {
+				parentKey = k
+				break
+			}
+		}
+	}
+
+	metadata := convert.StringMap(term.Metadata)
+
+	return glossaryTermContent{
+		Key:         key,
+		ParentKey:   parentKey,
+		Name:        term.Name,
+		Definition:  normalizeMarkdown(term.Definition),
+		Description: normalizeMarkdown(term.Description),
+		Metadata:    metadata,
+	}
+}
+
+// glossaryTermOwners returns the term's current owners, so updates keep
+// them.
+func glossaryTermOwners(term *marmot.GlossaryTerm) []marmot.TermOwner {
+	var out []marmot.TermOwner
+	for _, owner := range term.Owners {
+		if owner != nil && owner.ID != "" {
+			out = append(out, marmot.TermOwner{ID: owner.ID, Type: owner.Type})
+		}
+	}
+	return out
+}
+
+func glossaryDocMetadata(m map[string]string) map[string]any {
+	if len(m) == 0 {
+		return nil
+	}
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// glossaryTermPath is where diagnostics about one term are reported: its
+// entry in term_ids.
+func glossaryTermPath(key string) path.Path {
+	return path.Root("term_ids").AtMapKey(key)
+}
+
+func setGlossaryTermIDs(ctx context.Context, model *GlossaryBulkResourceModel, ids map[string]string) diag.Diagnostics {
+	m, diags := types.MapValueFrom(ctx, types.StringType, ids)
+	model.TermIDs = m
+	return diags
+}
@@ -0,0 +1,307 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats accepted by marmot_glossary's document.
+const (
+	glossaryFormatYAML = "yaml"
+	glossaryFormatCSV  = "csv"
+)
+
+// glossaryCSVMetadataPrefix marks CSV columns that hold a metadata key.
+const glossaryCSVMetadataPrefix = "metadata."
+
+// glossaryDocTerm is one term of a marmot_glossary document, flattened out
+// of the hierarchy. Key identifies the term across applies; it defaults to
+// the term's path.
+type glossaryDocTerm struct {
+	Key         string
+	Path        string
+	ParentKey   string
+	Name        string
+	Definition  string
+	Description string
+	Metadata    map[string]string
+}
+
+// glossaryYAMLTerm is a term as written in a YAML document.
+type glossaryYAMLTerm struct {
+	Name        string             `yaml:"name"`
+	Key         string             `yaml:"key"`
+	Definition  string             `yaml:"definition"`
+	Description string             `yaml:"description"`
+	Metadata    map[string]string  `yaml:"metadata"`
+	Children    []glossaryYAMLTerm `yaml:"children"`
+}
+
+// parseGlossaryDocument parses a document into its terms, ordered so that
+// every term comes after its parent.
+func parseGlossaryDocument(document, format string) ([]glossaryDocTerm, error) {
+	var terms []glossaryDocTerm
+	var err error
+	switch format {
+	case glossaryFormatCSV:
+		terms, err = parseGlossaryCSV(document)
+	default:
+		terms, err = parseGlossaryYAML(document)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(terms) == 0 {
+		return nil, errors.New("the document has no terms")
+	}
+
+	keys := make(map[string]string, len(terms))
+	for _, term := range terms {
+		if other, ok := keys[term.Key]; ok {
+			return nil, fmt.Errorf("terms %q and %q both use the key %q", other, term.Path, term.Key)
+		}
+		keys[term.Key] = term.Path
+	}
+	return terms, nil
+}
+
+func parseGlossaryYAML(document string) ([]glossaryDocTerm, error) {
+	decoder := yaml.NewDecoder(strings.NewReader(document))
+	decoder.KnownFields(true)
+
+	var roots []glossaryYAMLTerm
+	if err := decoder.Decode(&roots); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	var terms []glossaryDocTerm
+	paths := map[string]bool{}
+	var walk func(nodes []glossaryYAMLTerm, parent *glossaryDocTerm) error
+	walk = func(nodes []glossaryYAMLTerm, parent *glossaryDocTerm) error {
+		for _, node := range nodes {
+			term := glossaryDocTerm{
+				Name:        strings.TrimSpace(node.Name),
+				Key:         strings.TrimSpace(node.Key),
+				Definition:  node.Definition,
+				Description: node.Description,
+				Metadata:    node.Metadata,
+			}
+			if err := checkGlossaryName(term.Name); err != nil {
+				return err
+			}
+			term.Path = term.Name
+			if parent != nil {
+				term.Path = parent.Path + glossaryPathSeparator + term.Name
+				term.ParentKey = parent.Key
+			}
+			if paths[term.Path] {
+				return fmt.Errorf("the term %q is defined twice", term.Path)
+			}
+			paths[term.Path] = true
+			if term.Key == "" {
+				term.Key = term.Path
+			}
+			if strings.TrimSpace(term.Definition) == "" {
+				return fmt.Errorf("the term %q has no definition", term.Path)
+			}
+
+			terms = append(terms, term)
+			if err := walk(node.Children, &term); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(roots, nil); err != nil {
+		return nil, err
+	}
+	return terms, nil
+}
+
+func parseGlossaryCSV(document string) ([]glossaryDocTerm, error) {
+	reader := csv.NewReader(strings.NewReader(document))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "path", name == "key", name == "definition", name == "description",
+			strings.HasPrefix(name, glossaryCSVMetadataPrefix) && len(name) > len(glossaryCSVMetadataPrefix):
+		default:
+			return nil, fmt.Errorf("unknown column %q; use path, key, definition, description, or metadata.<key>", name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("the column %q appears twice", name)
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"path", "definition"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("the header has no %q column", required)
+		}
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok {
+			return record[i]
+		}
+		return ""
+	}
+
+	byPath := map[string]*glossaryDocTerm{}
+	var terms []*glossaryDocTerm
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		names := strings.Split(strings.TrimSpace(field(record, "path")), glossaryPathSeparator)
+		for i := range names {
+			names[i] = strings.TrimSpace(names[i])
+			if err := checkGlossaryName(names[i]); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+
+		term := &glossaryDocTerm{
+			Path:        strings.Join(names, glossaryPathSeparator),
+			Name:        names[len(names)-1],
+			Key:         strings.TrimSpace(field(record, "key")),
+			Definition:  field(record, "definition"),
+			Description: field(record, "description"),
+		}
+		if _, ok := byPath[term.Path]; ok {
+			return nil, fmt.Errorf("line %d: the term %q is defined twice", line, term.Path)
+		}
+		if term.Key == "" {
+			term.Key = term.Path
+		}
+		if strings.TrimSpace(term.Definition) == "" {
+			return nil, fmt.Errorf("line %d: the term %q has no definition", line, term.Path)
+		}
+		for name, i := range columns {
+			if key, ok := strings.CutPrefix(name, glossaryCSVMetadataPrefix); ok && record[i] != "" {
+				if term.Metadata == nil {
+					term.Metadata = map[string]string{}
+				}
+				term.Metadata[key] = record[i]
+			}
+		}
+
+		byPath[term.Path] = term
+		terms = append(terms, term)
+	}
+
+	// Rows may come in any order; parents are resolved once every row is
+	// read, and the result is sorted so parents come first.
+	for _, term := range terms {
+		parentPath, _, ok := cutLast(term.Path, glossaryPathSeparator)
+		if !ok {
+			continue
+		}
+		parent, ok := byPath[parentPath]
+		if !ok {
+			return nil, fmt.Errorf("the parent %q of %q is not in the document", parentPath, term.Path)
+		}
+		term.ParentKey = parent.Key
+	}
+	sort.SliceStable(terms, func(i, j int) bool {
+		return strings.Count(terms[i].Path, glossaryPathSeparator) < strings.Count(terms[j].Path, glossaryPathSeparator)
+	})
+
+	out := make([]glossaryDocTerm, len(terms))
+	for i, term := range terms {
+		out[i] = *term
+	}
+	return out, nil
+}
+
+// checkGlossaryName rejects names that can't be written as part of a term
+// path.
+func checkGlossaryName(name string) error {
+	switch {
+	case name == "":
+		return errors.New("a term has no name")
+	case strings.Contains(name, glossaryPathSeparator):
+		return fmt.Errorf("the term name %q contains %q, which separates names in a path", name, glossaryPathSeparator)
+	}
+	return nil
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// glossaryTermContent is what marmot_glossary compares between the document
+// and the server, both for its checksum and to decide which terms to update.
+type glossaryTermContent struct {
+	Key         string            `json:"key"`
+	ParentKey   string            `json:"parent_key"`
+	Name        string            `json:"name"`
+	Definition  string            `json:"definition"`
+	Description string            `json:"description"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+func (t glossaryDocTerm) content() glossaryTermContent {
+	metadata := t.Metadata
+	if len(metadata) == 0 {
+		metadata = nil
+	}
+	return glossaryTermContent{
+		Key:         t.Key,
+		ParentKey:   t.ParentKey,
+		Name:        t.Name,
+		Definition:  normalizeMarkdown(t.Definition),
+		Description: normalizeMarkdown(t.Description),
+		Metadata:    metadata,
+	}
+}
+
+// equal reports whether two contents match. Metadata maps are compared by
+// value.
+func (c glossaryTermContent) equal(o glossaryTermContent) bool {
+	a, _ := json.Marshal(c)
+	b, _ := json.Marshal(o)
+	return bytes.Equal(a, b)
+}
+
+// glossaryChecksum hashes the contents, sorted by key.
+func glossaryChecksum(contents []glossaryTermContent) string {
+	sorted := append([]glossaryTermContent(nil), contents...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+
+	encoded, _ := json.Marshal(sorted)
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
@@ -6,6 +6,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -18,6 +20,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -31,20 +34,24 @@ func NewGlossaryResource() resource.Resource {
 // GlossaryResource defines the resource implementation.
 type GlossaryResource struct {
 	client *marmot.Client
+
+	// failOnUnknownEnums fails on owner types the provider doesn't know.
+	failOnUnknownEnums bool
 }
 
 // GlossaryResourceModel describes the glossary resource data model.
 type GlossaryResourceModel struct {
-	Name         types.String `tfsdk:"name"`
-	Definition   types.String `tfsdk:"definition"`
-	Description  types.String `tfsdk:"description"`
-	ParentTermID types.String `tfsdk:"parent_term_id"`
-	OwnerTeamIDs types.Set    `tfsdk:"owner_team_ids"`
-	OwnerUserIDs types.Set    `tfsdk:"owner_user_ids"`
-	Metadata     types.Map    `tfsdk:"metadata"`
-	ID           types.String `tfsdk:"id"`
-	CreatedAt    types.String `tfsdk:"created_at"`
-	UpdatedAt    types.String `tfsdk:"updated_at"`
+	Name           types.String  `tfsdk:"name"`
+	Definition     markdownValue `tfsdk:"definition"`
+	Description    markdownValue `tfsdk:"description"`
+	ParentTermID   types.String  `tfsdk:"parent_term_id"`
+	OwnerTeamIDs   types.Set     `tfsdk:"owner_team_ids"`
+	OwnerUserIDs   types.Set     `tfsdk:"owner_user_ids"`
+	Metadata       types.Map     `tfsdk:"metadata"`
+	DeleteChildren types.Bool    `tfsdk:"delete_children"`
+	ID             types.String  `tfsdk:"id"`
+	CreatedAt      types.String  `tfsdk:"created_at"`
+	UpdatedAt      types.String  `tfsdk:"updated_at"`
 }
 
 func (r *GlossaryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -64,15 +71,20 @@ func (r *GlossaryResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 			},
 			"definition": schema.StringAttribute{
-				MarkdownDescription: "Definition of the glossary term",
-				Required:            true,
+				MarkdownDescription: "Definition of the glossary term, in Markdown. Differences in " +
+					"line endings and surrounding whitespace, such as a heredoc's trailing newline, " +
+					"are ignored.",
+				CustomType: markdownType{},
+				Required:   true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
 			"description": schema.StringAttribute{
-				MarkdownDescription: "Additional description for the glossary term",
-				Optional:            true,
+				MarkdownDescription: "Additional description for the glossary term, in Markdown. " +
+					"Whitespace is compared as for `definition`.",
+				CustomType: markdownType{},
+				Optional:   true,
 			},
 			"parent_term_id": schema.StringAttribute{
 				MarkdownDescription: "ID of the parent glossary term for hierarchical organization",
@@ -96,6 +108,12 @@ func (r *GlossaryResource) Schema(ctx context.Context, req resource.SchemaReques
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
+			"delete_children": schema.BoolAttribute{
+				MarkdownDescription: "Delete the term's child terms, and their children, along with it. " +
+					"Defaults to `false`, where deleting a term that still has children fails and " +
+					"lists them. Must be applied before the destroy for it to take effect.",
+				Optional: true,
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Glossary term ID",
 				Computed:            true,
@@ -123,19 +141,22 @@ func (r *GlossaryResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*marmot.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *marmot.Client, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.failOnUnknownEnums = data.failOnUnknownEnums
 }
 
 func (r *GlossaryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data GlossaryResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -145,7 +166,7 @@ func (r *GlossaryResource) Create(ctx context.Context, req resource.CreateReques
 
 	term, err := r.client.Glossary.Create(ctx, r.toCreateRequest(ctx, data, &resp.Diagnostics))
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create glossary term: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to create glossary term", err)
 		return
 	}
 
@@ -155,7 +176,7 @@ func (r *GlossaryResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	applyGlossaryComputedFields(&data, term)
-	setGlossaryOwnerSets(ctx, &data, term, &resp.Diagnostics)
+	setGlossaryOwnerSets(ctx, &data, term, r.failOnUnknownEnums, &resp.Diagnostics)
 
 	tflog.Info(ctx, "Glossary term created", map[string]interface{}{
 		"id":   data.ID.ValueString(),
@@ -166,6 +187,8 @@ func (r *GlossaryResource) Create(ctx context.Context, req resource.CreateReques
 }
 
 func (r *GlossaryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data GlossaryResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -180,7 +203,7 @@ func (r *GlossaryResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	term, err := r.client.Glossary.Get(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read glossary term: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to read glossary term", err)
 		return
 	}
 
@@ -194,6 +217,8 @@ func (r *GlossaryResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *GlossaryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data GlossaryResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -207,14 +232,38 @@ func (r *GlossaryResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	term, err := r.client.Glossary.Update(ctx, state.ID.ValueString(), r.toUpdateRequest(ctx, data, &resp.Diagnostics))
+	input := r.toUpdateRequest(ctx, data, &resp.Diagnostics)
+
+	// A configured owner list replaces the current one, so owners of types
+	// the provider doesn't map are sent back as they are.
+	if input.Owners != nil {
+		current, err := r.client.Glossary.Get(ctx, state.ID.ValueString())
+		if err != nil {
+			addClientError(ctx, &resp.Diagnostics, "Unable to read glossary term owners", err)
+			return
+		}
+		for _, owner := range current.Owners {
+			if owner == nil || knownOwnerType(owner.Type) {
+				continue
+			}
+			if r.failOnUnknownEnums {
+				addUnknownOwnerType(&resp.Diagnostics, true, "glossary term "+current.ID, owner.ID, owner.Type)
+			}
+			input.Owners = append(input.Owners, marmot.TermOwner{ID: owner.ID, Type: owner.Type})
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	term, err := r.client.Glossary.Update(ctx, state.ID.ValueString(), input)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update glossary term: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to update glossary term", err)
 		return
 	}
 
 	applyGlossaryComputedFields(&data, term)
-	setGlossaryOwnerSets(ctx, &data, term, &resp.Diagnostics)
+	setGlossaryOwnerSets(ctx, &data, term, r.failOnUnknownEnums, &resp.Diagnostics)
 
 	tflog.Info(ctx, "Glossary term updated", map[string]interface{}{
 		"id":   data.ID.ValueString(),
@@ -225,6 +274,8 @@ func (r *GlossaryResource) Update(ctx context.Context, req resource.UpdateReques
 }
 
 func (r *GlossaryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data GlossaryResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -232,8 +283,45 @@ func (r *GlossaryResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	terms, err := r.listTerms(ctx)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to list glossary terms", err)
+		return
+	}
+	descendants := glossaryDescendants(terms, data.ID.ValueString())
+
+	if len(descendants) > 0 && !data.DeleteChildren.ValueBool() {
+		names := make([]string, 0, len(descendants))
+		for _, term := range descendants {
+			if term.ParentTermID == data.ID.ValueString() {
+				names = append(names, fmt.Sprintf("%s (%s)", term.Name, term.ID))
+			}
+		}
+		sort.Strings(names)
+		resp.Diagnostics.AddError(
+			"Glossary Term Has Children",
+			fmt.Sprintf("Glossary term %q has %d child terms:\n\n  - %s\n\n"+
+				"Delete or move them first, or set delete_children = true and apply before destroying.",
+				data.Name.ValueString(), len(names), strings.Join(names, "\n  - ")),
+		)
+		return
+	}
+
+	// Descendants come deepest first, so no term is deleted before its
+	// children.
+	for _, term := range descendants {
+		if err := r.client.Glossary.Delete(ctx, term.ID); err != nil && !marmot.IsNotFound(err) {
+			addClientError(ctx, &resp.Diagnostics, "Unable to delete child glossary term", err)
+			return
+		}
+		tflog.Info(ctx, "Child glossary term deleted", map[string]interface{}{
+			"id":   term.ID,
+			"name": term.Name,
+		})
+	}
+
 	if err := r.client.Glossary.Delete(ctx, data.ID.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete glossary term: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to delete glossary term", err)
 		return
 	}
 
@@ -242,8 +330,85 @@ func (r *GlossaryResource) Delete(ctx context.Context, req resource.DeleteReques
 	})
 }
 
+// ImportState accepts a term ID, or a path of term names from the root of
+// the hierarchy such as "Finance/Revenue/ARR", since IDs aren't shown in the
+// UI.
 func (r *GlossaryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if !strings.Contains(req.ID, glossaryPathSeparator) {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	ctx = withAPIErrorCapture(ctx)
+
+	terms, err := r.listTerms(ctx)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to list glossary terms", err)
+		return
+	}
+
+	id, err := resolveGlossaryPath(terms, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cannot Import Glossary Term",
+			fmt.Sprintf("Unable to resolve %q: %s. Import by term ID instead if a name contains %q.", req.ID, err, glossaryPathSeparator),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// glossaryPathSeparator separates term names in an import path.
+const glossaryPathSeparator = "/"
+
+// glossaryPageSize is the page size used when listing every glossary term.
+const glossaryPageSize = 100
+
+// listTerms returns every glossary term, following pagination.
+func (r *GlossaryResource) listTerms(ctx context.Context) ([]*marmot.GlossaryTerm, error) {
+	return listGlossaryTerms(ctx, r.client)
+}
+
+// glossaryDescendants returns every term below the given one, deepest
+// first.
+func glossaryDescendants(terms []*marmot.GlossaryTerm, id string) []*marmot.GlossaryTerm {
+	var out []*marmot.GlossaryTerm
+	for _, term := range terms {
+		if term == nil || term.ParentTermID != id || term.DeletedAt != "" || term.ID == id {
+			continue
+		}
+		out = append(out, glossaryDescendants(terms, term.ID)...)
+		out = append(out, term)
+	}
+	return out
+}
+
+// resolveGlossaryPath walks a path of term names down from the root terms
+// and returns the ID of the last one.
+func resolveGlossaryPath(terms []*marmot.GlossaryTerm, termPath string) (string, error) {
+	parentID := ""
+	for _, name := range strings.Split(termPath, glossaryPathSeparator) {
+		var matches []*marmot.GlossaryTerm
+		for _, term := range terms {
+			if term != nil && term.Name == name && term.ParentTermID == parentID && term.DeletedAt == "" {
+				matches = append(matches, term)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			if parentID == "" {
+				return "", fmt.Errorf("no root term is named %q", name)
+			}
+			return "", fmt.Errorf("no term named %q under %s", name, parentID)
+		case 1:
+			parentID = matches[0].ID
+		default:
+			return "", fmt.Errorf("%d sibling terms are named %q", len(matches), name)
+		}
+	}
+	return parentID, nil
 }
 
 func (r *GlossaryResource) toCreateRequest(ctx context.Context, data GlossaryResourceModel, diags *diag.Diagnostics) marmot.CreateTermInput {
@@ -283,25 +448,31 @@ func (r *GlossaryResource) toUpdateRequest(ctx context.Context, data GlossaryRes
 func glossaryOwners(ctx context.Context, teamIDs, userIDs types.Set, diags *diag.Diagnostics) []marmot.TermOwner {
 	var out []marmot.TermOwner
 	for _, id := range setStrings(ctx, teamIDs, diags) {
-		out = append(out, marmot.TermOwner{ID: id, Type: "team"})
+		out = append(out, marmot.TermOwner{ID: id, Type: ownerTypeTeam})
 	}
 	for _, id := range setStrings(ctx, userIDs, diags) {
-		out = append(out, marmot.TermOwner{ID: id, Type: "user"})
+		out = append(out, marmot.TermOwner{ID: id, Type: ownerTypeUser})
 	}
 	return out
 }
 
 // setGlossaryOwnerSets populates the team and user owner sets from an API
 // response. Owners are server-controlled (an unset owner list defaults to the
-// calling user), so both sets are always taken from the response.
-func setGlossaryOwnerSets(ctx context.Context, model *GlossaryResourceModel, term *marmot.GlossaryTerm, diags *diag.Diagnostics) {
+// calling user), so both sets are always taken from the response. Owners of
+// other types are reported, failing when strict is set.
+func setGlossaryOwnerSets(ctx context.Context, model *GlossaryResourceModel, term *marmot.GlossaryTerm, strict bool, diags *diag.Diagnostics) {
 	var teamIDs, userIDs []string
 	for _, owner := range term.Owners {
+		if owner == nil {
+			continue
+		}
 		switch owner.Type {
-		case "team":
+		case ownerTypeTeam:
 			teamIDs = append(teamIDs, owner.ID)
-		case "user":
+		case ownerTypeUser:
 			userIDs = append(userIDs, owner.ID)
+		default:
+			addUnknownOwnerType(diags, strict, "glossary term "+term.ID, owner.ID, owner.Type)
 		}
 	}
 	model.OwnerTeamIDs = stringsToSet(ctx, teamIDs, diags)
@@ -341,14 +512,14 @@ func (r *GlossaryResource) updateModelFromResponse(ctx context.Context, model *G
 
 	model.ID = types.StringValue(term.ID)
 	model.Name = types.StringValue(term.Name)
-	model.Definition = types.StringValue(term.Definition)
+	model.Definition = newMarkdownValue(term.Definition)
 	model.CreatedAt = types.StringValue(term.CreatedAt)
 	model.UpdatedAt = types.StringValue(term.UpdatedAt)
 
 	if term.Description != "" {
-		model.Description = types.StringValue(term.Description)
+		model.Description = newMarkdownValue(term.Description)
 	} else {
-		model.Description = types.StringNull()
+		model.Description = newMarkdownNull()
 	}
 
 	if term.ParentTermID != "" {
@@ -357,17 +528,9 @@ func (r *GlossaryResource) updateModelFromResponse(ctx context.Context, model *G
 		model.ParentTermID = types.StringNull()
 	}
 
-	setGlossaryOwnerSets(ctx, model, term, &diags)
+	setGlossaryOwnerSets(ctx, model, term, r.failOnUnknownEnums, &diags)
 
-	if metaMap, ok := term.Metadata.(map[string]interface{}); ok && metaMap != nil && len(metaMap) > 0 {
-		strMap := make(map[string]string)
-		for k, v := range metaMap {
-			if strVal, ok := v.(string); ok {
-				strMap[k] = strVal
-			} else {
-				strMap[k] = fmt.Sprintf("%v", v)
-			}
-		}
+	if strMap := convert.StringMap(term.Metadata); strMap != nil {
 		metadata, diag := types.MapValueFrom(ctx, types.StringType, strMap)
 		diags.Append(diag...)
 		model.Metadata = metadata
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build acceptance
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/acctest"
+)
+
+func TestAccGlossaryTermResource(t *testing.T) {
+	parent := acctest.RandomName("term")
+	child := acctest.RandomName("term")
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed(t, "marmot_glossary_term", func(ctx context.Context, client *marmot.Client, id string) error {
+			_, err := client.Glossary.Get(ctx, id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlossaryTermConfig(parent, child, "Annual recurring revenue."),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("marmot_glossary_term.child", "name", child),
+					resource.TestCheckResourceAttr("marmot_glossary_term.child", "definition", "Annual recurring revenue."),
+					resource.TestCheckResourceAttr("marmot_glossary_term.child", "metadata.owner", "finance-analytics"),
+					resource.TestCheckResourceAttrPair("marmot_glossary_term.child", "parent_term_id", "marmot_glossary_term.parent", "id"),
+					testAccStoreID("marmot_glossary_term.child", &id),
+				),
+			},
+			{
+				Config: testAccGlossaryTermConfig(parent, child, "Annual recurring revenue from active subscriptions."),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("marmot_glossary_term.child", "definition", "Annual recurring revenue from active subscriptions."),
+					resource.TestCheckResourceAttrPtr("marmot_glossary_term.child", "id", &id),
+				),
+			},
+			{
+				ResourceName:            "marmot_glossary_term.child",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"updated_at", "delete_children"},
+			},
+			{
+				// Terms can also be imported by their path from the root.
+				ResourceName:            "marmot_glossary_term.child",
+				ImportState:             true,
+				ImportStateId:           parent + glossaryPathSeparator + child,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"updated_at", "delete_children"},
+			},
+			testAccDriftStep(t, testAccGlossaryTermConfig(parent, child, "Annual recurring revenue from active subscriptions."), func(ctx context.Context, client *marmot.Client) error {
+				current, err := client.Glossary.Get(ctx, id)
+				if err != nil {
+					return err
+				}
+				_, err = client.Glossary.Update(ctx, id, marmot.UpdateTermInput{
+					Name:         current.Name,
+					Definition:   "Changed outside Terraform.",
+					ParentTermID: current.ParentTermID,
+					Metadata:     map[string]any{"owner": "finance-analytics"},
+				})
+				return err
+			}),
+		},
+	})
+}
+
+func testAccGlossaryTermConfig(parent, child, definition string) string {
+	return testAccProviderConfig + fmt.Sprintf(`
+resource "marmot_glossary_term" "parent" {
+  name       = %q
+  definition = "Terms used in financial reporting."
+}
+
+resource "marmot_glossary_term" "child" {
+  name           = %q
+  definition     = %q
+  parent_term_id = marmot_glossary_term.parent.id
+
+  metadata = {
+    owner = "finance-analytics"
+  }
+}
+`, parent, child, definition)
+}
@@ -0,0 +1,256 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &GlossaryTermDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &GlossaryTermDataSource{}
+
+func NewGlossaryTermDataSource() datasource.DataSource {
+	return &GlossaryTermDataSource{}
+}
+
+// GlossaryTermDataSource defines the data source implementation.
+type GlossaryTermDataSource struct {
+	client *marmot.Client
+}
+
+// GlossaryTermDataSourceModel describes the glossary term data source data
+// model.
+type GlossaryTermDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Path         types.String `tfsdk:"path"`
+	Slug         types.String `tfsdk:"slug"`
+	Name         types.String `tfsdk:"name"`
+	Definition   types.String `tfsdk:"definition"`
+	Description  types.String `tfsdk:"description"`
+	ParentTermID types.String `tfsdk:"parent_term_id"`
+	Metadata     types.Map    `tfsdk:"metadata"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+	UpdatedAt    types.String `tfsdk:"updated_at"`
+}
+
+func (d *GlossaryTermDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_glossary_term"
+}
+
+func (d *GlossaryTermDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a single glossary term by `id`, `path`, or `slug`, so terms " +
+			"mentioned by name in plain text, such as documentation, can be resolved. Exactly one " +
+			"of the three must be set.\n\n" +
+			"Marmot identifies terms only by ID. A term's path is the names of the terms from the " +
+			"root down to it, joined with `/`, as in `Finance/Revenue/ARR`, the same form " +
+			"`marmot_glossary_term` imports and `marmot_glossary` uses. Its slug is the path with " +
+			"each name lowercased and every run of characters other than letters and digits " +
+			"replaced by `-`, as in `finance/revenue/arr`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Glossary term ID",
+				Optional:            true,
+				Computed:            true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Names of the terms from the root down to this one, joined with `/`",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"slug": schema.StringAttribute{
+				MarkdownDescription: "`path` lowercased, with runs of characters other than letters, " +
+					"digits, and `/` replaced by `-`",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the glossary term",
+				Computed:            true,
+			},
+			"definition": schema.StringAttribute{
+				MarkdownDescription: "Definition of the glossary term, in Markdown",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Additional description for the glossary term, in Markdown",
+				Computed:            true,
+			},
+			"parent_term_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the parent glossary term, or null for a root term",
+				Computed:            true,
+			},
+			"metadata": schema.MapAttribute{
+				MarkdownDescription: "Metadata associated with the glossary term",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Creation timestamp",
+				Computed:            true,
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "Last update timestamp",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *GlossaryTermDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("path"),
+			path.MatchRoot("slug"),
+		),
+	}
+}
+
+func (d *GlossaryTermDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *GlossaryTermDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data GlossaryTermDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The whole glossary is read even for an ID, since the path and slug
+	// are built from the term's ancestors.
+	terms, err := listGlossaryTerms(ctx, d.client)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to list glossary terms", err)
+		return
+	}
+	byID := liveGlossaryTerms(terms)
+
+	var term *marmot.GlossaryTerm
+	switch {
+	case !data.ID.IsNull():
+		term = byID[data.ID.ValueString()]
+		if term == nil {
+			err = fmt.Errorf("no term has the ID %q", data.ID.ValueString())
+		}
+	case !data.Path.IsNull():
+		var id string
+		id, err = resolveGlossaryPath(terms, data.Path.ValueString())
+		term = byID[id]
+	default:
+		term, err = resolveGlossarySlug(byID, data.Slug.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Glossary Term Not Found", fmt.Sprintf("No Marmot glossary term matches: %s.", err))
+		return
+	}
+
+	metadata, diags := types.MapValueFrom(ctx, types.StringType, convert.StringMap(term.Metadata))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	termPath := glossaryTermPathOf(byID, term)
+	data.ID = types.StringValue(term.ID)
+	data.Path = types.StringValue(termPath)
+	data.Slug = types.StringValue(glossarySlug(termPath))
+	data.Name = types.StringValue(term.Name)
+	data.Definition = types.StringValue(term.Definition)
+	data.Description = types.StringValue(term.Description)
+	data.ParentTermID = types.StringNull()
+	if term.ParentTermID != "" {
+		data.ParentTermID = types.StringValue(term.ParentTermID)
+	}
+	data.Metadata = metadata
+	data.CreatedAt = types.StringValue(normalizeTimestamp(term.CreatedAt))
+	data.UpdatedAt = types.StringValue(normalizeTimestamp(term.UpdatedAt))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// glossaryTermPathOf returns the names of the terms from the root down to
+// term, joined with glossaryPathSeparator.
+func glossaryTermPathOf(byID map[string]*marmot.GlossaryTerm, term *marmot.GlossaryTerm) string {
+	names := []string{term.Name}
+	seen := map[string]bool{term.ID: true}
+	for parent := byID[term.ParentTermID]; parent != nil && !seen[parent.ID]; parent = byID[parent.ParentTermID] {
+		seen[parent.ID] = true
+		names = append([]string{parent.Name}, names...)
+	}
+	return strings.Join(names, glossaryPathSeparator)
+}
+
+// glossarySlug lowercases each name in a term path and replaces every run of
+// characters other than letters and digits with a hyphen.
+func glossarySlug(termPath string) string {
+	parts := strings.Split(termPath, glossaryPathSeparator)
+	for i, part := range parts {
+		parts[i] = strings.Join(strings.FieldsFunc(strings.ToLower(part), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}), "-")
+	}
+	return strings.Join(parts, glossaryPathSeparator)
+}
+
+// resolveGlossarySlug returns the term whose slug is slug. Slugs are
+// compared after being normalized themselves, so "Finance/Revenue" also
+// matches.
+func resolveGlossarySlug(byID map[string]*marmot.GlossaryTerm, slug string) (*marmot.GlossaryTerm, error) {
+	want := glossarySlug(strings.Trim(slug, glossaryPathSeparator))
+
+	var matches []*marmot.GlossaryTerm
+	for _, term := range byID {
+		if glossarySlug(glossaryTermPathOf(byID, term)) == want {
+			matches = append(matches, term)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no term has the slug %q", want)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d terms have the slug %q; look the term up by path or ID instead", len(matches), want)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// healthCheckTimeout bounds the start-up request made by checkServerHealth.
+const healthCheckTimeout = 30 * time.Second
+
+// checkServerHealth makes one authenticated request so a wrong host or a
+// rejected credential fails at Configure with a clear error, instead of as a
+// client error on whichever resource happens to be read first. Marmot has no
+// version endpoint, so only reachability and authentication are checked.
+// Other API errors are reported as a warning and don't stop the run. The
+// request isn't retried, so an unreachable host is reported straight away.
+func checkServerHealth(ctx context.Context, client *marmot.Client) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ctx, cancel := context.WithTimeout(withoutRetries(withAPIErrorCapture(ctx)), healthCheckTimeout)
+	defer cancel()
+
+	_, err := client.Users.Me(ctx)
+	if err == nil {
+		return diags
+	}
+
+	var authErr *marmot.AuthError
+	_, answered := apiStatusCode(err)
+	switch {
+	case errors.As(err, &authErr):
+		diags.AddError(
+			"Invalid Marmot Credentials",
+			clientErrorDetail(ctx, "Marmot at "+client.Host()+" rejected the configured credentials (from "+
+				client.Credential().Source()+")", err)+"\n\n"+
+				"Check the api_key or token attribute, or the MARMOT_API_KEY and MARMOT_TOKEN "+
+				"environment variables. Set skip_health_check to bypass this check.",
+		)
+	case answered:
+		diags.AddWarning(
+			"Marmot Health Check Failed",
+			clientErrorDetail(ctx, "The start-up check against "+client.Host()+" returned an error", err),
+		)
+	default:
+		diags.AddError(
+			"Unable to Reach Marmot",
+			clientErrorDetail(ctx, "Could not connect to Marmot at "+client.Host(), err)+"\n\n"+
+				"Check the host attribute or the MARMOT_HOST environment variable. "+
+				"Set skip_health_check to bypass this check.",
+		)
+	}
+
+	return diags
+}
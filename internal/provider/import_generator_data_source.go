@@ -0,0 +1,319 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ImportGeneratorDataSource{}
+
+func NewImportGeneratorDataSource() datasource.DataSource {
+	return &ImportGeneratorDataSource{}
+}
+
+// ImportGeneratorDataSource defines the data source implementation.
+type ImportGeneratorDataSource struct {
+	client *marmot.Client
+}
+
+// ImportGeneratorDataSourceModel describes the import generator data source
+// data model.
+type ImportGeneratorDataSourceModel struct {
+	Query    types.String `tfsdk:"query"`
+	Types    types.Set    `tfsdk:"types"`
+	Services types.Set    `tfsdk:"services"`
+	Tags     types.Set    `tfsdk:"tags"`
+	Limit    types.Int64  `tfsdk:"limit"`
+
+	Total        types.Int64                 `tfsdk:"total"`
+	Assets       []ImportGeneratorAssetModel `tfsdk:"assets"`
+	ImportBlocks types.String                `tfsdk:"import_blocks"`
+	Config       types.String                `tfsdk:"config"`
+}
+
+// ImportGeneratorAssetModel describes one asset an import block was
+// generated for.
+type ImportGeneratorAssetModel struct {
+	ID           types.String `tfsdk:"id"`
+	MRN          types.String `tfsdk:"mrn"`
+	ResourceName types.String `tfsdk:"resource_name"`
+}
+
+func (d *ImportGeneratorDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_import_generator"
+}
+
+func (d *ImportGeneratorDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates `import` blocks and skeleton `marmot_asset` configuration for " +
+			"the assets matching a filter, to bring an existing catalog under Terraform. Write " +
+			"`import_blocks` and `config` to `.tf` files, for example with `terraform output -raw`, " +
+			"then run `terraform plan` to review the imports and fill in the remaining attributes.\n\n" +
+			"Import blocks need Terraform 1.5 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				MarkdownDescription: "Full-text search query selecting the assets. Leave unset to " +
+					"select every asset that passes the filters.",
+				Optional: true,
+			},
+			"types": schema.SetAttribute{
+				MarkdownDescription: "Only select assets of these types",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"services": schema.SetAttribute{
+				MarkdownDescription: "Only select assets from these services",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Only select assets carrying these tags",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of assets to generate blocks for. "+
+					"Defaults to %d, at most %d.", searchDefaultLimit, searchMaxLimit),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, searchMaxLimit),
+				},
+			},
+			"total": schema.Int64Attribute{
+				MarkdownDescription: "Total number of matching assets, which may exceed `limit`",
+				Computed:            true,
+			},
+			"assets": schema.ListNestedAttribute{
+				MarkdownDescription: "Assets blocks were generated for, ordered by MRN",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Asset ID, used as the import ID",
+							Computed:            true,
+						},
+						"mrn": schema.StringAttribute{
+							MarkdownDescription: "Marmot Resource Name",
+							Computed:            true,
+						},
+						"resource_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the generated `marmot_asset` resource, " +
+								"derived from the asset's type and name",
+							Computed: true,
+						},
+					},
+				},
+			},
+			"import_blocks": schema.StringAttribute{
+				MarkdownDescription: "An `import` block for each asset",
+				Computed:            true,
+			},
+			"config": schema.StringAttribute{
+				MarkdownDescription: "A `marmot_asset` resource for each asset, with its name, type, " +
+					"services, description, and tags filled in",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *ImportGeneratorDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *ImportGeneratorDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data ImportGeneratorDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := int64(searchDefaultLimit)
+	if !data.Limit.IsNull() {
+		limit = data.Limit.ValueInt64()
+	}
+
+	opts := marmot.AssetSearchOptions{
+		Query:     data.Query.ValueString(),
+		Types:     setStrings(ctx, data.Types, &resp.Diagnostics),
+		Providers: setStrings(ctx, data.Services, &resp.Diagnostics),
+		Tags:      setStrings(ctx, data.Tags, &resp.Diagnostics),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var assets []*marmot.Asset
+	var total int64
+	for int64(len(assets)) < limit {
+		opts.Limit = min(limit-int64(len(assets)), searchDefaultLimit)
+		opts.Offset = int64(len(assets))
+
+		page, err := d.client.Assets.Search(ctx, opts)
+		if err != nil {
+			addClientError(ctx, &resp.Diagnostics, "Unable to search assets", err)
+			return
+		}
+		total = page.Total
+		for _, asset := range page.Assets {
+			if asset != nil {
+				assets = append(assets, asset)
+			}
+		}
+		if len(page.Assets) == 0 || int64(len(assets)) >= total {
+			break
+		}
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i].Mrn < assets[j].Mrn })
+
+	var imports, config strings.Builder
+	used := map[string]bool{}
+	data.Assets = make([]ImportGeneratorAssetModel, 0, len(assets))
+	for i, asset := range assets {
+		name := uniqueResourceName(asset, used)
+		if i > 0 {
+			imports.WriteString("\n")
+			config.WriteString("\n")
+		}
+		writeImportBlock(&imports, name, asset)
+		writeAssetSkeleton(&config, name, asset)
+
+		data.Assets = append(data.Assets, ImportGeneratorAssetModel{
+			ID:           types.StringValue(asset.ID),
+			MRN:          types.StringValue(asset.Mrn),
+			ResourceName: types.StringValue(name),
+		})
+	}
+
+	data.Total = types.Int64Value(total)
+	data.ImportBlocks = types.StringValue(imports.String())
+	data.Config = types.StringValue(config.String())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+var resourceNameInvalid = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// uniqueResourceName derives a Terraform resource name from the asset's type
+// and name, such as "topic_orders_created" for the topic orders.created, and
+// adds a numeric suffix when it is already in use.
+func uniqueResourceName(asset *marmot.Asset, used map[string]bool) string {
+	base := strings.ToLower(asset.Type + "_" + asset.Name)
+	base = strings.Trim(resourceNameInvalid.ReplaceAllString(base, "_"), "_-")
+	if base == "" || !unicode.IsLetter(rune(base[0])) {
+		base = "asset_" + base
+	}
+
+	name := base
+	for n := 2; used[name]; n++ {
+		name = fmt.Sprintf("%s_%d", base, n)
+	}
+	used[name] = true
+	return name
+}
+
+func writeImportBlock(b *strings.Builder, name string, asset *marmot.Asset) {
+	fmt.Fprintf(b, "import {\n  to = marmot_asset.%s\n  id = %s\n}\n", name, hclString(asset.ID))
+}
+
+// writeAssetSkeleton writes a marmot_asset resource with the attributes the
+// search results carry, aligned as terraform fmt would.
+func writeAssetSkeleton(b *strings.Builder, name string, asset *marmot.Asset) {
+	services := append([]string{}, asset.Providers...)
+	sort.Strings(services)
+	tags := append([]string{}, asset.Tags...)
+	sort.Strings(tags)
+
+	attrs := [][2]string{
+		{"name", hclString(asset.Name)},
+		{"type", hclString(asset.Type)},
+		{"services", hclStringList(services)},
+	}
+	if asset.Description != "" {
+		attrs = append(attrs, [2]string{"description", hclString(asset.Description)})
+	}
+	if len(tags) > 0 {
+		attrs = append(attrs, [2]string{"tags", hclStringList(tags)})
+	}
+
+	width := 0
+	for _, a := range attrs {
+		width = max(width, len(a[0]))
+	}
+
+	fmt.Fprintf(b, "resource \"marmot_asset\" %q {\n", name)
+	for _, a := range attrs {
+		fmt.Fprintf(b, "  %-*s = %s\n", width, a[0], a[1])
+	}
+	b.WriteString("}\n")
+}
+
+// hclString quotes s as an HCL string literal, escaping template sequences
+// so the value is taken literally.
+func hclString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case unicode.IsControl(r):
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func hclStringList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = hclString(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Job run statuses that end a run. Anything else, such as "pending" or
+// "running", means the run is still in progress.
+const (
+	jobRunStatusSucceeded = "succeeded"
+	jobRunStatusCompleted = "completed"
+	jobRunStatusFailed    = "failed"
+	jobRunStatusCancelled = "cancelled"
+)
+
+// jobRun mirrors the Marmot ingestion job run payload. The SDK's
+// TriggerSchedule discards it, so triggering goes through the raw client.
+type jobRun struct {
+	ID             string `json:"id,omitempty"`
+	ScheduleID     string `json:"schedule_id,omitempty"`
+	PipelineName   string `json:"pipeline_name,omitempty"`
+	Status         string `json:"status,omitempty"`
+	ErrorMessage   string `json:"error_message,omitempty"`
+	StartedAt      string `json:"started_at,omitempty"`
+	FinishedAt     string `json:"finished_at,omitempty"`
+	AssetsCreated  int64  `json:"assets_created,omitempty"`
+	AssetsUpdated  int64  `json:"assets_updated,omitempty"`
+	AssetsDeleted  int64  `json:"assets_deleted,omitempty"`
+	LineageCreated int64  `json:"lineage_created,omitempty"`
+}
+
+// finished reports whether the run has reached a final status.
+func (r *jobRun) finished() bool {
+	switch r.Status {
+	case jobRunStatusSucceeded, jobRunStatusCompleted, jobRunStatusFailed, jobRunStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// failed reports whether the run ended without completing.
+func (r *jobRun) failed() bool {
+	return r.Status == jobRunStatusFailed || r.Status == jobRunStatusCancelled
+}
+
+// pluginConfigValidation mirrors the Marmot ingestion validate response.
+type pluginConfigValidation struct {
+	Valid  bool `json:"valid"`
+	Errors []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// validatePluginConfig checks a pipeline config against its plugin without
+// saving anything. The SDK doesn't wrap the endpoint.
+func (c *apiClient) validatePluginConfig(ctx context.Context, pluginID string, config map[string]any) (*pluginConfigValidation, error) {
+	in := map[string]any{"plugin_id": pluginID, "config": config}
+	var out pluginConfigValidation
+	if err := c.do(ctx, http.MethodPost, "/ingestion/validate", nil, in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *apiClient) triggerSchedule(ctx context.Context, scheduleID string) (*jobRun, error) {
+	var out jobRun
+	if err := c.do(ctx, http.MethodPost, "/ingestion/schedules/"+url.PathEscape(scheduleID)+"/trigger", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *apiClient) getJobRun(ctx context.Context, id string) (*jobRun, error) {
+	var out jobRun
+	if err := c.do(ctx, http.MethodGet, "/ingestion/runs/"+url.PathEscape(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
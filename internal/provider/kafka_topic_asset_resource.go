@@ -0,0 +1,515 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &KafkaTopicAssetResource{}
+var _ resource.ResourceWithImportState = &KafkaTopicAssetResource{}
+
+// The asset type, service, and field names the Kafka ingestion plugin uses,
+// so topics declared here look the same as scanned ones.
+const (
+	kafkaTopicAssetType = "Topic"
+	kafkaService        = "Kafka"
+
+	kafkaMetaCluster           = "cluster"
+	kafkaMetaPartitionCount    = "partition_count"
+	kafkaMetaReplicationFactor = "replication_factor"
+	kafkaMetaKeySchemaType     = "key_schema_type"
+	kafkaMetaValueSchemaType   = "value_schema_type"
+
+	kafkaSchemaKey   = "key"
+	kafkaSchemaValue = "value"
+
+	defaultKafkaSchemaType = "AVRO"
+)
+
+var kafkaSchemaTypes = []string{"AVRO", "JSON", "PROTOBUF"}
+
+func NewKafkaTopicAssetResource() resource.Resource {
+	return &KafkaTopicAssetResource{}
+}
+
+// KafkaTopicAssetResource defines the resource implementation.
+type KafkaTopicAssetResource struct {
+	client          *marmot.Client
+	ignoreLabelCase bool
+	uiBaseURL       string
+}
+
+// KafkaTopicAssetResourceModel describes the Kafka topic asset resource data
+// model.
+type KafkaTopicAssetResourceModel struct {
+	Name              types.String  `tfsdk:"name"`
+	Cluster           types.String  `tfsdk:"cluster"`
+	Partitions        types.Int64   `tfsdk:"partitions"`
+	ReplicationFactor types.Int64   `tfsdk:"replication_factor"`
+	KeySchema         types.String  `tfsdk:"key_schema"`
+	KeySchemaType     types.String  `tfsdk:"key_schema_type"`
+	ValueSchema       types.String  `tfsdk:"value_schema"`
+	ValueSchemaType   types.String  `tfsdk:"value_schema_type"`
+	Description       markdownValue `tfsdk:"description"`
+	Tags              types.Set     `tfsdk:"tags"`
+
+	ID  types.String `tfsdk:"id"`
+	MRN types.String `tfsdk:"mrn"`
+	URL types.String `tfsdk:"url"`
+}
+
+func (r *KafkaTopicAssetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kafka_topic_asset"
+}
+
+func (r *KafkaTopicAssetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	schemaTypeDescription := func(part string) string {
+		return fmt.Sprintf("Format of `%s_schema`: `AVRO`, `JSON`, or `PROTOBUF`, as reported by "+
+			"the schema registry. Defaults to `AVRO`.", part)
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Registers a Kafka topic as a Marmot asset, with the asset type, " +
+			"service, metadata keys, and schema fields the Kafka ingestion plugin uses, so " +
+			"topics declared in Terraform look the same as scanned ones. The asset's MRN is " +
+			"`mrn://topic/kafka/<name>`.\n\n" +
+			"Schemas are stored in canonical form, so formatting changes in the schema files " +
+			"don't show up in plans. Attributes left unset keep whatever value ingestion wrote. " +
+			"A topic that ingestion already registered can be brought under Terraform with " +
+			"`terraform import`.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Topic name, such as `orders.created`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Name of the Kafka cluster the topic lives on, stored as the " +
+					"`cluster` metadata key",
+				Optional: true,
+			},
+			"partitions": schema.Int64Attribute{
+				MarkdownDescription: "Number of partitions, stored as `partition_count`",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"replication_factor": schema.Int64Attribute{
+				MarkdownDescription: "Replication factor, stored as `replication_factor`",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"key_schema": schema.StringAttribute{
+				MarkdownDescription: "Schema of the message keys, such as " +
+					"`file(\"${path.module}/schemas/order-key.avsc\")`",
+				Optional: true,
+			},
+			"key_schema_type": schema.StringAttribute{
+				MarkdownDescription: schemaTypeDescription("key"),
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(kafkaSchemaTypes...),
+				},
+			},
+			"value_schema": schema.StringAttribute{
+				MarkdownDescription: "Schema of the message values",
+				Optional:            true,
+			},
+			"value_schema_type": schema.StringAttribute{
+				MarkdownDescription: schemaTypeDescription("value"),
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(kafkaSchemaTypes...),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Markdown description of the topic",
+				Optional:            true,
+				CustomType:          markdownType{},
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Tags for the topic",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Asset ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mrn": schema.StringAttribute{
+				MarkdownDescription: "Marmot Resource Name",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "Link to the topic in the Marmot UI",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *KafkaTopicAssetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.ignoreLabelCase = data.ignoreLabelCase
+	r.uiBaseURL = data.uiBaseURL
+}
+
+func (r *KafkaTopicAssetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data KafkaTopicAssetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metadata, schemas := kafkaTopicFields(data, &resp.Diagnostics)
+	tags := setStrings(ctx, data.Tags, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, err := r.client.Assets.Create(ctx, marmot.CreateAssetInput{
+		Name:        data.Name.ValueString(),
+		Type:        kafkaTopicAssetType,
+		Providers:   []string{kafkaService},
+		Description: data.Description.ValueString(),
+		Tags:        tags,
+		Metadata:    metadata,
+		Schema:      schemas,
+	})
+	if err != nil {
+		if isConflict(err) {
+			addTypedAssetExistsError(ctx, r.client, &resp.Diagnostics, "Kafka topic", marmot.LookupInput{
+				Type:    kafkaTopicAssetType,
+				Service: kafkaService,
+				Name:    data.Name.ValueString(),
+			})
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to create topic asset", err)
+		return
+	}
+
+	if asset.ID == "" {
+		resp.Diagnostics.AddError("API Error", "Asset created but no ID returned")
+		return
+	}
+
+	data.ID = types.StringValue(asset.ID)
+	data.MRN = types.StringValue(asset.Mrn)
+	data.URL = assetURL(r.uiBaseURL, asset.Mrn)
+
+	tflog.Info(ctx, "Kafka topic asset created", map[string]any{
+		"id":   data.ID.ValueString(),
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KafkaTopicAssetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data KafkaTopicAssetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, err := r.client.Assets.Get(ctx, data.ID.ValueString())
+	if err != nil {
+		if marmot.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to read topic asset", err)
+		return
+	}
+
+	prior := data
+	r.updateModelFromResponse(ctx, &data, asset, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An import starts from an ID alone and reads every attribute. Otherwise,
+	// optional attributes left out of the configuration stay unset, since
+	// updates leave their server values alone.
+	if !prior.Name.IsNull() {
+		keepUnsetTopicAttributes(&data, prior)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KafkaTopicAssetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data, state KafkaTopicAssetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metadata, schemas := kafkaTopicFields(data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API only offers a full replace, so read the asset and carry over
+	// whatever the configuration doesn't set, including fields ingestion
+	// wrote, rather than clearing it.
+	current, err := r.client.Assets.Get(ctx, state.ID.ValueString())
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to read topic asset", err)
+		return
+	}
+
+	input := typedAssetUpdate(ctx, current, data.Description, data.Tags, metadata, schemas, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, err := r.client.Assets.Update(ctx, state.ID.ValueString(), input)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to update topic asset", err)
+		return
+	}
+
+	data.ID = types.StringValue(asset.ID)
+	data.MRN = types.StringValue(asset.Mrn)
+	data.URL = assetURL(r.uiBaseURL, asset.Mrn)
+
+	tflog.Info(ctx, "Kafka topic asset updated", map[string]any{
+		"id":   data.ID.ValueString(),
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KafkaTopicAssetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data KafkaTopicAssetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.Assets.Delete(ctx, data.ID.ValueString()); err != nil {
+		if marmot.IsNotFound(err) {
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to delete topic asset", err)
+		return
+	}
+
+	tflog.Info(ctx, "Kafka topic asset deleted", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+}
+
+// ImportState imports an asset by its ID or its MRN.
+func (r *KafkaTopicAssetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importAssetRef(ctx, r.client, req, resp)
+}
+
+// kafkaTopicFields returns the metadata keys and schema fields the
+// configuration sets, with schemas in canonical form.
+func kafkaTopicFields(data KafkaTopicAssetResourceModel, diags *diag.Diagnostics) (map[string]any, map[string]string) {
+	metadata := map[string]any{}
+	schemas := map[string]string{}
+
+	if !data.Cluster.IsNull() {
+		metadata[kafkaMetaCluster] = data.Cluster.ValueString()
+	}
+	if !data.Partitions.IsNull() {
+		metadata[kafkaMetaPartitionCount] = data.Partitions.ValueInt64()
+	}
+	if !data.ReplicationFactor.IsNull() {
+		metadata[kafkaMetaReplicationFactor] = data.ReplicationFactor.ValueInt64()
+	}
+
+	for _, part := range []struct {
+		attr, schemaKey, typeKey string
+		doc, schemaType          types.String
+	}{
+		{"key_schema", kafkaSchemaKey, kafkaMetaKeySchemaType, data.KeySchema, data.KeySchemaType},
+		{"value_schema", kafkaSchemaValue, kafkaMetaValueSchemaType, data.ValueSchema, data.ValueSchemaType},
+	} {
+		schemaType := kafkaSchemaType(part.schemaType)
+		if !part.schemaType.IsNull() || !part.doc.IsNull() {
+			metadata[part.typeKey] = schemaType
+		}
+		if part.doc.IsNull() {
+			continue
+		}
+		normalized, err := normalizeKafkaSchema(schemaType, part.doc.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root(part.attr),
+				"Invalid Schema",
+				fmt.Sprintf("The %s is not a valid %s schema: %s", part.attr, schemaType, err),
+			)
+			continue
+		}
+		schemas[part.schemaKey] = normalized
+	}
+
+	return metadata, schemas
+}
+
+// kafkaSchemaType returns the configured schema type, or the schema
+// registry's default when unset.
+func kafkaSchemaType(v types.String) string {
+	if v.IsNull() || v.IsUnknown() {
+		return defaultKafkaSchemaType
+	}
+	return v.ValueString()
+}
+
+func normalizeKafkaSchema(schemaType, doc string) (string, error) {
+	switch strings.ToUpper(schemaType) {
+	case "JSON":
+		return normalizeJSONSchema(doc)
+	case "PROTOBUF":
+		return normalizeProtobufSchema(doc)
+	default:
+		return normalizeAvroSchema(doc)
+	}
+}
+
+// updateModelFromResponse copies the asset into model. Schemas that are the
+// same as the prior value once normalized keep their prior spelling.
+func (r *KafkaTopicAssetResource) updateModelFromResponse(ctx context.Context, model *KafkaTopicAssetResourceModel, asset *marmot.Asset, diags *diag.Diagnostics) {
+	model.ID = types.StringValue(asset.ID)
+	model.MRN = types.StringValue(asset.Mrn)
+	model.URL = assetURL(r.uiBaseURL, asset.Mrn)
+	model.Name = types.StringValue(asset.Name)
+
+	model.Description, model.Tags = typedAssetDescriptionAndTags(ctx, asset, model.Tags, r.ignoreLabelCase, diags)
+
+	meta := convert.Object(asset.Metadata)
+	model.Cluster = metadataString(meta, kafkaMetaCluster)
+	model.Partitions = metadataInt64(meta, kafkaMetaPartitionCount)
+	model.ReplicationFactor = metadataInt64(meta, kafkaMetaReplicationFactor)
+	model.KeySchemaType = keepSchemaType(metadataString(meta, kafkaMetaKeySchemaType), model.KeySchemaType)
+	model.ValueSchemaType = keepSchemaType(metadataString(meta, kafkaMetaValueSchemaType), model.ValueSchemaType)
+
+	model.KeySchema = keepEquivalentSchema(asset.Schema[kafkaSchemaKey], model.KeySchema, kafkaSchemaType(model.KeySchemaType))
+	model.ValueSchema = keepEquivalentSchema(asset.Schema[kafkaSchemaValue], model.ValueSchema, kafkaSchemaType(model.ValueSchemaType))
+}
+
+// keepEquivalentSchema returns the prior schema when it normalizes to the
+// same document as the server's, and the server's otherwise.
+func keepEquivalentSchema(server string, prior types.String, schemaType string) types.String {
+	if server == "" {
+		return types.StringNull()
+	}
+	if !prior.IsNull() {
+		a, errA := normalizeKafkaSchema(schemaType, server)
+		b, errB := normalizeKafkaSchema(schemaType, prior.ValueString())
+		if errA == nil && errB == nil && a == b {
+			return prior
+		}
+	}
+	return types.StringValue(server)
+}
+
+// keepSchemaType returns the prior schema type when it names the same format
+// as the server's in another case.
+func keepSchemaType(server, prior types.String) types.String {
+	if !server.IsNull() && !prior.IsNull() && strings.EqualFold(server.ValueString(), prior.ValueString()) {
+		return prior
+	}
+	return server
+}
+
+// keepUnsetTopicAttributes sets the optional attributes that were unset in
+// prior back to unset after a read.
+func keepUnsetTopicAttributes(model *KafkaTopicAssetResourceModel, prior KafkaTopicAssetResourceModel) {
+	if prior.Cluster.IsNull() {
+		model.Cluster = prior.Cluster
+	}
+	if prior.Partitions.IsNull() {
+		model.Partitions = prior.Partitions
+	}
+	if prior.ReplicationFactor.IsNull() {
+		model.ReplicationFactor = prior.ReplicationFactor
+	}
+	if prior.KeySchema.IsNull() {
+		model.KeySchema = prior.KeySchema
+	}
+	if prior.KeySchemaType.IsNull() {
+		model.KeySchemaType = prior.KeySchemaType
+	}
+	if prior.ValueSchema.IsNull() {
+		model.ValueSchema = prior.ValueSchema
+	}
+	if prior.ValueSchemaType.IsNull() {
+		model.ValueSchemaType = prior.ValueSchemaType
+	}
+	if prior.Description.IsNull() {
+		model.Description = prior.Description
+	}
+	if prior.Tags.IsNull() {
+		model.Tags = prior.Tags
+	}
+}
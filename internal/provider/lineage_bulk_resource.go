@@ -0,0 +1,390 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &LineageBulkResource{}
+
+// lineageBatchSize bounds how many edges go into one batch request.
+const lineageBatchSize = 100
+
+func NewLineageBulkResource() resource.Resource {
+	return &LineageBulkResource{}
+}
+
+// LineageBulkResource defines the resource implementation.
+type LineageBulkResource struct {
+	client *marmot.Client
+}
+
+// LineageBulkResourceModel describes the bulk lineage resource data model.
+type LineageBulkResourceModel struct {
+	Edges   []LineageBulkEdgeModel `tfsdk:"edges"`
+	EdgeIDs types.Map              `tfsdk:"edge_ids"`
+}
+
+// LineageBulkEdgeModel is one edge of a bulk lineage resource.
+type LineageBulkEdgeModel struct {
+	Source types.String `tfsdk:"source"`
+	Target types.String `tfsdk:"target"`
+}
+
+func (e LineageBulkEdgeModel) key() string {
+	return lineageEdgeKey(e.Source.ValueString(), e.Target.ValueString())
+}
+
+// lineageEdgeKey is how an edge is identified in edge_ids.
+func lineageEdgeKey(source, target string) string {
+	return source + " -> " + target
+}
+
+// lineageEdgePath is where diagnostics about one edge are reported. It is
+// the edge's entry in edge_ids, which also names edges removed from edges.
+func lineageEdgePath(key string) path.Path {
+	return path.Root("edge_ids").AtMapKey(key)
+}
+
+func (r *LineageBulkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_lineage_bulk"
+}
+
+func (r *LineageBulkResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a whole set of lineage edges, such as every dependency in a " +
+			"pipeline DAG, as one resource. New edges are written with batched API calls and only " +
+			"edges removed from the set are deleted, so large graphs plan and apply without one " +
+			"`marmot_lineage` resource per edge.\n\n" +
+			"An edge that fails to be written or deleted is reported against its `edge_ids` " +
+			"entry while the other edges are still applied and kept in state, so the next apply " +
+			"only retries the failures.\n\n" +
+			"Don't manage the same edge here and in `marmot_lineage`.",
+
+		Attributes: map[string]schema.Attribute{
+			"edges": schema.SetNestedAttribute{
+				MarkdownDescription: "Edges to maintain",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source": schema.StringAttribute{
+							MarkdownDescription: "MRN of the upstream asset",
+							Required:            true,
+							Validators: []validator.String{
+								isMRN(),
+							},
+						},
+						"target": schema.StringAttribute{
+							MarkdownDescription: "MRN of the downstream asset",
+							Required:            true,
+							Validators: []validator.String{
+								isMRN(),
+							},
+						},
+					},
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"edge_ids": schema.MapAttribute{
+				MarkdownDescription: "Lineage edge IDs, keyed by `\"<source> -> <target>\"`",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (r *LineageBulkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *LineageBulkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data LineageBulkResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids := map[string]string{}
+	if !r.writeEdges(ctx, data.Edges, ids, &resp.Diagnostics) {
+		// Keep whatever was written in state, so it is tracked and cleaned up.
+		r.setPartialState(ctx, resp.State.Set, ids, &resp.Diagnostics)
+		return
+	}
+
+	resp.Diagnostics.Append(setEdgeIDs(ctx, &data, ids)...)
+
+	tflog.Info(ctx, "Lineage edges created", map[string]interface{}{
+		"edges": len(ids),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LineageBulkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data LineageBulkResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids := idMap(data.EdgeIDs)
+
+	// Edges deleted outside Terraform drop out of the set, so the next plan
+	// adds them back.
+	var edges []LineageBulkEdgeModel
+	for _, edge := range data.Edges {
+		id, ok := ids[edge.key()]
+		if !ok {
+			continue
+		}
+		if _, err := r.client.Lineage.Edge(ctx, id); err != nil {
+			if marmot.IsNotFound(err) {
+				delete(ids, edge.key())
+				continue
+			}
+			addClientError(ctx, &resp.Diagnostics, "Unable to read lineage edge", err)
+			return
+		}
+		edges = append(edges, edge)
+	}
+
+	if len(edges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Edges = edges
+	resp.Diagnostics.Append(setEdgeIDs(ctx, &data, ids)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LineageBulkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data LineageBulkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state LineageBulkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids := idMap(state.EdgeIDs)
+
+	planned := make(map[string]bool, len(data.Edges))
+	var added []LineageBulkEdgeModel
+	for _, edge := range data.Edges {
+		planned[edge.key()] = true
+		if _, ok := ids[edge.key()]; !ok {
+			added = append(added, edge)
+		}
+	}
+
+	var removed []string
+	for key := range ids {
+		if !planned[key] {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(removed)
+
+	// An edge that fails to delete stays in edge_ids, so the next apply
+	// tries again, and the other edges are still updated.
+	deleted := r.deleteEdges(ctx, ids, removed, &resp.Diagnostics)
+	if !r.writeEdges(ctx, added, ids, &resp.Diagnostics) || !deleted {
+		r.setPartialState(ctx, resp.State.Set, ids, &resp.Diagnostics)
+		return
+	}
+
+	resp.Diagnostics.Append(setEdgeIDs(ctx, &data, ids)...)
+
+	tflog.Info(ctx, "Lineage edges updated", map[string]interface{}{
+		"added":   len(added),
+		"removed": len(removed),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LineageBulkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data LineageBulkResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids := idMap(data.EdgeIDs)
+	keys := make([]string, 0, len(ids))
+	for key := range ids {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if !r.deleteEdges(ctx, ids, keys, &resp.Diagnostics) {
+		// Only the edges that are left stay in state.
+		r.setPartialState(ctx, resp.State.Set, ids, &resp.Diagnostics)
+		return
+	}
+
+	tflog.Info(ctx, "Lineage edges deleted", map[string]interface{}{
+		"edges": len(keys),
+	})
+}
+
+// deleteEdges deletes the edges under the given keys, removing each from ids
+// as it goes. An edge that fails to delete is reported at its path and kept
+// in ids, and the rest are still deleted. It returns false if any failed.
+func (r *LineageBulkResource) deleteEdges(ctx context.Context, ids map[string]string, keys []string, diags *diag.Diagnostics) bool {
+	ok := true
+	for _, key := range keys {
+		if err := r.client.Lineage.Delete(ctx, ids[key]); err != nil && !marmot.IsNotFound(err) {
+			addItemClientError(ctx, diags, lineageEdgePath(key), fmt.Sprintf("Unable to delete lineage edge %s", key), err)
+			ok = false
+			continue
+		}
+		delete(ids, key)
+	}
+	return ok
+}
+
+// writeEdges creates edges in batches of lineageBatchSize, recording each
+// new edge ID in ids as it goes. An edge the server doesn't create is
+// reported at its path and the remaining batches are still written; a batch
+// request that fails outright stops the write. It returns false if any edge
+// wasn't created.
+func (r *LineageBulkResource) writeEdges(ctx context.Context, edges []LineageBulkEdgeModel, ids map[string]string, diags *diag.Diagnostics) bool {
+	ok := true
+	for start := 0; start < len(edges); start += lineageBatchSize {
+		chunk := edges[start:min(start+lineageBatchSize, len(edges))]
+
+		inputs := make([]marmot.WriteEdgeInput, len(chunk))
+		for i, edge := range chunk {
+			inputs[i] = marmot.WriteEdgeInput{
+				Source: edge.Source.ValueString(),
+				Target: edge.Target.ValueString(),
+			}
+		}
+
+		results, err := r.client.Lineage.Batch(ctx, inputs)
+		if err != nil {
+			addClientError(ctx, diags, "Unable to create lineage edges", err)
+			return false
+		}
+
+		statuses := map[string]string{}
+		for _, result := range results {
+			if result == nil || result.Edge == nil {
+				continue
+			}
+			key := lineageEdgeKey(result.Edge.Source, result.Edge.Target)
+			statuses[key] = result.Status
+			if result.Edge.ID != "" {
+				ids[key] = result.Edge.ID
+			}
+		}
+
+		for _, edge := range chunk {
+			if _, written := ids[edge.key()]; written {
+				continue
+			}
+			detail := fmt.Sprintf("Marmot did not create the lineage edge %s and returned no result for it.", edge.key())
+			if status, found := statuses[edge.key()]; found {
+				detail = fmt.Sprintf("Marmot did not create the lineage edge %s and returned the status %q.", edge.key(), status)
+			}
+			diags.AddAttributeError(lineageEdgePath(edge.key()), "Lineage Edge Not Created", detail)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// setPartialState records the edges that exist after a failed create or
+// update, so they are tracked and later removed or reused.
+func (r *LineageBulkResource) setPartialState(ctx context.Context, set func(context.Context, any) diag.Diagnostics, ids map[string]string, diags *diag.Diagnostics) {
+	if len(ids) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(ids))
+	for key := range ids {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var partial LineageBulkResourceModel
+	for _, key := range keys {
+		source, target, _ := strings.Cut(key, " -> ")
+		partial.Edges = append(partial.Edges, LineageBulkEdgeModel{
+			Source: types.StringValue(source),
+			Target: types.StringValue(target),
+		})
+	}
+	diags.Append(setEdgeIDs(ctx, &partial, ids)...)
+	diags.Append(set(ctx, &partial)...)
+}
+
+// idMap returns a computed map of IDs, such as edge_ids, as a Go map that is
+// never nil.
+func idMap(m types.Map) map[string]string {
+	ids := map[string]string{}
+	if m.IsNull() || m.IsUnknown() {
+		return ids
+	}
+	for key, v := range m.Elements() {
+		if s, ok := v.(types.String); ok {
+			ids[key] = s.ValueString()
+		}
+	}
+	return ids
+}
+
+func setEdgeIDs(ctx context.Context, model *LineageBulkResourceModel, ids map[string]string) diag.Diagnostics {
+	m, diags := types.MapValueFrom(ctx, types.StringType, ids)
+	model.EdgeIDs = m
+	return diags
+}
@@ -0,0 +1,276 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &LineagePathDataSource{}
+
+func NewLineagePathDataSource() datasource.DataSource {
+	return &LineagePathDataSource{}
+}
+
+// LineagePathDataSource defines the data source implementation.
+type LineagePathDataSource struct {
+	client *marmot.Client
+	assets *assetLookup
+}
+
+// LineagePathDataSourceModel describes the lineage path data source data
+// model.
+type LineagePathDataSourceModel struct {
+	Source  types.String `tfsdk:"source"`
+	Target  types.String `tfsdk:"target"`
+	MaxHops types.Int64  `tfsdk:"max_hops"`
+	Origins types.Set    `tfsdk:"origins"`
+	Exists  types.Bool   `tfsdk:"exists"`
+	Path    types.List   `tfsdk:"path"`
+	Hops    types.Int64  `tfsdk:"hops"`
+}
+
+func (d *LineagePathDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_lineage_path"
+}
+
+func (d *LineagePathDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Finds the shortest lineage path from one asset to another, following " +
+			"edges downstream. Add a `postcondition` on `exists` to assert in CI that an asset is " +
+			"connected to its approved upstream sources.\n\n" +
+			"The path is searched in the source's downstream lineage and the target's upstream " +
+			"lineage as returned by Marmot, so paths longer than twice the server's traversal " +
+			"depth are not found.",
+
+		Attributes: map[string]schema.Attribute{
+			"source": schema.StringAttribute{
+				MarkdownDescription: "MRN of the upstream asset the path starts from",
+				Required:            true,
+				Validators: []validator.String{
+					isMRN(),
+				},
+			},
+			"target": schema.StringAttribute{
+				MarkdownDescription: "MRN of the downstream asset the path ends at",
+				Required:            true,
+				Validators: []validator.String{
+					isMRN(),
+				},
+			},
+			"max_hops": schema.Int64Attribute{
+				MarkdownDescription: "Only count paths of at most this many edges. Unset means no " +
+					"limit beyond the server's.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"origins": schema.SetAttribute{
+				MarkdownDescription: "Only follow edges whose `origin`, as `marmot_lineage` reports " +
+					"it, is one of these, such as the origin of edges written through the API to " +
+					"check that a path doesn't depend on scanner-derived lineage. Edges without an " +
+					"origin are then skipped. Unset follows every edge.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"exists": schema.BoolAttribute{
+				MarkdownDescription: "Whether a path from `source` to `target` was found",
+				Computed:            true,
+			},
+			"path": schema.ListAttribute{
+				MarkdownDescription: "MRNs along the shortest path, from `source` to `target`. " +
+					"Empty when no path was found.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"hops": schema.Int64Attribute{
+				MarkdownDescription: "Number of edges in `path`, or null when no path was found",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *LineagePathDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+	d.assets = data.assets
+}
+
+func (d *LineagePathDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data LineagePathDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source, target := data.Source.ValueString(), data.Target.ValueString()
+	maxHops := -1
+	if !data.MaxHops.IsNull() {
+		maxHops = int(data.MaxHops.ValueInt64())
+	}
+
+	var origins map[string]bool
+	if !data.Origins.IsNull() {
+		origins = map[string]bool{}
+		for _, origin := range setStrings(ctx, data.Origins, &resp.Diagnostics) {
+			origins[origin] = true
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	sourceAsset, ok := d.lookup(ctx, source, resp)
+	if !ok {
+		return
+	}
+
+	downstream, err := d.client.Lineage.Downstream(ctx, sourceAsset.ID, marmot.LineageOptions{})
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to read source lineage", err)
+		return
+	}
+	edges := filterLineageOrigins(downstream.Edges, origins)
+
+	path := shortestLineagePath(edges, source, target, maxHops)
+	if path == nil {
+		// The target may lie beyond the depth the server traverses from the
+		// source, so search from the other end as well.
+		targetAsset, ok := d.lookup(ctx, target, resp)
+		if !ok {
+			return
+		}
+		upstream, err := d.client.Lineage.Upstream(ctx, targetAsset.ID, marmot.LineageOptions{})
+		if err != nil {
+			addClientError(ctx, &resp.Diagnostics, "Unable to read target lineage", err)
+			return
+		}
+		path = shortestLineagePath(append(edges, filterLineageOrigins(upstream.Edges, origins)...), source, target, maxHops)
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.StringType, nonNilStrings(path))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Exists = types.BoolValue(path != nil)
+	data.Path = list
+	data.Hops = types.Int64Null()
+	if path != nil {
+		data.Hops = types.Int64Value(int64(len(path) - 1))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// lookup returns the asset with the given MRN, adding an error to resp when
+// it can't be found.
+func (d *LineagePathDataSource) lookup(ctx context.Context, mrn string, resp *datasource.ReadResponse) (*marmot.Asset, bool) {
+	asset, err := d.assets.getByMRN(ctx, mrn)
+	if marmot.IsNotFound(err) || (err == nil && asset == nil) {
+		resp.Diagnostics.AddError("Asset Not Found", fmt.Sprintf("No Marmot asset has the MRN %s.", mrn))
+		return nil, false
+	}
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to read asset", err)
+		return nil, false
+	}
+	return asset, true
+}
+
+// filterLineageOrigins returns the edges whose origin is in origins, or all
+// of them when origins is nil.
+func filterLineageOrigins(edges []*marmot.LineageEdge, origins map[string]bool) []*marmot.LineageEdge {
+	if origins == nil {
+		return edges
+	}
+	var kept []*marmot.LineageEdge
+	for _, edge := range edges {
+		if edge != nil && origins[edge.Origin] {
+			kept = append(kept, edge)
+		}
+	}
+	return kept
+}
+
+// shortestLineagePath returns the MRNs along the shortest path of edges from
+// source to target, or nil when there is none within maxHops edges. A
+// negative maxHops means no limit. Ties are broken by MRN, so the same graph
+// always gives the same path.
+func shortestLineagePath(edges []*marmot.LineageEdge, source, target string, maxHops int) []string {
+	if source == target {
+		return []string{source}
+	}
+
+	next := map[string][]string{}
+	for _, edge := range edges {
+		if edge != nil && edge.Source != edge.Target {
+			next[edge.Source] = append(next[edge.Source], edge.Target)
+		}
+	}
+	for _, targets := range next {
+		sort.Strings(targets)
+	}
+
+	prev := map[string]string{source: ""}
+	frontier := []string{source}
+	for hops := 0; len(frontier) > 0 && (maxHops < 0 || hops < maxHops); hops++ {
+		var following []string
+		for _, mrn := range frontier {
+			for _, t := range next[mrn] {
+				if _, seen := prev[t]; seen {
+					continue
+				}
+				prev[t] = mrn
+				if t == target {
+					path := []string{t}
+					for p := mrn; p != ""; p = prev[p] {
+						path = append(path, p)
+					}
+					for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+						path[i], path[j] = path[j], path[i]
+					}
+					return path
+				}
+				following = append(following, t)
+			}
+		}
+		frontier = following
+	}
+	return nil
+}
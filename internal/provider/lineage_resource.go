@@ -6,12 +6,19 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	marmot "github.com/marmotdata/marmot/sdk/go"
@@ -20,6 +27,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &LineageResource{}
 var _ resource.ResourceWithImportState = &LineageResource{}
+var _ resource.ResourceWithConfigValidators = &LineageResource{}
+var _ resource.ResourceWithModifyPlan = &LineageResource{}
 
 func NewLineageResource() resource.Resource {
 	return &LineageResource{}
@@ -28,37 +37,120 @@ func NewLineageResource() resource.Resource {
 // LineageResource defines the resource implementation.
 type LineageResource struct {
 	client *marmot.Client
+	assets *assetLookup
+
+	// readOnly keeps apply from deleting expired edges.
+	readOnly bool
 }
 
 // LineageResourceModel describes the lineage resource data model.
 type LineageResourceModel struct {
-	Source types.String `tfsdk:"source"`
-	Target types.String `tfsdk:"target"`
-	ID     types.String `tfsdk:"id"`
+	Source        types.String `tfsdk:"source"`
+	Target        types.String `tfsdk:"target"`
+	SourceAssetID types.String `tfsdk:"source_asset_id"`
+	TargetAssetID types.String `tfsdk:"target_asset_id"`
+	OnConflict    types.String `tfsdk:"on_conflict"`
+	ExpiresAt     types.String `tfsdk:"expires_at"`
+	ID            types.String `tfsdk:"id"`
+	Origin        types.String `tfsdk:"origin"`
 }
 
+// lineageImportSeparator separates the two ends in an import ID such as
+// "mrn://table/postgresql/orders->mrn://dashboard/looker/revenue".
+const lineageImportSeparator = "->"
+
+// Values of on_conflict.
+const (
+	lineageConflictAdopt = "adopt"
+	lineageConflictError = "error"
+)
+
 func (r *LineageResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_lineage"
 }
 
 func (r *LineageResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Lineage resource representing a connection between two assets",
+		MarkdownDescription: "Lineage resource representing a connection between two assets. Each " +
+			"end is given either as an MRN (`source`, `target`) or as an asset ID (`source_asset_id`, " +
+			"`target_asset_id`), which is resolved to the asset's MRN on create. Planning a new " +
+			"edge fails when an end that is already known names an asset missing from the catalog.",
 
 		Attributes: map[string]schema.Attribute{
 			"source": schema.StringAttribute{
-				MarkdownDescription: "Source asset",
-				Required:            true,
+				MarkdownDescription: "MRN of the source asset, e.g. `mrn://table/postgresql/orders`. " +
+					"Conflicts with `source_asset_id`; computed when that is set.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					isMRN(),
+				},
 			},
 			"target": schema.StringAttribute{
-				MarkdownDescription: "Target asset",
-				Required:            true,
+				MarkdownDescription: "MRN of the target asset, e.g. `mrn://dashboard/looker/revenue`. " +
+					"Conflicts with `target_asset_id`; computed when that is set.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					isMRN(),
+				},
+			},
+			"source_asset_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the source asset, such as `marmot_asset.x.id`. Conflicts with " +
+					"`source`; computed when that is set, and null when no asset has that MRN.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"target_asset_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the target asset, such as `marmot_asset.x.id`. Conflicts with " +
+					"`target`; computed when that is set, and null when no asset has that MRN.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"on_conflict": schema.StringAttribute{
+				MarkdownDescription: "What to do when the edge already exists, for example because " +
+					"another configuration created it at the same time: `adopt` (the default) takes " +
+					"over the existing edge, `error` fails the apply. An adopted edge is deleted when " +
+					"any resource managing it is destroyed.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(lineageConflictAdopt),
+				Validators: []validator.String{
+					stringvalidator.OneOf(lineageConflictAdopt, lineageConflictError),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "When the edge stops applying, as an RFC 3339 timestamp such as " +
+					"`2026-01-31T18:00:00Z`. Use it for short-lived edges such as a backfill. Marmot " +
+					"has no expiry of its own, so the first apply after this time deletes the edge, " +
+					"and the refresh after that drops it from state; remove the resource from the " +
+					"configuration once it has expired. Changing it doesn't replace the edge.",
+				Optional: true,
+				Validators: []validator.String{
+					timestampValidator{},
+				},
 			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Lineage ID",
@@ -67,28 +159,50 @@ func (r *LineageResource) Schema(ctx context.Context, req resource.SchemaRequest
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"origin": schema.StringAttribute{
+				MarkdownDescription: "How Marmot recorded the edge, as it reports it: edges written " +
+					"through the API, as this resource does, are told apart from those that " +
+					"ingestion plugins and OpenLineage events derive. An adopted edge keeps the " +
+					"origin of whatever created it. Null when the server doesn't report one. " +
+					"Filter `marmot_lineage_path` by it with `origins`.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
 
+func (r *LineageResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("source"), path.MatchRoot("source_asset_id")),
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("target"), path.MatchRoot("target_asset_id")),
+	}
+}
+
 func (r *LineageResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*marmot.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *marmot.Client, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.assets = data.assets.forResources()
+	r.readOnly = data.readOnly
 }
 
 func (r *LineageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data LineageResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -96,21 +210,62 @@ func (r *LineageResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	source, err := r.resolveMRN(ctx, data.Source, data.SourceAssetID)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to resolve lineage source asset", err)
+		return
+	}
+	target, err := r.resolveMRN(ctx, data.Target, data.TargetAssetID)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to resolve lineage target asset", err)
+		return
+	}
+	if data.SourceAssetID, err = r.resolveAssetID(ctx, source, data.SourceAssetID); err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to resolve lineage source asset", err)
+		return
+	}
+	if data.TargetAssetID, err = r.resolveAssetID(ctx, target, data.TargetAssetID); err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to resolve lineage target asset", err)
+		return
+	}
+
 	edge, err := r.client.Lineage.Write(ctx, marmot.WriteEdgeInput{
-		Source: data.Source.ValueString(),
-		Target: data.Target.ValueString(),
+		Source: source,
+		Target: target,
 	})
+	if err != nil && isConflict(err) && data.OnConflict.ValueString() == lineageConflictAdopt {
+		// The edge already exists, typically created by a parallel apply;
+		// take it over rather than failing.
+		existing, findErr := r.findEdge(ctx, source, target)
+		if findErr != nil {
+			addClientError(ctx, &resp.Diagnostics, "Unable to find existing lineage to adopt", findErr)
+			return
+		}
+		if existing != nil {
+			tflog.Info(ctx, "Adopting existing lineage", map[string]interface{}{
+				"id":     existing.ID,
+				"source": source,
+				"target": target,
+			})
+			edge, err = existing, nil
+		}
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create lineage: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to create lineage", err)
 		return
 	}
 
 	data.ID = types.StringValue(edge.ID)
+	data.Source = types.StringValue(source)
+	data.Target = types.StringValue(target)
+	data.Origin = lineageOrigin(edge)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *LineageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data LineageResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -120,24 +275,166 @@ func (r *LineageResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 	edge, err := r.client.Lineage.Edge(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read lineage: %s", err))
+		if marmot.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to read lineage", err)
 		return
 	}
 
 	data.Source = types.StringValue(edge.Source)
 	data.Target = types.StringValue(edge.Target)
+	data.Origin = lineageOrigin(edge)
+
+	// Imports, and edges created by earlier provider versions, only hold
+	// the MRNs of their ends; look up the asset IDs so configurations that
+	// give the ends by ID don't plan a replacement.
+	if data.SourceAssetID, err = r.resolveAssetID(ctx, edge.Source, data.SourceAssetID); err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to resolve lineage source asset", err)
+		return
+	}
+	if data.TargetAssetID, err = r.resolveAssetID(ctx, edge.Target, data.TargetAssetID); err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to resolve lineage target asset", err)
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// Update only ever changes on_conflict and expires_at, which are local to the
+// provider; changes to either end replace the edge. Once expires_at has
+// passed, it deletes the edge, which ModifyPlan plans for; the next refresh
+// then drops it from state.
 func (r *LineageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"Lineage resources cannot be updated. Changes to source or target require replacement.",
-	)
+	ctx = withAPIErrorCapture(ctx)
+
+	var data, state LineageResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// UseStateForUnknown leaves a null origin unknown.
+	if data.Origin.IsUnknown() {
+		data.Origin = state.Origin
+	}
+	data.ID = state.ID
+
+	if lineageExpired(data.ExpiresAt, time.Now()) {
+		if err := r.client.Lineage.Delete(ctx, data.ID.ValueString()); err != nil && !marmot.IsNotFound(err) {
+			addClientError(ctx, &resp.Diagnostics, "Unable to delete expired lineage", err)
+			return
+		}
+		tflog.Info(ctx, "Lineage expired; deleted", map[string]interface{}{
+			"id":         data.ID.ValueString(),
+			"expires_at": data.ExpiresAt.ValueString(),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// ModifyPlan rejects creating an edge whose expires_at has already passed,
+// since it would only be deleted again. An existing edge whose expires_at
+// has passed is planned for an update, which deletes it, so nothing is
+// deleted before apply. It also checks that both ends of a new edge exist,
+// so a missing asset fails the plan instead of the apply.
+func (r *LineageResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data LineageResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	expired := lineageExpired(data.ExpiresAt, time.Now())
+
+	if !req.State.Raw.IsNull() {
+		switch {
+		case !expired:
+		case r.readOnly:
+			resp.Diagnostics.AddWarning(
+				"Lineage Expired",
+				fmt.Sprintf("The edge %s expired at %s but is not deleted, since the provider is read-only.",
+					data.ID.ValueString(), data.ExpiresAt.ValueString()),
+			)
+		default:
+			resp.Diagnostics.AddWarning(
+				"Lineage Expired",
+				fmt.Sprintf("The edge %s expired at %s and is deleted when this plan is applied. Remove "+
+					"the resource from the configuration afterwards.", data.ID.ValueString(), data.ExpiresAt.ValueString()),
+			)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		}
+		return
+	}
+
+	if expired {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expires_at"),
+			"Lineage Already Expired",
+			fmt.Sprintf("expires_at (%s) has passed, so the edge would be deleted again on the next "+
+				"apply. Remove the resource from the configuration, or move expires_at later.",
+				data.ExpiresAt.ValueString()),
+		)
+	}
+
+	ctx = withAPIErrorCapture(ctx)
+	r.checkEnd(ctx, "source", data.Source, data.SourceAssetID, &resp.Diagnostics)
+	r.checkEnd(ctx, "target", data.Target, data.TargetAssetID, &resp.Diagnostics)
+}
+
+// checkEnd adds an error when one end of a planned edge names an asset that
+// doesn't exist. Ends that are unknown, typically because they reference an
+// asset created in the same apply, are left to the apply. A lookup that
+// fails for another reason only warns.
+//
+// Both forms of an end are computed from the other, so the form left out of
+// the configuration is unknown rather than null in the plan. An end given
+// by asset ID is therefore recognised by its ID being known.
+func (r *LineageResource) checkEnd(ctx context.Context, end string, mrn, assetID types.String, diags *diag.Diagnostics) {
+	attr, ref := end, mrn
+	if !assetID.IsNull() && !assetID.IsUnknown() {
+		attr, ref = end+"_asset_id", assetID
+	}
+	if ref.IsNull() || ref.IsUnknown() {
+		return
+	}
+
+	var asset *marmot.Asset
+	var err error
+	if attr == end {
+		asset, err = r.assets.getByMRN(ctx, ref.ValueString())
+	} else {
+		asset, err = r.assets.getByID(ctx, ref.ValueString())
+	}
+	switch {
+	case err == nil && asset != nil:
+	case err == nil || marmot.IsNotFound(err):
+		diags.AddAttributeError(
+			path.Root(attr),
+			"Lineage Asset Not Found",
+			fmt.Sprintf("No Marmot asset matches the %s %s, so the edge can't be created. If the asset "+
+				"is created in this configuration, reference it, as in marmot_asset.x.mrn or "+
+				"marmot_asset.x.id, so Terraform creates it first.", attr, ref.ValueString()),
+		)
+	default:
+		diags.AddAttributeWarning(
+			path.Root(attr),
+			"Unable to Check Lineage Asset",
+			clientErrorDetail(ctx, fmt.Sprintf("Unable to check that the %s asset exists; it is checked again on apply", end), err),
+		)
+	}
 }
 
 func (r *LineageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data LineageResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -145,8 +442,8 @@ func (r *LineageResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	if err := r.client.Lineage.Delete(ctx, data.ID.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete lineage: %s", err))
+	if err := r.client.Lineage.Delete(ctx, data.ID.ValueString()); err != nil && !marmot.IsNotFound(err) {
+		addClientError(ctx, &resp.Diagnostics, "Unable to delete lineage", err)
 		return
 	}
 
@@ -155,6 +452,119 @@ func (r *LineageResource) Delete(ctx context.Context, req resource.DeleteRequest
 	})
 }
 
+// ImportState imports an edge by its ID, or by its ends as
+// "<source>-><target>", each given as an MRN or asset ID.
 func (r *LineageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx = withAPIErrorCapture(ctx)
+
+	sourceRef, targetRef, ok := strings.Cut(req.ID, lineageImportSeparator)
+	if !ok {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_conflict"), lineageConflictAdopt)...)
+		return
+	}
+
+	var mrns [2]string
+	for i, ref := range []string{strings.TrimSpace(sourceRef), strings.TrimSpace(targetRef)} {
+		asset, err := findAssetRef(ctx, r.client, ref)
+		if err != nil {
+			addClientError(ctx, &resp.Diagnostics, fmt.Sprintf("Unable to find asset %q", ref), err)
+			return
+		}
+		mrns[i] = asset.Mrn
+	}
+	edge, err := r.findEdge(ctx, mrns[0], mrns[1])
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to find lineage to import", err)
+		return
+	}
+	if edge == nil {
+		resp.Diagnostics.AddError(
+			"Lineage Not Found",
+			fmt.Sprintf("There is no lineage edge from %s to %s.", mrns[0], mrns[1]),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), edge.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_conflict"), lineageConflictAdopt)...)
+}
+
+// lineageExpired reports whether expiresAt is set and not after now.
+func lineageExpired(expiresAt types.String, now time.Time) bool {
+	if expiresAt.IsNull() || expiresAt.IsUnknown() {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, expiresAt.ValueString())
+	return err == nil && !t.After(now)
+}
+
+// lineageOrigin returns the origin Marmot reports for edge, or null when it
+// reports none.
+func lineageOrigin(edge *marmot.LineageEdge) types.String {
+	if edge.Origin == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(edge.Origin)
+}
+
+// findEdge returns the existing edge from source to target, or nil when the
+// source asset's lineage doesn't include one.
+func (r *LineageResource) findEdge(ctx context.Context, source, target string) (*marmot.LineageEdge, error) {
+	assetType, service, name, err := parseMRN(source)
+	if err != nil {
+		return nil, err
+	}
+	asset, err := r.client.Assets.Lookup(ctx, marmot.LookupInput{Type: assetType, Service: service, Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	lineage, err := r.client.Lineage.Downstream(ctx, asset.ID, marmot.LineageOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, edge := range lineage.Edges {
+		if edge != nil && edge.ID != "" && edge.Source == source && edge.Target == target {
+			return edge, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveMRN returns mrn when it is set, otherwise the MRN of the asset with
+// the given ID.
+func (r *LineageResource) resolveMRN(ctx context.Context, mrn, assetID types.String) (string, error) {
+	if !mrn.IsNull() && !mrn.IsUnknown() {
+		return mrn.ValueString(), nil
+	}
+
+	asset, err := r.client.Assets.Get(ctx, assetID.ValueString())
+	if err != nil {
+		return "", err
+	}
+	if asset.Mrn == "" {
+		return "", fmt.Errorf("asset %s has no MRN", assetID.ValueString())
+	}
+	return asset.Mrn, nil
+}
+
+// resolveAssetID returns id when it is set, otherwise the ID of the asset
+// with the given MRN, or null when there is no such asset, as for an edge to
+// an asset that isn't in the catalog yet.
+func (r *LineageResource) resolveAssetID(ctx context.Context, mrn string, id types.String) (types.String, error) {
+	if !id.IsNull() && !id.IsUnknown() {
+		return id, nil
+	}
+
+	asset, err := r.assets.getByMRN(ctx, mrn)
+	switch {
+	case marmot.IsNotFound(err):
+		return types.StringNull(), nil
+	case err != nil:
+		return id, err
+	case asset == nil:
+		return types.StringNull(), nil
+	}
+	return types.StringValue(asset.ID), nil
 }
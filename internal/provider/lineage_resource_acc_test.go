@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build acceptance
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/acctest"
+)
+
+func TestAccLineageResource(t *testing.T) {
+	prefix := acctest.RandomName("lineage")
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if err := testAccCheckDestroyed(t, "marmot_lineage", func(ctx context.Context, client *marmot.Client, id string) error {
+				_, err := client.Lineage.Edge(ctx, id)
+				return err
+			})(s); err != nil {
+				return err
+			}
+			return testAccCheckAssetsDestroyed(t)(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLineageConfig(prefix, "marmot_asset.report"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("marmot_lineage.test", "source", "marmot_asset.orders", "mrn"),
+					resource.TestCheckResourceAttrPair("marmot_lineage.test", "source_asset_id", "marmot_asset.orders", "id"),
+					resource.TestCheckResourceAttrPair("marmot_lineage.test", "target", "marmot_asset.report", "mrn"),
+					resource.TestCheckResourceAttrPair("marmot_lineage.test", "target_asset_id", "marmot_asset.report", "id"),
+					testAccStoreID("marmot_lineage.test", &id),
+				),
+			},
+			{
+				// Moving an end replaces the edge.
+				Config: testAccLineageConfig(prefix, "marmot_asset.dashboard"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("marmot_lineage.test", "target", "marmot_asset.dashboard", "mrn"),
+					testAccStoreID("marmot_lineage.test", &id),
+				),
+			},
+			{
+				ResourceName:      "marmot_lineage.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Edges can also be imported by their ends.
+				ResourceName: "marmot_lineage.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					edge := s.RootModule().Resources["marmot_lineage.test"].Primary.Attributes
+					return edge["source"] + lineageImportSeparator + edge["target"], nil
+				},
+				ImportStateVerify: true,
+			},
+			testAccDriftStep(t, testAccLineageConfig(prefix, "marmot_asset.dashboard"), func(ctx context.Context, client *marmot.Client) error {
+				return client.Lineage.Delete(ctx, id)
+			}),
+		},
+	})
+}
+
+func testAccLineageConfig(prefix, target string) string {
+	return testAccProviderConfig + fmt.Sprintf(`
+resource "marmot_asset" "orders" {
+  name     = "%[1]s-orders"
+  type     = "Table"
+  services = ["PostgreSQL"]
+}
+
+resource "marmot_asset" "report" {
+  name     = "%[1]s-report"
+  type     = "Dashboard"
+  services = ["Looker"]
+}
+
+resource "marmot_asset" "dashboard" {
+  name     = "%[1]s-dashboard"
+  type     = "Dashboard"
+  services = ["Looker"]
+}
+
+resource "marmot_lineage" "test" {
+  source = marmot_asset.orders.mrn
+  target = %[2]s.mrn
+}
+`, prefix, target)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// cachedAssetLookup returns an assetLookup whose cache already holds the
+// given results, so lookups never reach the API.
+func cachedAssetLookup(byMRN, byID map[string]*marmot.Asset) *assetLookup {
+	l := newAssetLookup(nil)
+	fill := func(cache map[string]*assetLookupEntry, assets map[string]*marmot.Asset) {
+		for k, asset := range assets {
+			e := &assetLookupEntry{done: make(chan struct{}), asset: asset}
+			if asset == nil {
+				e.err = &marmot.NotFoundError{APIError: &marmot.APIError{StatusCode: 404}}
+			}
+			close(e.done)
+			cache[k] = e
+		}
+	}
+	fill(l.byMRN, byMRN)
+	fill(l.byID, byID)
+	return l
+}
+
+func TestLineageCheckEnd(t *testing.T) {
+	const mrn = "mrn://table/postgres/orders"
+	r := &LineageResource{assets: cachedAssetLookup(
+		map[string]*marmot.Asset{mrn: {ID: "a1", Mrn: mrn}, "mrn://table/postgres/gone": nil},
+		map[string]*marmot.Asset{"a1": {ID: "a1", Mrn: mrn}, "gone": nil},
+	)}
+
+	tests := map[string]struct {
+		mrn, assetID types.String
+		wantErrorAt  string
+	}{
+		"mrn found": {
+			mrn: types.StringValue(mrn), assetID: types.StringUnknown(),
+		},
+		"mrn missing": {
+			mrn: types.StringValue("mrn://table/postgres/gone"), assetID: types.StringUnknown(),
+			wantErrorAt: "source",
+		},
+		"asset id found": {
+			mrn: types.StringUnknown(), assetID: types.StringValue("a1"),
+		},
+		"asset id missing": {
+			mrn: types.StringUnknown(), assetID: types.StringValue("gone"),
+			wantErrorAt: "source_asset_id",
+		},
+		"both unknown": {
+			mrn: types.StringUnknown(), assetID: types.StringUnknown(),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			r.checkEnd(t.Context(), "source", tt.mrn, tt.assetID, &diags)
+			if tt.wantErrorAt == "" {
+				if diags.ErrorsCount() > 0 {
+					t.Fatalf("unexpected errors: %v", diags)
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("got %d errors, want 1: %v", diags.ErrorsCount(), diags)
+			}
+			if got := diags.Errors()[0].(diag.DiagnosticWithPath).Path(); !got.Equal(path.Root(tt.wantErrorAt)) {
+				t.Errorf("error at %s, want %s", got, tt.wantErrorAt)
+			}
+		})
+	}
+}
+
+func TestLineageResolveAssetID(t *testing.T) {
+	const mrn = "mrn://table/postgres/orders"
+	r := &LineageResource{assets: cachedAssetLookup(
+		map[string]*marmot.Asset{mrn: {ID: "a1", Mrn: mrn}, "mrn://table/postgres/gone": nil},
+		nil,
+	)}
+
+	tests := map[string]struct {
+		mrn  string
+		id   types.String
+		want types.String
+	}{
+		"configured":      {mrn: mrn, id: types.StringValue("configured"), want: types.StringValue("configured")},
+		"unknown":         {mrn: mrn, id: types.StringUnknown(), want: types.StringValue("a1")},
+		"null in state":   {mrn: mrn, id: types.StringNull(), want: types.StringValue("a1")},
+		"asset not found": {mrn: "mrn://table/postgres/gone", id: types.StringUnknown(), want: types.StringNull()},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := r.resolveAssetID(t.Context(), tt.mrn, tt.id)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
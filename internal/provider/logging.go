@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxLoggedBodyBytes bounds how much of a request or response body is
+// written to the TRACE log.
+const maxLoggedBodyBytes = 16 << 10
+
+// redactedValue replaces secrets in logged headers and bodies.
+const redactedValue = "[REDACTED]"
+
+// sensitiveHeaders are always redacted when headers are logged.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "X-Api-Key", "Cookie", "Set-Cookie"}
+
+// sensitiveBodyKeys are the JSON object keys whose values are redacted from
+// logged bodies. Keys match case-insensitively, on substring.
+var sensitiveBodyKeys = []string{"password", "secret", "token", "api_key", "apikey", "credential"}
+
+// withSensitiveLogValues returns a context under which the given values are
+// masked anywhere they would appear in the provider's logs, including the
+// request bodies logged by loggingTransport. Resources use it for
+// write-only values that end up inside otherwise loggable payloads.
+func withSensitiveLogValues(ctx context.Context, values ...string) context.Context {
+	nonEmpty := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" {
+			nonEmpty = append(nonEmpty, v)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return ctx
+	}
+	return tflog.MaskLogStrings(ctx, nonEmpty...)
+}
+
+// loggingTransport logs each API request through tflog: method, path,
+// status, and latency at DEBUG, plus headers and bodies at TRACE. Credential
+// headers, any header named in redactHeaders, and secret-looking JSON fields
+// are redacted. Run Terraform with TF_LOG=DEBUG or TF_LOG=TRACE to see them.
+type loggingTransport struct {
+	base          http.RoundTripper
+	redactHeaders map[string]bool
+}
+
+func newLoggingTransport(base http.RoundTripper, extraSensitiveHeaders []string) *loggingTransport {
+	redact := make(map[string]bool, len(sensitiveHeaders)+len(extraSensitiveHeaders))
+	for _, h := range sensitiveHeaders {
+		redact[http.CanonicalHeaderKey(h)] = true
+	}
+	for _, h := range extraSensitiveHeaders {
+		redact[http.CanonicalHeaderKey(h)] = true
+	}
+	return &loggingTransport{base: base, redactHeaders: redact}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	fields := map[string]any{
+		"http_method": req.Method,
+		"http_path":   req.URL.Path,
+	}
+	if req.URL.RawQuery != "" {
+		fields["http_query"] = req.URL.RawQuery
+	}
+
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			fields["http_request_body"] = loggableBody(body)
+			body.Close()
+		}
+	}
+	tflog.Trace(ctx, "Sending Marmot API request", mergeFields(fields, map[string]any{
+		"http_request_headers": t.loggableHeaders(req.Header),
+	}))
+	delete(fields, "http_request_body")
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	fields["http_duration_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "Marmot API request failed", fields)
+		return resp, err
+	}
+	fields["http_status"] = resp.StatusCode
+	tflog.Debug(ctx, "Marmot API request", fields)
+
+	body, readErr := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBodyBytes))
+	rest := resp.Body
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), rest), rest}
+	if readErr == nil {
+		tflog.Trace(ctx, "Received Marmot API response", mergeFields(fields, map[string]any{
+			"http_response_headers": t.loggableHeaders(resp.Header),
+			"http_response_body":    redactBody(body),
+		}))
+	}
+
+	return resp, nil
+}
+
+// loggableHeaders flattens h for logging, redacting sensitive values.
+func (t *loggingTransport) loggableHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		if t.redactHeaders[http.CanonicalHeaderKey(k)] {
+			out[k] = redactedValue
+			continue
+		}
+		out[k] = strings.Join(v, ", ")
+	}
+	return out
+}
+
+// loggableBody reads at most maxLoggedBodyBytes of a request body copy.
+func loggableBody(r io.Reader) string {
+	body, err := io.ReadAll(io.LimitReader(r, maxLoggedBodyBytes))
+	if err != nil {
+		return ""
+	}
+	return redactBody(body)
+}
+
+// redactBody returns body as a string with secret-looking JSON fields
+// replaced. Bodies that aren't JSON are returned unchanged; values masked
+// through withSensitiveLogValues are still hidden by tflog.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	redacted, err := json.Marshal(redactJSON(v))
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+func redactJSON(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, elem := range val {
+			if isSensitiveKey(k) {
+				val[k] = redactedValue
+				continue
+			}
+			val[k] = redactJSON(elem)
+		}
+		return val
+	case []any:
+		for i, elem := range val {
+			val[i] = redactJSON(elem)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveBodyKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeFields(base, extra map[string]any) map[string]any {
+	out := make(map[string]any, len(base)+len(extra))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range extra {
+		out[k] = v
+	}
+	return out
+}
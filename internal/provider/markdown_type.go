@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ basetypes.StringTypable = markdownType{}
+var _ basetypes.StringValuableWithSemanticEquals = markdownValue{}
+
+// markdownType is a string attribute type for Markdown text such as
+// descriptions. Values that differ only in line endings or in blank lines
+// and trailing whitespace around the text are semantically equal, so a
+// heredoc's trailing newline, or a server that trims it, isn't drift.
+type markdownType struct {
+	basetypes.StringType
+}
+
+func (t markdownType) String() string {
+	return "markdownType"
+}
+
+func (t markdownType) ValueType(ctx context.Context) attr.Value {
+	return markdownValue{}
+}
+
+func (t markdownType) Equal(o attr.Type) bool {
+	other, ok := o.(markdownType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t markdownType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return markdownValue{StringValue: in}, nil
+}
+
+func (t markdownType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return markdownValue{StringValue: stringValue}, nil
+}
+
+// markdownValue is a value of markdownType.
+type markdownValue struct {
+	basetypes.StringValue
+}
+
+func (v markdownValue) Type(ctx context.Context) attr.Type {
+	return markdownType{}
+}
+
+func (v markdownValue) Equal(o attr.Value) bool {
+	other, ok := o.(markdownValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v markdownValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(markdownValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return normalizeMarkdown(v.ValueString()) == normalizeMarkdown(newValue.ValueString()), diags
+}
+
+// normalizeMarkdown converts line endings to \n and drops leading blank lines
+// and trailing whitespace. Leading spaces on the first line are kept, since
+// they can make it a code block.
+func normalizeMarkdown(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	s = strings.TrimRight(s, " \t\n")
+
+	for {
+		line, rest, ok := strings.Cut(s, "\n")
+		if !ok || strings.TrimSpace(line) != "" {
+			return s
+		}
+		s = rest
+	}
+}
+
+func newMarkdownValue(s string) markdownValue {
+	return markdownValue{StringValue: basetypes.NewStringValue(s)}
+}
+
+func newMarkdownNull() markdownValue {
+	return markdownValue{StringValue: basetypes.NewStringNull()}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestNormalizeMarkdown(t *testing.T) {
+	tests := map[string]struct {
+		in, want string
+	}{
+		"unchanged":           {in: "# Title\n\nBody", want: "# Title\n\nBody"},
+		"crlf":                {in: "a\r\nb\r\n", want: "a\nb"},
+		"bare cr":             {in: "a\rb", want: "a\nb"},
+		"trailing whitespace": {in: "text \t\n\n", want: "text"},
+		"leading blank lines": {in: "\n  \n\ttext", want: "\ttext"},
+		"leading spaces kept": {in: "    code\n", want: "    code"},
+		"inner spaces kept":   {in: "a  \n\n\n  b", want: "a  \n\n\n  b"},
+		"only whitespace":     {in: " \n\t\r\n", want: ""},
+		"empty":               {in: "", want: ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := normalizeMarkdown(tt.in); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownSemanticEquals(t *testing.T) {
+	ctx := t.Context()
+
+	equal, diags := newMarkdownValue("Body\n").StringSemanticEquals(ctx, newMarkdownValue("\r\nBody"))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !equal {
+		t.Error("values differing in surrounding whitespace: got not equal")
+	}
+
+	equal, _ = newMarkdownValue("    code").StringSemanticEquals(ctx, newMarkdownValue("code"))
+	if equal {
+		t.Error("values differing in indentation: got equal")
+	}
+}
@@ -0,0 +1,362 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &MetadataSyncRunResource{}
+var _ resource.ResourceWithImportState = &MetadataSyncRunResource{}
+
+const (
+	// syncRunPollInterval is how often a waiting run is checked.
+	syncRunPollInterval = 5 * time.Second
+
+	// syncRunDefaultTimeout is how long a run is waited for by default.
+	syncRunDefaultTimeout = "30m"
+)
+
+func NewMetadataSyncRunResource() resource.Resource {
+	return &MetadataSyncRunResource{}
+}
+
+// MetadataSyncRunResource defines the resource implementation.
+type MetadataSyncRunResource struct {
+	api *apiClient
+}
+
+// MetadataSyncRunResourceModel describes the metadata sync run resource data model.
+type MetadataSyncRunResourceModel struct {
+	PipelineID        types.String `tfsdk:"pipeline_id"`
+	Triggers          types.Map    `tfsdk:"triggers"`
+	WaitForCompletion types.Bool   `tfsdk:"wait_for_completion"`
+	Timeout           types.String `tfsdk:"timeout"`
+	ID                types.String `tfsdk:"id"`
+	Status            types.String `tfsdk:"status"`
+	ErrorMessage      types.String `tfsdk:"error_message"`
+	StartedAt         types.String `tfsdk:"started_at"`
+	FinishedAt        types.String `tfsdk:"finished_at"`
+	AssetsCreated     types.Int64  `tfsdk:"assets_created"`
+	AssetsUpdated     types.Int64  `tfsdk:"assets_updated"`
+	AssetsDeleted     types.Int64  `tfsdk:"assets_deleted"`
+	LineageCreated    types.Int64  `tfsdk:"lineage_created"`
+}
+
+func (r *MetadataSyncRunResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_metadata_sync_run"
+}
+
+func (r *MetadataSyncRunResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Triggers a run of a pipeline outside its cron schedule, for example to " +
+			"catalog a database right after it is provisioned. The run happens on create, and again " +
+			"whenever `pipeline_id` or `triggers` change. Destroying the resource only removes it " +
+			"from state.",
+
+		Attributes: map[string]schema.Attribute{
+			"pipeline_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the pipeline to run, such as `marmot_pipeline.x.id`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that start a new run when any of them change, " +
+					"such as the ID of a database the pipeline scans",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the run to finish, and fail the apply if it fails. " +
+					"Defaults to `false`, which returns as soon as the run is queued.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the run when `wait_for_completion` is set, " +
+					"as a Go duration such as `45m`. Defaults to `30m`. The run itself carries on " +
+					"after a timeout.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(syncRunDefaultTimeout),
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Run ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Status of the run as reported by Marmot, such as `running`, `succeeded`, or `failed`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"error_message": schema.StringAttribute{
+				MarkdownDescription: "Error reported by a failed run",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"started_at": schema.StringAttribute{
+				MarkdownDescription: "Timestamp the run started",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"finished_at": schema.StringAttribute{
+				MarkdownDescription: "Timestamp the run finished",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"assets_created": schema.Int64Attribute{
+				MarkdownDescription: "Number of assets the run created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"assets_updated": schema.Int64Attribute{
+				MarkdownDescription: "Number of assets the run updated",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"assets_deleted": schema.Int64Attribute{
+				MarkdownDescription: "Number of assets the run deleted",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"lineage_created": schema.Int64Attribute{
+				MarkdownDescription: "Number of lineage edges the run created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *MetadataSyncRunResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = data.api
+}
+
+func (r *MetadataSyncRunResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data MetadataSyncRunResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	run, err := r.api.triggerSchedule(ctx, data.PipelineID.ValueString())
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to trigger pipeline run", err)
+		return
+	}
+	if run.ID == "" {
+		resp.Diagnostics.AddError("API Error", "Pipeline run triggered but no ID returned")
+		return
+	}
+
+	tflog.Info(ctx, "Pipeline run triggered", map[string]interface{}{
+		"pipeline_id": data.PipelineID.ValueString(),
+		"run_id":      run.ID,
+	})
+
+	if data.WaitForCompletion.ValueBool() {
+		// The timeout was checked at validate time.
+		timeout, _ := time.ParseDuration(data.Timeout.ValueString())
+		run = r.waitForRun(ctx, run, timeout, &resp.Diagnostics)
+	}
+
+	// Record the run even when waiting failed, so it is tracked and a
+	// replacement starts a new one.
+	applyJobRun(&data, run)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MetadataSyncRunResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data MetadataSyncRunResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	run, err := r.api.getJobRun(ctx, data.ID.ValueString())
+	if err != nil {
+		// Old runs may be pruned by the server. The run still happened, so
+		// keep the last known values rather than starting another.
+		if marmot.IsNotFound(err) {
+			tflog.Debug(ctx, "Pipeline run no longer found; keeping state", map[string]interface{}{
+				"run_id": data.ID.ValueString(),
+			})
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to read pipeline run", err)
+		return
+	}
+
+	// An import starts from the run ID alone.
+	if data.PipelineID.IsNull() {
+		data.PipelineID = types.StringValue(run.ScheduleID)
+	}
+	if data.WaitForCompletion.IsNull() {
+		data.WaitForCompletion = types.BoolValue(false)
+	}
+	if data.Timeout.IsNull() {
+		data.Timeout = types.StringValue(syncRunDefaultTimeout)
+	}
+
+	applyJobRun(&data, run)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MetadataSyncRunResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data MetadataSyncRunResourceModel
+
+	// Only wait_for_completion and timeout can change in place, and they
+	// only apply to the next run, so there is nothing to send.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MetadataSyncRunResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data MetadataSyncRunResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Pipeline run removed from state", map[string]interface{}{
+		"run_id": data.ID.ValueString(),
+	})
+}
+
+func (r *MetadataSyncRunResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// waitForRun polls the run until it finishes or timeout passes, returning
+// the last state seen. Failed runs and timeouts are reported in diags.
+func (r *MetadataSyncRunResource) waitForRun(ctx context.Context, run *jobRun, timeout time.Duration, diags *diag.Diagnostics) *jobRun {
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(syncRunPollInterval)
+	defer ticker.Stop()
+
+	for !run.finished() {
+		if time.Now().After(deadline) {
+			diags.AddError(
+				"Pipeline Run Timed Out",
+				fmt.Sprintf("Run %s was still %s after %s. It carries on in Marmot; raise timeout to wait longer.",
+					run.ID, run.Status, timeout),
+			)
+			return run
+		}
+
+		select {
+		case <-ctx.Done():
+			diags.AddError("Pipeline Run Wait Cancelled", fmt.Sprintf("Stopped waiting for run %s: %s", run.ID, ctx.Err()))
+			return run
+		case <-ticker.C:
+		}
+
+		latest, err := r.api.getJobRun(ctx, run.ID)
+		if err != nil {
+			addClientError(ctx, diags, "Unable to read pipeline run", err)
+			return run
+		}
+		run = latest
+
+		tflog.Debug(ctx, "Waiting for pipeline run", map[string]interface{}{
+			"run_id": run.ID,
+			"status": run.Status,
+		})
+	}
+
+	if run.failed() {
+		detail := fmt.Sprintf("Run %s ended with status %s.", run.ID, run.Status)
+		if run.ErrorMessage != "" {
+			detail += "\n\n" + run.ErrorMessage
+		}
+		diags.AddError("Pipeline Run Failed", detail)
+	}
+	return run
+}
+
+func applyJobRun(model *MetadataSyncRunResourceModel, run *jobRun) {
+	model.ID = types.StringValue(run.ID)
+	model.Status = types.StringValue(run.Status)
+	model.ErrorMessage = optionalString(run.ErrorMessage)
+	model.StartedAt = optionalString(normalizeTimestamp(run.StartedAt))
+	model.FinishedAt = optionalString(normalizeTimestamp(run.FinishedAt))
+	model.AssetsCreated = types.Int64Value(run.AssetsCreated)
+	model.AssetsUpdated = types.Int64Value(run.AssetsUpdated)
+	model.AssetsDeleted = types.Int64Value(run.AssetsDeleted)
+	model.LineageCreated = types.Int64Value(run.LineageCreated)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &NormalizeSchemaFunction{}
+
+func NewNormalizeAvroFunction() function.Function {
+	return &NormalizeSchemaFunction{
+		name:    "normalize_avro",
+		format:  "Avro",
+		summary: "Canonicalize an Avro schema",
+		description: "Returns an Avro schema in a canonical form: object keys sorted, whitespace " +
+			"removed, and primitive types written as `{\"type\": \"string\"}` collapsed to `\"string\"`. " +
+			"The order of record fields, union branches, and enum symbols is meaningful in Avro and is " +
+			"kept.",
+		normalize: normalizeAvroSchema,
+	}
+}
+
+func NewNormalizeJSONSchemaFunction() function.Function {
+	return &NormalizeSchemaFunction{
+		name:    "normalize_json_schema",
+		format:  "JSON Schema",
+		summary: "Canonicalize a JSON Schema document",
+		description: "Returns a JSON Schema document in a canonical form: object keys sorted, " +
+			"whitespace removed, and `required` lists sorted.",
+		normalize: normalizeJSONSchema,
+	}
+}
+
+func NewNormalizeProtobufFunction() function.Function {
+	return &NormalizeSchemaFunction{
+		name:    "normalize_protobuf",
+		format:  "Protobuf",
+		summary: "Canonicalize a Protobuf schema",
+		description: "Returns a `.proto` definition in a canonical layout: comments dropped, one " +
+			"statement per line, two-space indentation, and single spaces between tokens. " +
+			"Declaration order is kept.",
+		normalize: normalizeProtobufSchema,
+	}
+}
+
+// NormalizeSchemaFunction defines the schema normalization functions. Each
+// canonicalizes one schema format so that schemas stored in `marmot_asset`'s
+// `schema` attribute don't show diffs for formatting or key order alone.
+type NormalizeSchemaFunction struct {
+	name        string
+	format      string
+	summary     string
+	description string
+	normalize   func(string) (string, error)
+}
+
+func (f *NormalizeSchemaFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = f.name
+}
+
+func (f *NormalizeSchemaFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: f.summary,
+		MarkdownDescription: f.description + " Use it on schema documents before storing them in " +
+			"`marmot_asset`'s `schema` attribute, so regenerated schemas only show a diff when their " +
+			"content changes.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "schema",
+				MarkdownDescription: fmt.Sprintf("%s schema document", f.format),
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeSchemaFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var schema string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &schema))
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := f.normalize(schema)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid %s schema: %s", f.format, err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, normalized))
+}
@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+const (
+	// openLineageProducer identifies the provider as the producer of events
+	// that don't name one.
+	openLineageProducer = "https://github.com/marmotdata/terraform-provider-marmot"
+
+	// openLineageSchemaURL is the RunEvent schema assumed for events that
+	// don't name one.
+	openLineageSchemaURL = "https://openlineage.io/spec/2-0-2/OpenLineage.json#/definitions/RunEvent"
+
+	// openLineageDefaultEventType is used when an event has no eventType,
+	// since a Terraform-published event describes a job that is in place.
+	openLineageDefaultEventType = "COMPLETE"
+)
+
+// openLineageEventTypes are the run states defined by the OpenLineage spec.
+var openLineageEventTypes = []string{"START", "RUNNING", "COMPLETE", "ABORT", "FAIL", "OTHER"}
+
+// openLineageEvent holds the parts of an OpenLineage RunEvent the provider
+// inspects. Facets are passed through untouched, so they aren't modelled.
+type openLineageEvent struct {
+	EventType string `json:"eventType"`
+	EventTime string `json:"eventTime"`
+	Run       struct {
+		RunID string `json:"runId"`
+	} `json:"run"`
+	Job     openLineageDataset   `json:"job"`
+	Inputs  []openLineageDataset `json:"inputs"`
+	Outputs []openLineageDataset `json:"outputs"`
+}
+
+// openLineageDataset is the namespace and name pair that identifies both
+// jobs and datasets in OpenLineage.
+type openLineageDataset struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// parseOpenLineageEvent decodes and checks an OpenLineage RunEvent document.
+func parseOpenLineageEvent(doc string) (*openLineageEvent, error) {
+	var event openLineageEvent
+	if err := json.Unmarshal([]byte(doc), &event); err != nil {
+		return nil, fmt.Errorf("not a JSON object: %w", err)
+	}
+
+	if event.Job.Namespace == "" || event.Job.Name == "" {
+		return nil, fmt.Errorf("job.namespace and job.name are required")
+	}
+	if event.EventType != "" && !slices.Contains(openLineageEventTypes, event.EventType) {
+		return nil, fmt.Errorf("eventType must be one of %v, got %q", openLineageEventTypes, event.EventType)
+	}
+	if event.EventTime != "" {
+		if _, err := time.Parse(time.RFC3339, event.EventTime); err != nil {
+			return nil, fmt.Errorf("eventTime must be an RFC 3339 timestamp, got %q", event.EventTime)
+		}
+	}
+	for i, ds := range event.Inputs {
+		if ds.Namespace == "" || ds.Name == "" {
+			return nil, fmt.Errorf("inputs[%d] needs both a namespace and a name", i)
+		}
+	}
+	for i, ds := range event.Outputs {
+		if ds.Namespace == "" || ds.Name == "" {
+			return nil, fmt.Errorf("outputs[%d] needs both a namespace and a name", i)
+		}
+	}
+	return &event, nil
+}
+
+// completeOpenLineageEvent returns doc with the fields Marmot needs filled in
+// where the document leaves them out: eventType, eventTime, run.runId,
+// producer, and schemaURL. Everything else, facets included, is kept as is.
+func completeOpenLineageEvent(doc string, now time.Time) (map[string]any, error) {
+	var event map[string]any
+	if err := json.Unmarshal([]byte(doc), &event); err != nil {
+		return nil, err
+	}
+
+	run, _ := event["run"].(map[string]any)
+	if run == nil {
+		run = map[string]any{}
+		event["run"] = run
+	}
+	if isBlank(run["runId"]) {
+		runID, err := uuid.GenerateUUID()
+		if err != nil {
+			return nil, err
+		}
+		run["runId"] = runID
+	}
+
+	defaults := map[string]string{
+		"eventType": openLineageDefaultEventType,
+		"eventTime": now.UTC().Format(time.RFC3339),
+		"producer":  openLineageProducer,
+		"schemaURL": openLineageSchemaURL,
+	}
+	for key, value := range defaults {
+		if isBlank(event[key]) {
+			event[key] = value
+		}
+	}
+	return event, nil
+}
+
+// isBlank reports whether a decoded JSON value is missing or an empty string.
+func isBlank(v any) bool {
+	s, ok := v.(string)
+	return v == nil || (ok && s == "")
+}
+
+// postOpenLineageEvent sends a RunEvent to Marmot, which creates or updates
+// the job and dataset assets it names and the lineage between them.
+func (c *apiClient) postOpenLineageEvent(ctx context.Context, event map[string]any) error {
+	return c.do(ctx, http.MethodPost, "/lineage", nil, event, nil)
+}
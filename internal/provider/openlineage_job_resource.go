@@ -0,0 +1,279 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OpenLineageJobResource{}
+var _ resource.ResourceWithValidateConfig = &OpenLineageJobResource{}
+var _ resource.ResourceWithModifyPlan = &OpenLineageJobResource{}
+
+func NewOpenLineageJobResource() resource.Resource {
+	return &OpenLineageJobResource{}
+}
+
+// OpenLineageJobResource defines the resource implementation.
+type OpenLineageJobResource struct {
+	api *apiClient
+}
+
+// OpenLineageJobResourceModel describes the OpenLineage job resource data model.
+type OpenLineageJobResourceModel struct {
+	Event        jsontypes.Normalized `tfsdk:"event"`
+	JobNamespace types.String         `tfsdk:"job_namespace"`
+	JobName      types.String         `tfsdk:"job_name"`
+	RunID        types.String         `tfsdk:"run_id"`
+	EventTime    types.String         `tfsdk:"event_time"`
+}
+
+func (r *OpenLineageJobResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_openlineage_job"
+}
+
+func (r *OpenLineageJobResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Publishes an [OpenLineage](https://openlineage.io) run event to Marmot, " +
+			"which creates or updates the job and its input and output datasets as assets and links " +
+			"them with lineage. Use it to publish lineage exported from tools such as Airflow or " +
+			"Spark without writing each `marmot_lineage` edge by hand. The event is sent again " +
+			"whenever it changes.\n\n" +
+			"Marmot keeps what an event created, so destroying this resource only removes it from " +
+			"state; the assets and lineage stay in the catalog.",
+
+		Attributes: map[string]schema.Attribute{
+			"event": schema.StringAttribute{
+				MarkdownDescription: "OpenLineage `RunEvent` document as JSON, for example from " +
+					"`file()` or `jsonencode()`. `job.namespace` and `job.name` are required; " +
+					"`eventType` defaults to `COMPLETE`, `eventTime` to the time of apply, " +
+					"`run.runId` to a generated UUID, and `producer` and `schemaURL` to values " +
+					"identifying this provider.",
+				Required:   true,
+				CustomType: jsontypes.NormalizedType{},
+			},
+			"job_namespace": schema.StringAttribute{
+				MarkdownDescription: "Namespace of the job in the event",
+				Computed:            true,
+			},
+			"job_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the job in the event",
+				Computed:            true,
+			},
+			"run_id": schema.StringAttribute{
+				MarkdownDescription: "Run ID sent with the last event",
+				Computed:            true,
+			},
+			"event_time": schema.StringAttribute{
+				MarkdownDescription: "Event time sent with the last event",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *OpenLineageJobResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = data.api
+}
+
+// ValidateConfig checks the event's shape at plan time, so a malformed
+// document fails before anything is sent.
+func (r *OpenLineageJobResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data OpenLineageJobResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Event.IsNull() || data.Event.IsUnknown() {
+		return
+	}
+
+	if _, err := parseOpenLineageEvent(data.Event.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("event"),
+			"Invalid OpenLineage Event",
+			fmt.Sprintf("The event is not a usable OpenLineage run event: %s.", err),
+		)
+	}
+}
+
+// ModifyPlan fills in the job identity from the event, so it is known at plan
+// time, along with the run ID and event time when the event sets them.
+func (r *OpenLineageJobResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan OpenLineageJobResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Event.IsUnknown() || plan.Event.IsNull() {
+		return
+	}
+
+	event, err := parseOpenLineageEvent(plan.Event.ValueString())
+	if err != nil {
+		// Reported by ValidateConfig.
+		return
+	}
+
+	plan.JobNamespace = types.StringValue(event.Job.Namespace)
+	plan.JobName = types.StringValue(event.Job.Name)
+
+	if !req.State.Raw.IsNull() {
+		var state OpenLineageJobResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if plan.Event.Equal(state.Event) {
+			plan.RunID = state.RunID
+			plan.EventTime = state.EventTime
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+			return
+		}
+	}
+
+	plan.RunID = types.StringUnknown()
+	if event.Run.RunID != "" {
+		plan.RunID = types.StringValue(event.Run.RunID)
+	}
+	plan.EventTime = types.StringUnknown()
+	if event.EventTime != "" {
+		plan.EventTime = types.StringValue(event.EventTime)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *OpenLineageJobResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data OpenLineageJobResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.publish(ctx, &data, &resp.Diagnostics) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OpenLineageJobResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Marmot has no record of individual events to read back, so the state
+	// is what was last sent.
+	var data OpenLineageJobResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OpenLineageJobResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data OpenLineageJobResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.publish(ctx, &data, &resp.Diagnostics) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OpenLineageJobResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data OpenLineageJobResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "OpenLineage job removed from state; its assets and lineage remain in Marmot", map[string]any{
+		"job_namespace": data.JobNamespace.ValueString(),
+		"job_name":      data.JobName.ValueString(),
+	})
+}
+
+// publish completes and sends the event in data, recording the job identity,
+// run ID, and event time that were sent. It reports whether it succeeded.
+func (r *OpenLineageJobResource) publish(ctx context.Context, data *OpenLineageJobResourceModel, diags *diag.Diagnostics) bool {
+	doc := data.Event.ValueString()
+
+	event, err := parseOpenLineageEvent(doc)
+	if err != nil {
+		diags.AddAttributeError(path.Root("event"), "Invalid OpenLineage Event", err.Error())
+		return false
+	}
+
+	body, err := completeOpenLineageEvent(doc, time.Now())
+	if err != nil {
+		diags.AddError("Unable to prepare OpenLineage event", err.Error())
+		return false
+	}
+
+	if err := r.api.postOpenLineageEvent(ctx, body); err != nil {
+		addClientError(ctx, diags, "Unable to publish OpenLineage event", err)
+		return false
+	}
+
+	run, _ := body["run"].(map[string]any)
+	runID, _ := run["runId"].(string)
+	eventTime, _ := body["eventTime"].(string)
+
+	data.JobNamespace = types.StringValue(event.Job.Namespace)
+	data.JobName = types.StringValue(event.Job.Name)
+	data.RunID = types.StringValue(runID)
+	data.EventTime = types.StringValue(eventTime)
+
+	tflog.Info(ctx, "OpenLineage event published", map[string]any{
+		"job_namespace": event.Job.Namespace,
+		"job_name":      event.Job.Name,
+		"run_id":        runID,
+		"inputs":        len(event.Inputs),
+		"outputs":       len(event.Outputs),
+	})
+	return true
+}
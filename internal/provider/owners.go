@@ -5,12 +5,41 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"sort"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// Owner types the provider maps to owner_team_ids and owner_user_ids. Marmot
+// may return others; owners of those types are reported and kept as they
+// are, since writing them back as a team or user would corrupt them.
+const (
+	ownerTypeTeam = "team"
+	ownerTypeUser = "user"
+)
+
+// knownOwnerType reports whether the provider maps owners of this type.
+func knownOwnerType(ownerType string) bool {
+	return ownerType == ownerTypeTeam || ownerType == ownerTypeUser
+}
+
+// addUnknownOwnerType reports an owner whose type the provider doesn't map:
+// as an error when the provider sets fail_on_unknown_enum_values, otherwise
+// as a warning.
+func addUnknownOwnerType(diags *diag.Diagnostics, strict bool, object, ownerID, ownerType string) {
+	detail := fmt.Sprintf("Marmot lists %s as an owner of the %s with the type %q, which this provider "+
+		"version doesn't know, so it isn't shown in owner_team_ids or owner_user_ids.", ownerID, object, ownerType)
+	if strict {
+		diags.AddError("Unknown Owner Type", detail+" Upgrade the provider, or unset "+
+			"fail_on_unknown_enum_values to keep such owners as they are.")
+		return
+	}
+	diags.AddWarning("Unknown Owner Type", detail+" Updates keep the owner as it is; upgrade the "+
+		"provider to manage it.")
+}
+
 // setStrings reads a string Set into a slice, returning nil when the set is
 // unset. The values are not sorted; callers that need a stable order sort them.
 func setStrings(ctx context.Context, set types.Set, diags *diag.Diagnostics) []string {
@@ -18,14 +18,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &PipelineResource{}
 var _ resource.ResourceWithImportState = &PipelineResource{}
+var _ resource.ResourceWithModifyPlan = &PipelineResource{}
 
 func NewPipelineResource() resource.Resource {
 	return &PipelineResource{}
@@ -34,22 +37,28 @@ func NewPipelineResource() resource.Resource {
 // PipelineResource defines the resource implementation.
 type PipelineResource struct {
 	client *marmot.Client
+	api    *apiClient
+
+	// validateOnPlan checks configs with the plugin during plan.
+	validateOnPlan bool
 }
 
 // PipelineResourceModel describes the pipeline resource data model.
 type PipelineResourceModel struct {
-	Name           types.String         `tfsdk:"name"`
-	PluginID       types.String         `tfsdk:"plugin_id"`
-	Config         jsontypes.Normalized `tfsdk:"config"`
-	CronExpression types.String         `tfsdk:"cron_expression"`
-	Enabled        types.Bool           `tfsdk:"enabled"`
-	ID             types.String         `tfsdk:"id"`
-	ManagedBy      types.String         `tfsdk:"managed_by"`
-	LastRunStatus  types.String         `tfsdk:"last_run_status"`
-	LastRunAt      types.String         `tfsdk:"last_run_at"`
-	NextRunAt      types.String         `tfsdk:"next_run_at"`
-	CreatedAt      types.String         `tfsdk:"created_at"`
-	UpdatedAt      types.String         `tfsdk:"updated_at"`
+	Name                   types.String         `tfsdk:"name"`
+	PluginID               types.String         `tfsdk:"plugin_id"`
+	Config                 jsontypes.Normalized `tfsdk:"config"`
+	SensitiveConfig        types.Map            `tfsdk:"sensitive_config"`
+	SensitiveConfigVersion types.String         `tfsdk:"sensitive_config_version"`
+	CronExpression         types.String         `tfsdk:"cron_expression"`
+	Enabled                types.Bool           `tfsdk:"enabled"`
+	ID                     types.String         `tfsdk:"id"`
+	ManagedBy              types.String         `tfsdk:"managed_by"`
+	LastRunStatus          types.String         `tfsdk:"last_run_status"`
+	LastRunAt              types.String         `tfsdk:"last_run_at"`
+	NextRunAt              types.String         `tfsdk:"next_run_at"`
+	CreatedAt              types.String         `tfsdk:"created_at"`
+	UpdatedAt              types.String         `tfsdk:"updated_at"`
 }
 
 func (r *PipelineResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -85,6 +94,22 @@ func (r *PipelineResource) Schema(ctx context.Context, req resource.SchemaReques
 				Required:   true,
 				CustomType: jsontypes.NormalizedType{},
 			},
+			"sensitive_config": schema.MapAttribute{
+				MarkdownDescription: "Top-level config keys, such as `password` or `service_account_key`, " +
+					"merged into `config` when sent but never kept in state or shown in plans. " +
+					"Write-only, so it needs Terraform 1.11 or newer. Keys must not also appear in " +
+					"`config`. Bump `sensitive_config_version` to push changed values.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				ElementType: types.StringType,
+			},
+			"sensitive_config_version": schema.StringAttribute{
+				MarkdownDescription: "A version marker for `sensitive_config`. Terraform can't diff a " +
+					"write-only value, so changing this is what triggers an update that sends the " +
+					"current `sensitive_config`.",
+				Optional: true,
+			},
 			"cron_expression": schema.StringAttribute{
 				MarkdownDescription: "Cron expression setting how often the pipeline runs, for example " +
 					"`0 * * * *` for hourly.",
@@ -144,19 +169,105 @@ func (r *PipelineResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*marmot.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *marmot.Client, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.api = data.api
+	r.validateOnPlan = data.validateOnPlan
+}
+
+// ModifyPlan checks a new or changed config against its plugin when the
+// provider sets validate_on_plan, so an invalid config fails the plan
+// instead of the apply.
+func (r *PipelineResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !r.validateOnPlan || req.Plan.Raw.IsNull() {
+		return
+	}
+	ctx = withAPIErrorCapture(ctx)
+
+	var data PipelineResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.PluginID.IsUnknown() || data.Config.IsUnknown() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var state PipelineResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if data.PluginID.Equal(state.PluginID) && data.Config.Equal(state.Config) &&
+			data.SensitiveConfigVersion.Equal(state.SensitiveConfigVersion) {
+			return
+		}
+	}
+
+	var sensitiveMap types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sensitive_config"), &sensitiveMap)...)
+	if resp.Diagnostics.HasError() || sensitiveMap.IsUnknown() {
+		return
+	}
+	for _, v := range sensitiveMap.Elements() {
+		if v.IsUnknown() {
+			return
+		}
+	}
+
+	config, diags := scheduleConfig(data.Config)
+	resp.Diagnostics.Append(diags...)
+	sensitive, diags := r.sensitiveConfig(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config, diags = mergeSensitiveConfig(config, sensitive)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withSensitiveLogValues(ctx, sensitiveValues(sensitive)...)
+
+	result, err := r.api.validatePluginConfig(ctx, data.PluginID.ValueString(), config)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Validate Pipeline Config",
+			clientErrorDetail(ctx, "Unable to check the config with the plugin; it is checked again on apply", err),
+		)
+		return
+	}
+	if result.Valid {
+		return
+	}
+	if len(result.Errors) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("config"),
+			"Invalid Pipeline Config",
+			fmt.Sprintf("The %s plugin rejected the config.", data.PluginID.ValueString()),
+		)
+	}
+	for _, e := range result.Errors {
+		detail := e.Message
+		if e.Field != "" {
+			detail = e.Field + ": " + e.Message
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("config"),
+			"Invalid Pipeline Config",
+			fmt.Sprintf("The %s plugin rejected the config: %s", data.PluginID.ValueString(), detail),
+		)
+	}
 }
 
 func (r *PipelineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data PipelineResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -170,6 +281,18 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	sensitive, diags := r.sensitiveConfig(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config, diags = mergeSensitiveConfig(config, sensitive)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withSensitiveLogValues(ctx, sensitiveValues(sensitive)...)
+
 	schedule, err := r.client.Ingestion.CreateSchedule(ctx, marmot.CreateScheduleInput{
 		Name:           data.Name.ValueString(),
 		PluginID:       data.PluginID.ValueString(),
@@ -178,7 +301,7 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 		Enabled:        data.Enabled.ValueBool(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create pipeline: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to create pipeline", err)
 		return
 	}
 
@@ -188,6 +311,7 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	applyScheduleComputedFields(&data, schedule)
+	resp.Diagnostics.Append(setSensitiveKeys(ctx, resp.Private, sensitiveConfigPrivateKey, sensitive)...)
 
 	tflog.Info(ctx, "Pipeline created", map[string]any{
 		"id":   data.ID.ValueString(),
@@ -198,6 +322,8 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 }
 
 func (r *PipelineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data PipelineResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -211,10 +337,22 @@ func (r *PipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read pipeline: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to read pipeline", err)
 		return
 	}
 
+	// Keys sent through sensitive_config come back in the config; drop them
+	// so they neither reach state nor show up as drift.
+	sensitiveKeys, diags := recordedSensitiveKeys(ctx, req.Private, sensitiveConfigPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	configMap := convert.Object(schedule.Config)
+	for _, k := range sensitiveKeys {
+		delete(configMap, k)
+	}
+
 	resp.Diagnostics.Append(r.updateModelFromResponse(&data, schedule)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -224,6 +362,8 @@ func (r *PipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data PipelineResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -243,6 +383,18 @@ func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	sensitive, diags := r.sensitiveConfig(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config, diags = mergeSensitiveConfig(config, sensitive)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withSensitiveLogValues(ctx, sensitiveValues(sensitive)...)
+
 	schedule, err := r.client.Ingestion.UpdateSchedule(ctx, state.ID.ValueString(), marmot.UpdateScheduleInput{
 		Name:           data.Name.ValueString(),
 		PluginID:       data.PluginID.ValueString(),
@@ -251,11 +403,12 @@ func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateReques
 		Enabled:        data.Enabled.ValueBool(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update pipeline: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to update pipeline", err)
 		return
 	}
 
 	applyScheduleComputedFields(&data, schedule)
+	resp.Diagnostics.Append(setSensitiveKeys(ctx, resp.Private, sensitiveConfigPrivateKey, sensitive)...)
 
 	tflog.Info(ctx, "Pipeline updated", map[string]any{
 		"id":   data.ID.ValueString(),
@@ -266,6 +419,8 @@ func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateReques
 }
 
 func (r *PipelineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data PipelineResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -274,7 +429,7 @@ func (r *PipelineResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 
 	if err := r.client.Ingestion.DeleteSchedule(ctx, data.ID.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete pipeline: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to delete pipeline", err)
 		return
 	}
 
@@ -297,6 +452,51 @@ func scheduleConfig(config jsontypes.Normalized) (map[string]any, diag.Diagnosti
 	return out, diags
 }
 
+// sensitiveConfigPrivateKey is the private state key holding the names of
+// the config keys that were sent through sensitive_config.
+const sensitiveConfigPrivateKey = "sensitive_config_keys"
+
+// sensitiveConfig reads sensitive_config from the config. Write-only values
+// are always null in the plan and state.
+func (r *PipelineResource) sensitiveConfig(ctx context.Context, config tfsdk.Config) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var tfMap types.Map
+	diags.Append(config.GetAttribute(ctx, path.Root("sensitive_config"), &tfMap)...)
+	if diags.HasError() || tfMap.IsNull() || tfMap.IsUnknown() {
+		return nil, diags
+	}
+
+	result := make(map[string]string, len(tfMap.Elements()))
+	diags.Append(tfMap.ElementsAs(ctx, &result, false)...)
+	return result, diags
+}
+
+// mergeSensitiveConfig adds the sensitive values to the request config,
+// refusing keys that are already set through config.
+func mergeSensitiveConfig(config map[string]any, sensitive map[string]string) (map[string]any, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if len(sensitive) == 0 {
+		return config, diags
+	}
+
+	if config == nil {
+		config = make(map[string]any, len(sensitive))
+	}
+	for k, v := range sensitive {
+		if _, ok := config[k]; ok {
+			diags.AddAttributeError(
+				path.Root("sensitive_config").AtMapKey(k),
+				"Duplicate Config Key",
+				fmt.Sprintf("The key %q is set in both config and sensitive_config. Set it in only one of them.", k),
+			)
+			continue
+		}
+		config[k] = v
+	}
+	return config, diags
+}
+
 // applyScheduleComputedFields copies the server-generated (read-only) attributes
 // from an API response onto the model, leaving every configured attribute
 // untouched. The configured `config` is kept as written so a plan-time equal
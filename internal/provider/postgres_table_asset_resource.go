@@ -0,0 +1,469 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PostgresTableAssetResource{}
+var _ resource.ResourceWithImportState = &PostgresTableAssetResource{}
+
+// The asset type, service, and field names the PostgreSQL ingestion plugin
+// uses, so tables declared here look the same as scanned ones. Scanned
+// tables are named <database>.<schema>.<table>.
+const (
+	postgresTableAssetType = "Table"
+	postgresService        = "PostgreSQL"
+
+	postgresMetaDatabase = "database"
+	postgresMetaSchema   = "schema"
+	postgresMetaTable    = "table_name"
+
+	postgresSchemaColumns = "columns"
+)
+
+func NewPostgresTableAssetResource() resource.Resource {
+	return &PostgresTableAssetResource{}
+}
+
+// PostgresTableAssetResource defines the resource implementation.
+type PostgresTableAssetResource struct {
+	client          *marmot.Client
+	ignoreLabelCase bool
+	uiBaseURL       string
+}
+
+// PostgresTableAssetResourceModel describes the PostgreSQL table asset
+// resource data model.
+type PostgresTableAssetResourceModel struct {
+	Database    types.String          `tfsdk:"database"`
+	Schema      types.String          `tfsdk:"schema"`
+	Table       types.String          `tfsdk:"table"`
+	Columns     []PostgresColumnModel `tfsdk:"columns"`
+	Description markdownValue         `tfsdk:"description"`
+	Tags        types.Set             `tfsdk:"tags"`
+
+	ID  types.String `tfsdk:"id"`
+	MRN types.String `tfsdk:"mrn"`
+	URL types.String `tfsdk:"url"`
+}
+
+// PostgresColumnModel describes a table column.
+type PostgresColumnModel struct {
+	Name        types.String `tfsdk:"name"`
+	DataType    types.String `tfsdk:"data_type"`
+	Nullable    types.Bool   `tfsdk:"nullable"`
+	PrimaryKey  types.Bool   `tfsdk:"primary_key"`
+	Description types.String `tfsdk:"description"`
+}
+
+// postgresColumn is a column as stored in the asset's columns schema field.
+type postgresColumn struct {
+	Name        string `json:"name"`
+	DataType    string `json:"data_type"`
+	IsNullable  *bool  `json:"is_nullable,omitempty"`
+	PrimaryKey  *bool  `json:"is_primary_key,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+func (r *PostgresTableAssetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_postgres_table_asset"
+}
+
+func (r *PostgresTableAssetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	identity := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			MarkdownDescription: description,
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Registers a PostgreSQL table as a Marmot asset, with the asset type, " +
+			"service, name, and metadata keys the PostgreSQL ingestion plugin uses. Application " +
+			"teams can pre-register the tables their migrations create, and they look the same " +
+			"as scanned tables once the scanner runs. The asset's MRN is " +
+			"`mrn://table/postgresql/<database>.<schema>.<table>`.\n\n" +
+			"Attributes left unset keep whatever value ingestion wrote. A table that ingestion " +
+			"already registered can be brought under Terraform with `terraform import`.",
+
+		Attributes: map[string]schema.Attribute{
+			"database": identity("Database the table is in"),
+			"schema":   identity("Schema the table is in, such as `public`"),
+			"table":    identity("Table name"),
+			"columns": schema.ListNestedAttribute{
+				MarkdownDescription: "Columns of the table, in order",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Column name",
+							Required:            true,
+						},
+						"data_type": schema.StringAttribute{
+							MarkdownDescription: "PostgreSQL data type, such as `bigint` or `timestamp with time zone`",
+							Required:            true,
+						},
+						"nullable": schema.BoolAttribute{
+							MarkdownDescription: "Whether the column accepts nulls",
+							Optional:            true,
+						},
+						"primary_key": schema.BoolAttribute{
+							MarkdownDescription: "Whether the column is part of the primary key",
+							Optional:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Column description",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Markdown description of the table",
+				Optional:            true,
+				CustomType:          markdownType{},
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Tags for the table",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Asset ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mrn": schema.StringAttribute{
+				MarkdownDescription: "Marmot Resource Name",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "Link to the table in the Marmot UI",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *PostgresTableAssetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.ignoreLabelCase = data.ignoreLabelCase
+	r.uiBaseURL = data.uiBaseURL
+}
+
+func (r *PostgresTableAssetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data PostgresTableAssetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schemas := postgresTableSchema(data, &resp.Diagnostics)
+	tags := setStrings(ctx, data.Tags, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, err := r.client.Assets.Create(ctx, marmot.CreateAssetInput{
+		Name:        postgresTableName(data),
+		Type:        postgresTableAssetType,
+		Providers:   []string{postgresService},
+		Description: data.Description.ValueString(),
+		Tags:        tags,
+		Metadata:    postgresTableMetadata(data),
+		Schema:      schemas,
+	})
+	if err != nil {
+		if isConflict(err) {
+			addTypedAssetExistsError(ctx, r.client, &resp.Diagnostics, "PostgreSQL table", marmot.LookupInput{
+				Type:    postgresTableAssetType,
+				Service: postgresService,
+				Name:    postgresTableName(data),
+			})
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to create table asset", err)
+		return
+	}
+
+	if asset.ID == "" {
+		resp.Diagnostics.AddError("API Error", "Asset created but no ID returned")
+		return
+	}
+
+	data.ID = types.StringValue(asset.ID)
+	data.MRN = types.StringValue(asset.Mrn)
+	data.URL = assetURL(r.uiBaseURL, asset.Mrn)
+
+	tflog.Info(ctx, "PostgreSQL table asset created", map[string]any{
+		"id":   data.ID.ValueString(),
+		"name": postgresTableName(data),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PostgresTableAssetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data PostgresTableAssetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, err := r.client.Assets.Get(ctx, data.ID.ValueString())
+	if err != nil {
+		if marmot.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to read table asset", err)
+		return
+	}
+
+	prior := data
+	data.ID = types.StringValue(asset.ID)
+	data.MRN = types.StringValue(asset.Mrn)
+	data.URL = assetURL(r.uiBaseURL, asset.Mrn)
+	data.Description, data.Tags = typedAssetDescriptionAndTags(ctx, asset, prior.Tags, r.ignoreLabelCase, &resp.Diagnostics)
+
+	// The metadata names the table; assets registered without it, such as by
+	// hand, are named <database>.<schema>.<table>.
+	meta := convert.Object(asset.Metadata)
+	nameParts := strings.SplitN(asset.Name, ".", 3)
+	for i, field := range []struct {
+		value *types.String
+		key   string
+	}{
+		{&data.Database, postgresMetaDatabase},
+		{&data.Schema, postgresMetaSchema},
+		{&data.Table, postgresMetaTable},
+	} {
+		if v := metadataString(meta, field.key); !v.IsNull() {
+			*field.value = v
+		} else if len(nameParts) == 3 {
+			*field.value = types.StringValue(nameParts[i])
+		}
+	}
+
+	data.Columns = postgresColumnsFromResponse(asset.Schema[postgresSchemaColumns], prior.Columns)
+
+	// An import starts from an ID alone and reads every attribute. Otherwise,
+	// optional attributes left out of the configuration stay unset, since
+	// updates leave their server values alone.
+	if !prior.Table.IsNull() {
+		if prior.Columns == nil {
+			data.Columns = nil
+		}
+		if prior.Description.IsNull() {
+			data.Description = prior.Description
+		}
+		if prior.Tags.IsNull() {
+			data.Tags = prior.Tags
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PostgresTableAssetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data, state PostgresTableAssetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schemas := postgresTableSchema(data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.client.Assets.Get(ctx, state.ID.ValueString())
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to read table asset", err)
+		return
+	}
+
+	input := typedAssetUpdate(ctx, current, data.Description, data.Tags, postgresTableMetadata(data), schemas, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, err := r.client.Assets.Update(ctx, state.ID.ValueString(), input)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to update table asset", err)
+		return
+	}
+
+	data.ID = types.StringValue(asset.ID)
+	data.MRN = types.StringValue(asset.Mrn)
+	data.URL = assetURL(r.uiBaseURL, asset.Mrn)
+
+	tflog.Info(ctx, "PostgreSQL table asset updated", map[string]any{
+		"id":   data.ID.ValueString(),
+		"name": postgresTableName(data),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PostgresTableAssetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data PostgresTableAssetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.Assets.Delete(ctx, data.ID.ValueString()); err != nil {
+		if marmot.IsNotFound(err) {
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to delete table asset", err)
+		return
+	}
+
+	tflog.Info(ctx, "PostgreSQL table asset deleted", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+}
+
+// ImportState imports an asset by its ID or its MRN.
+func (r *PostgresTableAssetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importAssetRef(ctx, r.client, req, resp)
+}
+
+// postgresTableName returns the asset name the scanner gives the table.
+func postgresTableName(data PostgresTableAssetResourceModel) string {
+	return data.Database.ValueString() + "." + data.Schema.ValueString() + "." + data.Table.ValueString()
+}
+
+func postgresTableMetadata(data PostgresTableAssetResourceModel) map[string]any {
+	return map[string]any{
+		postgresMetaDatabase: data.Database.ValueString(),
+		postgresMetaSchema:   data.Schema.ValueString(),
+		postgresMetaTable:    data.Table.ValueString(),
+	}
+}
+
+// postgresTableSchema returns the schema fields the configuration sets: the
+// columns as canonical JSON, when they are set.
+func postgresTableSchema(data PostgresTableAssetResourceModel, diags *diag.Diagnostics) map[string]string {
+	if data.Columns == nil {
+		return nil
+	}
+	doc, err := encodePostgresColumns(data.Columns)
+	if err != nil {
+		diags.AddAttributeError(path.Root("columns"), "Unable to Encode Columns", err.Error())
+		return nil
+	}
+	return map[string]string{postgresSchemaColumns: doc}
+}
+
+func encodePostgresColumns(columns []PostgresColumnModel) (string, error) {
+	out := make([]postgresColumn, 0, len(columns))
+	for _, c := range columns {
+		out = append(out, postgresColumn{
+			Name:        c.Name.ValueString(),
+			DataType:    c.DataType.ValueString(),
+			IsNullable:  c.Nullable.ValueBoolPointer(),
+			PrimaryKey:  c.PrimaryKey.ValueBoolPointer(),
+			Description: c.Description.ValueString(),
+		})
+	}
+	return encodeCanonicalJSON(out)
+}
+
+// postgresColumnsFromResponse decodes the columns schema field. When it
+// describes the same columns as prior, prior is returned as is, so
+// attributes left unset in the configuration stay unset.
+func postgresColumnsFromResponse(doc string, prior []PostgresColumnModel) []PostgresColumnModel {
+	if doc == "" {
+		return nil
+	}
+	var columns []postgresColumn
+	if err := json.Unmarshal([]byte(doc), &columns); err != nil {
+		return prior
+	}
+	if prior != nil {
+		if priorDoc, err := encodePostgresColumns(prior); err == nil {
+			if serverDoc, err := encodeCanonicalJSON(columns); err == nil && serverDoc == priorDoc {
+				return prior
+			}
+		}
+	}
+
+	out := make([]PostgresColumnModel, 0, len(columns))
+	for _, c := range columns {
+		column := PostgresColumnModel{
+			Name:        types.StringValue(c.Name),
+			DataType:    types.StringValue(c.DataType),
+			Nullable:    types.BoolPointerValue(c.IsNullable),
+			PrimaryKey:  types.BoolPointerValue(c.PrimaryKey),
+			Description: types.StringNull(),
+		}
+		if c.Description != "" {
+			column.Description = types.StringValue(c.Description)
+		}
+		out = append(out, column)
+	}
+	return out
+}
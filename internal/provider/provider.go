@@ -5,7 +5,18 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -26,6 +37,7 @@ var (
 	_ provider.ProviderWithFunctions          = &MarmotProvider{}
 	_ provider.ProviderWithEphemeralResources = &MarmotProvider{}
 	_ provider.ProviderWithActions            = &MarmotProvider{}
+	_ provider.ProviderWithValidateConfig     = &MarmotProvider{}
 )
 
 type MarmotProvider struct {
@@ -33,9 +45,40 @@ type MarmotProvider struct {
 }
 
 type MarmotProviderModel struct {
-	Host   types.String `tfsdk:"host"`
-	APIKey types.String `tfsdk:"api_key"`
-	Token  types.String `tfsdk:"token"`
+	Host     types.String `tfsdk:"host"`
+	BasePath types.String `tfsdk:"base_path"`
+	APIKey   types.String `tfsdk:"api_key"`
+	Token    types.String `tfsdk:"token"`
+
+	APIKeySecondary types.String `tfsdk:"api_key_secondary"`
+
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Int64 `tfsdk:"requests_per_second"`
+
+	MaxRetries           types.Int64 `tfsdk:"max_retries"`
+	RetryStatusCodes     types.Set   `tfsdk:"retry_status_codes"`
+	RetryErrorSubstrings types.Set   `tfsdk:"retry_error_substrings"`
+
+	MaxIdleConns    types.Int64  `tfsdk:"max_idle_conns"`
+	IdleConnTimeout types.String `tfsdk:"idle_conn_timeout"`
+
+	Headers         types.Map    `tfsdk:"headers"`
+	ProxyURL        types.String `tfsdk:"proxy_url"`
+	SkipHealthCheck types.Bool   `tfsdk:"skip_health_check"`
+
+	UserAgentComment types.String `tfsdk:"user_agent_comment"`
+	ChangeReason     types.String `tfsdk:"change_reason"`
+	RunMetadata      types.Map    `tfsdk:"run_metadata"`
+
+	IgnoreLabelCase         types.Bool `tfsdk:"ignore_label_case"`
+	FailOnUnknownFields     types.Bool `tfsdk:"fail_on_unknown_fields"`
+	FailOnUnknownEnumValues types.Bool `tfsdk:"fail_on_unknown_enum_values"`
+
+	DefaultEnvironments types.Map `tfsdk:"default_environments"`
+
+	ReadOnly           types.Bool `tfsdk:"read_only"`
+	ValidateOnPlan     types.Bool `tfsdk:"validate_on_plan"`
+	CheckExternalLinks types.Bool `tfsdk:"check_external_links"`
 }
 
 func New(version string) func() provider.Provider {
@@ -61,10 +104,21 @@ func (p *MarmotProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 			"they describe.",
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				MarkdownDescription: "Marmot API host URL. May also be set via the `MARMOT_HOST` " +
-					"environment variable.",
+				MarkdownDescription: "Marmot API host URL, such as `https://marmot.example.com`. " +
+					"It may include a port and a path prefix, such as " +
+					"`https://marmot.example.com:8443/catalog`, which is added in front of " +
+					"`base_path`. May also be set via the `MARMOT_HOST` environment variable.",
 				Optional: true,
 			},
+			"base_path": schema.StringAttribute{
+				MarkdownDescription: "Path prefix of the Marmot API on `host`. Defaults to `/api/v1`. " +
+					"Set it when Marmot is mounted under another path behind a gateway, such as " +
+					"`/marmot/api/v1`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^(/[^/]+)+/?$`), "must be a path such as /api/v1"),
+				},
+			},
 			"api_key": schema.StringAttribute{
 				MarkdownDescription: "The provider authenticates with a Marmot API key, set through " +
 					"the `api_key` attribute or the `MARMOT_API_KEY` environment variable.",
@@ -74,6 +128,15 @@ func (p *MarmotProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 					stringvalidator.ConflictsWith(path.MatchRoot("token")),
 				},
 			},
+			"api_key_secondary": schema.StringAttribute{
+				MarkdownDescription: "A second Marmot API key, tried whenever the primary credential " +
+					"is rejected with a 401 and used for the rest of the run once it is accepted. " +
+					"Set it to the new key while rotating `api_key`, so runs keep working until " +
+					"the old key is revoked. May also be set via the `MARMOT_API_KEY_SECONDARY` " +
+					"environment variable.",
+				Optional:  true,
+				Sensitive: true,
+			},
 			"token": schema.StringAttribute{
 				MarkdownDescription: "Marmot bearer token. May also be set via the `MARMOT_TOKEN` " +
 					"environment variable. Conflicts with `api_key`.",
@@ -83,10 +146,270 @@ func (p *MarmotProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 					stringvalidator.ConflictsWith(path.MatchRoot("api_key")),
 				},
 			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of API requests the provider keeps in flight at " +
+					"once. Unset means no limit beyond Terraform's own `-parallelism`. Lower this if " +
+					"large applies overwhelm the Marmot server.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of API requests per second sent to `host`. " +
+					"Requests over the limit wait for a free slot rather than failing. Unset means " +
+					"no limit.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "How often a failed API request is retried, with exponential " +
+					"backoff from one second up to 30 seconds and any `Retry-After` the server sends. " +
+					"Defaults to `5`; `0` disables retries. Every request is retried on `429` and " +
+					"`503` and when no connection could be opened. `502`, `504`, and dropped " +
+					"connections could come after Marmot acted, so they only retry requests that " +
+					"are safe to repeat, not `POST` or `PATCH`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 20),
+				},
+			},
+			"retry_status_codes": schema.SetAttribute{
+				MarkdownDescription: "HTTP status codes retried in addition to the built-in ones, such " +
+					"as `[502, 520]` for a gateway that returns them while Marmot restarts. List only " +
+					"codes returned for requests that never reached Marmot: they retry every " +
+					"request, including `POST`.",
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Validators: []validator.Set{
+					setvalidator.ValueInt64sAre(int64validator.Between(400, 599)),
+				},
+			},
+			"retry_error_substrings": schema.SetAttribute{
+				MarkdownDescription: "Text that marks a failure as retryable when it appears, ignoring " +
+					"case, in an error response body or a connection error, such as " +
+					"`upstream connect error`. Like `retry_status_codes`, matches retry every request.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				MarkdownDescription: "Number of idle keep-alive connections kept open to `host` for " +
+					"reuse. Defaults to `16`. Raise it with `max_concurrent_requests` or a high " +
+					"`-parallelism` so requests reuse connections instead of opening new ones.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"idle_conn_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long an idle connection is kept open, as a duration such " +
+					"as `90s` or `5m`. Defaults to `90s`. Provider configurations with the same " +
+					"connection settings, such as aliases for several catalogs, share one pool.",
+				Optional: true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"headers": schema.MapAttribute{
+				MarkdownDescription: "Extra HTTP headers sent with every request, such as a tenant ID " +
+					"or `Proxy-Authorization` for a proxy in front of Marmot. They can't set " +
+					"`Authorization` or `X-API-Key`; use `api_key` or `token` for those.",
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
+			"user_agent_comment": schema.StringAttribute{
+				MarkdownDescription: "Text appended to the `User-Agent` header, which is otherwise " +
+					"`terraform-provider-marmot/<version> terraform/<version>`. Set it to a pipeline or " +
+					"repository name, such as `ci/analytics-infra`, so the server's audit logs show " +
+					"where catalog changes came from.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[\x20-\x7e]+$`), "must be printable ASCII"),
+				},
+			},
+			"change_reason": schema.StringAttribute{
+				MarkdownDescription: "Why this run changes the catalog, such as a ticket or pull " +
+					"request reference. Sent as the `X-Marmot-Change-Reason` header with every " +
+					"create, update, and delete request so it can be recorded in Marmot's audit " +
+					"log. May also be set via the `MARMOT_CHANGE_REASON` environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^\x00-\x1f\x7f]+$`), "must not contain control characters such as newlines"),
+				},
+			},
+			"run_metadata": schema.MapAttribute{
+				MarkdownDescription: "Key-value pairs describing the run, such as the Terraform Cloud " +
+					"run ID or the VCS commit. Sent form-encoded as the `X-Marmot-Run-Metadata` " +
+					"header with every create, update, and delete request.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of an HTTP, HTTPS, or SOCKS5 proxy to send requests through, " +
+					"such as `http://proxy.example.com:3128`. Overrides `HTTPS_PROXY` and `HTTP_PROXY`; " +
+					"hosts listed in `NO_PROXY` still bypass it. When unset, the proxy environment " +
+					"variables are used.",
+				Optional: true,
+			},
+			"skip_health_check": schema.BoolAttribute{
+				MarkdownDescription: "Skip the authenticated request the provider makes on start-up " +
+					"to check that `host` is reachable and the credentials are accepted. Defaults " +
+					"to `false`.",
+				Optional: true,
+			},
+			"ignore_label_case": schema.BoolAttribute{
+				MarkdownDescription: "Treat asset `services` and `tags` that Marmot returns in a " +
+					"different case from the configuration, such as `kafka` for `Kafka`, as " +
+					"unchanged. Defaults to `true`. Set to `false` to report case changes as drift.",
+				Optional: true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Refuse every request that would change the catalog, so the " +
+					"configuration can be planned against a production catalog by an audit-only " +
+					"pipeline. Creates, updates, deletes, and actions fail before anything is sent, " +
+					"and expired `marmot_lineage` edges are reported instead of planned for deletion. " +
+					"Defaults to `false`. " +
+					"May also be set via the `MARMOT_READ_ONLY` environment variable.",
+				Optional: true,
+			},
+			"validate_on_plan": schema.BoolAttribute{
+				MarkdownDescription: "Check configuration with Marmot's own validation while planning, " +
+					"so errors show up in `terraform plan` rather than partway through an apply. " +
+					"`marmot_pipeline` configs are checked against their plugin and " +
+					"`marmot_data_product_rule` expressions are previewed; nothing is saved. Only " +
+					"new and changed resources are checked, and a check that can't run only warns. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+			"check_external_links": schema.BoolAttribute{
+				MarkdownDescription: "After `marmot_asset` is created or updated, request each of its " +
+					"`external_links` and warn about any that can't be reached or return an error " +
+					"status, so dead dashboard links are caught when they are added. The asset is " +
+					"still saved. Links are requested directly, without Marmot credentials, " +
+					"`headers`, or `proxy_url`, though the standard `HTTPS_PROXY` environment " +
+					"variables apply. A `401` or `403` counts as reachable. Defaults to `false`.",
+				Optional: true,
+			},
+			"fail_on_unknown_fields": schema.BoolAttribute{
+				MarkdownDescription: "Fail requests whose responses carry fields this provider " +
+					"version doesn't know, typically because the Marmot server is newer. Such " +
+					"fields are dropped when read and can be lost when a resource writes the " +
+					"object back. Defaults to `false`, which logs each unknown field once as a " +
+					"warning (visible with `TF_LOG=WARN`).",
+				Optional: true,
+			},
+			"fail_on_unknown_enum_values": schema.BoolAttribute{
+				MarkdownDescription: "Fail when Marmot returns a value this provider version doesn't " +
+					"know for a field with a fixed set of values, typically because the server is " +
+					"newer. Today that is an owner of a `marmot_data_product` or `marmot_glossary_term` " +
+					"whose type is neither `team` nor `user`. Defaults to `false`, which warns about " +
+					"each such value and sends it back unchanged when the resource is updated.",
+				Optional: true,
+			},
+			"default_environments": schema.MapNestedAttribute{
+				MarkdownDescription: "Environments added to every `marmot_asset`, keyed like its " +
+					"`environments`. An asset that configures the same key keeps its own. `path` " +
+					"may use `{{name}}` and `{{type}}`, which are replaced with the asset's name and " +
+					"lowercased type, such as `prod-{{name}}`. Defaults don't appear in an asset's " +
+					"state or plans.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the environment",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(1, 255),
+							},
+						},
+						"path": schema.StringAttribute{
+							MarkdownDescription: "Path of the environment, which may use `{{name}}` and `{{type}}`",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(1, 500),
+							},
+						},
+						"metadata": schema.MapAttribute{
+							MarkdownDescription: "Metadata of the environment",
+							Optional:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
+// ValidateConfig catches settings that can't work together, or values that
+// can't be parsed, at validate time. Values that are unknown until apply are
+// checked again in Configure.
+func (p *MarmotProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var config MarmotProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !isEmptyString(config.Host) {
+		if _, _, err := parseHost(config.Host.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("host"),
+				"Invalid Host",
+				fmt.Sprintf("The host value %q is not a valid Marmot URL: %s.", config.Host.ValueString(), err),
+			)
+		}
+	}
+
+	if !isEmptyString(config.ProxyURL) {
+		if _, err := parseProxyURL(config.ProxyURL.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("proxy_url"), "Invalid Proxy URL", "The proxy_url value is invalid: "+err.Error()+".")
+		}
+	}
+
+	if !isEmptyString(config.APIKeySecondary) && config.APIKeySecondary.Equal(config.APIKey) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_key_secondary"),
+			"Duplicate API Key",
+			"api_key_secondary is the same key as api_key, so it can't help while rotating. "+
+				"Set it to the new key, or remove it.",
+		)
+	}
+
+	if !config.Headers.IsNull() && !config.Headers.IsUnknown() {
+		for name := range config.Headers.Elements() {
+			switch http.CanonicalHeaderKey(name) {
+			case "Authorization", "X-Api-Key":
+				resp.Diagnostics.AddAttributeError(
+					path.Root("headers").AtMapKey(name),
+					"Reserved Header",
+					fmt.Sprintf("The %s header carries the Marmot credential and can't be set through headers. "+
+						"Use the api_key or token attribute instead.", name),
+				)
+			}
+		}
+	}
+
+	maxIdle, maxConcurrent := config.MaxIdleConns, config.MaxConcurrentRequests
+	if !maxIdle.IsNull() && !maxIdle.IsUnknown() && !maxConcurrent.IsNull() && !maxConcurrent.IsUnknown() &&
+		maxIdle.ValueInt64() < maxConcurrent.ValueInt64() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("max_idle_conns"),
+			"Idle Connection Pool Smaller Than Concurrency",
+			fmt.Sprintf("max_idle_conns (%d) is lower than max_concurrent_requests (%d), so connections "+
+				"beyond the pool are closed after each request and reopened for the next one.",
+				maxIdle.ValueInt64(), maxConcurrent.ValueInt64()),
+		)
+	}
+}
+
 func (p *MarmotProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var config MarmotProviderModel
 
@@ -95,10 +418,151 @@ func (p *MarmotProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
+	var headers map[string]string
+	if !config.Headers.IsNull() && !config.Headers.IsUnknown() {
+		resp.Diagnostics.Append(config.Headers.ElementsAs(ctx, &headers, false)...)
+	}
+
+	changeHeader := http.Header{}
+	changeReason := config.ChangeReason.ValueString()
+	if changeReason == "" {
+		changeReason = os.Getenv("MARMOT_CHANGE_REASON")
+	}
+	if changeReason != "" {
+		changeHeader.Set("X-Marmot-Change-Reason", changeReason)
+	}
+	var runMetadata map[string]string
+	if !config.RunMetadata.IsNull() && !config.RunMetadata.IsUnknown() {
+		resp.Diagnostics.Append(config.RunMetadata.ElementsAs(ctx, &runMetadata, false)...)
+	}
+	if len(runMetadata) > 0 {
+		values := url.Values{}
+		for k, v := range runMetadata {
+			values.Set(k, v)
+		}
+		changeHeader.Set("X-Marmot-Run-Metadata", values.Encode())
+	}
+
+	var defaultEnvironments map[string]AssetEnvironmentModel
+	if !config.DefaultEnvironments.IsNull() && !config.DefaultEnvironments.IsUnknown() {
+		resp.Diagnostics.Append(config.DefaultEnvironments.ElementsAs(ctx, &defaultEnvironments, false)...)
+	}
+
+	// The duration is checked at validate time too, but not when it was
+	// unknown then, such as when it comes from another resource.
+	var idleConnTimeout time.Duration
+	if raw := config.IdleConnTimeout.ValueString(); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("idle_conn_timeout"),
+				"Invalid Duration",
+				fmt.Sprintf("%q is not a positive duration such as 30m or 1h30m.", raw),
+			)
+		}
+		idleConnTimeout = d
+	}
+
+	var proxyURL *url.URL
+	if raw := config.ProxyURL.ValueString(); raw != "" {
+		u, err := parseProxyURL(raw)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("proxy_url"), "Invalid Proxy URL", "The proxy_url value is invalid: "+err.Error()+".")
+		}
+		proxyURL = u
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	retry := retryOptions{MaxRetries: defaultMaxRetries}
+	if !config.MaxRetries.IsNull() {
+		retry.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	if !config.RetryStatusCodes.IsNull() && !config.RetryStatusCodes.IsUnknown() {
+		resp.Diagnostics.Append(config.RetryStatusCodes.ElementsAs(ctx, &retry.StatusCodes, false)...)
+	}
+	if !config.RetryErrorSubstrings.IsNull() && !config.RetryErrorSubstrings.IsUnknown() {
+		resp.Diagnostics.Append(config.RetryErrorSubstrings.ElementsAs(ctx, &retry.ErrorSubstrings, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secondaryKey := config.APIKeySecondary.ValueString()
+	if secondaryKey == "" {
+		secondaryKey = os.Getenv("MARMOT_API_KEY_SECONDARY")
+	}
+
+	readOnly := config.ReadOnly.ValueBool()
+	if config.ReadOnly.IsNull() {
+		if raw := os.Getenv("MARMOT_READ_ONLY"); raw != "" {
+			v, err := strconv.ParseBool(raw)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Invalid MARMOT_READ_ONLY",
+					fmt.Sprintf("The MARMOT_READ_ONLY value %q is not a boolean; use true or false.", raw),
+				)
+				return
+			}
+			readOnly = v
+		}
+	}
+
+	basePath := strings.TrimRight(config.BasePath.ValueString(), "/")
+	if basePath == "" {
+		basePath = marmot.DefaultBasePath
+	}
+
+	// A host from the marmot CLI's config file is left to the SDK.
+	host, hostAttr := config.Host.ValueString(), "host"
+	if host == "" {
+		host, hostAttr = os.Getenv("MARMOT_HOST"), "MARMOT_HOST"
+	}
+	if host != "" {
+		origin, prefix, err := parseHost(host)
+		if err != nil {
+			detail := fmt.Sprintf("The %s value %q is not a valid Marmot URL: %s.\n\n"+
+				"Expected a URL such as https://marmot.example.com, optionally with a port "+
+				"and a path prefix, such as https://marmot.example.com:8443/catalog.", hostAttr, host, err)
+			if hostAttr == "host" {
+				resp.Diagnostics.AddAttributeError(path.Root("host"), "Invalid Host", detail)
+			} else {
+				resp.Diagnostics.AddError("Invalid Host", detail)
+			}
+			return
+		}
+		host = origin
+		if !strings.HasSuffix(prefix, basePath) {
+			prefix += basePath
+		}
+		basePath = prefix
+	}
+
+	httpClient := newHTTPClient(transportOptions{
+		MaxConcurrentRequests: config.MaxConcurrentRequests.ValueInt64(),
+		RequestsPerSecond:     config.RequestsPerSecond.ValueInt64(),
+		Headers:               headers,
+		ProxyURL:              proxyURL,
+		MaxIdleConns:          int(config.MaxIdleConns.ValueInt64()),
+		IdleConnTimeout:       idleConnTimeout,
+		BasePath:              basePath,
+		FailOnUnknownFields:   config.FailOnUnknownFields.ValueBool(),
+		SecondaryAPIKey:       secondaryKey,
+		ChangeHeader:          changeHeader,
+		ReadOnly:              readOnly,
+		Retry:                 retry,
+	})
+
+	ua := userAgent(p.version, req.TerraformVersion, config.UserAgentComment.ValueString())
+
 	sdkClient, err := marmot.NewClient(marmot.ClientOptions{
-		Host:   config.Host.ValueString(),
-		APIKey: config.APIKey.ValueString(),
-		Token:  config.Token.ValueString(),
+		Host:       host,
+		BasePath:   basePath,
+		APIKey:     config.APIKey.ValueString(),
+		Token:      config.Token.ValueString(),
+		HTTPClient: httpClient,
+		UserAgent:  ua,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -111,35 +575,163 @@ func (p *MarmotProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	resp.ResourceData = sdkClient
-	resp.DataSourceData = sdkClient
+	if !config.SkipHealthCheck.ValueBool() {
+		resp.Diagnostics.Append(checkServerHealth(ctx, sdkClient)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data := &providerData{
+		client:          sdkClient,
+		api:             newAPIClient(httpClient, sdkClient.Host(), basePath, sdkClient.Credential(), ua),
+		assets:          newAssetLookup(sdkClient),
+		ignoreLabelCase: config.IgnoreLabelCase.IsNull() || config.IgnoreLabelCase.ValueBool(),
+		uiBaseURL:       uiBaseURL(sdkClient.Host(), basePath),
+
+		defaultEnvironments: defaultEnvironments,
+		readOnly:            readOnly,
+		validateOnPlan:      config.ValidateOnPlan.ValueBool(),
+		checkExternalLinks:  config.CheckExternalLinks.ValueBool(),
+		failOnUnknownEnums:  config.FailOnUnknownEnumValues.ValueBool(),
+	}
+	resp.ResourceData = data
+	resp.DataSourceData = data
 
 	tflog.Info(ctx, "Configured Marmot client", map[string]any{
 		"host":        sdkClient.Host(),
+		"base_path":   basePath,
 		"auth_source": sdkClient.Credential().Source(),
+		"read_only":   readOnly,
 	})
 }
 
+// userAgent returns the User-Agent header sent with every request, such as
+// "terraform-provider-marmot/1.2.0 terraform/1.9.5 (ci/analytics-infra)".
+func userAgent(providerVersion, terraformVersion, comment string) string {
+	if providerVersion == "" {
+		providerVersion = "dev"
+	}
+	ua := "terraform-provider-marmot/" + providerVersion
+	if terraformVersion != "" {
+		ua += " terraform/" + terraformVersion
+	}
+	if comment = strings.TrimSpace(comment); comment != "" {
+		ua += " (" + comment + ")"
+	}
+	return ua
+}
+
+// parseHost splits a host value such as "https://marmot.corp:8443/catalog/"
+// into its origin and path prefix. The SDK only uses the host and port of the
+// URL, so any path has to be moved into the base path. A value without a
+// scheme is taken as http, as the SDK does.
+func parseHost(raw string) (origin, prefix string, err error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return "", "", err
+	}
+	switch {
+	case u.Scheme != "http" && u.Scheme != "https":
+		return "", "", fmt.Errorf("unsupported scheme %q, use http or https", u.Scheme)
+	case u.Hostname() == "":
+		return "", "", errors.New("no host name")
+	case u.User != nil:
+		return "", "", errors.New("credentials in the URL aren't supported, use api_key or token")
+	case u.RawQuery != "" || u.Fragment != "":
+		return "", "", errors.New("query strings and fragments aren't supported")
+	}
+	if port := u.Port(); port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return "", "", fmt.Errorf("invalid port %q", port)
+		}
+	} else if strings.HasSuffix(u.Host, ":") {
+		return "", "", errors.New("empty port")
+	}
+	return u.Scheme + "://" + u.Host, strings.TrimRight(u.EscapedPath(), "/"), nil
+}
+
+// parseProxyURL parses a proxy_url value, which must be an http, https, or
+// socks5 URL with a host.
+func parseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") {
+		return nil, fmt.Errorf("expected an http://, https://, or socks5:// URL with a host, got: %s", raw)
+	}
+	return u, nil
+}
+
+// uiBaseURL returns where the Marmot UI is served. Marmot serves it from the
+// same host as the API, so a gateway prefix in front of /api/v1 applies to
+// both.
+func uiBaseURL(host, basePath string) string {
+	prefix, ok := strings.CutSuffix(basePath, marmot.DefaultBasePath)
+	if !ok {
+		prefix = ""
+	}
+	return strings.TrimRight(host, "/") + prefix
+}
+
 func (p *MarmotProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAssetResource,
+		NewAssetMetadataResource,
+		NewAssetColumnsResource,
+		NewAssetTagsResource,
+		NewKafkaTopicAssetResource,
+		NewS3BucketAssetResource,
+		NewPostgresTableAssetResource,
 		NewPipelineResource,
+		NewMetadataSyncRunResource,
 		NewLineageResource,
+		NewLineageBulkResource,
+		NewOpenLineageJobResource,
 		NewGlossaryResource,
+		NewGlossaryBulkResource,
 		NewTeamResource,
+		NewSSOTeamMappingResource,
 		NewUserResource,
 		NewDataProductResource,
 		NewDataProductRuleResource,
 		NewDataProductAssetResource,
+		NewRoleResource,
+		NewRoleAssignmentResource,
 	}
 }
 
 func (p *MarmotProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewAssetDataSource,
+		NewUserDataSource,
+		NewUsersDataSource,
+		NewRoleDataSource,
+		NewSearchDataSource,
+		NewLineagePathDataSource,
+		NewComplianceReportDataSource,
+		NewImportGeneratorDataSource,
+		NewDbtManifestDataSource,
+		NewTeamOwnershipReportDataSource,
+		NewGlossaryTermDataSource,
+	}
 }
 
 func (p *MarmotProvider) Functions(ctx context.Context) []func() function.Function {
-	return nil
+	return []func() function.Function{
+		NewNormalizeAvroFunction,
+		NewNormalizeJSONSchemaFunction,
+		NewNormalizeProtobufFunction,
+		NewNormalizeTagFunction,
+		NewMergeTagsFunction,
+		NewCanonicalServiceFunction,
+	}
 }
 
 func (p *MarmotProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
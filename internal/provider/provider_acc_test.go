@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build acceptance
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/acctest"
+)
+
+// testAccProtoV6ProviderFactories serves the provider under test to the
+// terraform binary that resource.Test runs.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"marmot": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testAccProviderConfig is the provider block of every acceptance test
+// configuration. acctest.PreCheck sets the host and credentials in the
+// environment, which the provider reads.
+const testAccProviderConfig = `
+provider "marmot" {}
+`
+
+// testAccStoreID records the ID of the resource at address in state, so a
+// later step can change or check it through the API.
+func testAccStoreID(address string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[address]
+		if !ok {
+			return fmt.Errorf("%s not found in state", address)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+// testAccDriftStep changes the resource out of band through the API before
+// planning cfg again. The step fails unless Terraform finds the drift, and
+// applying it must put the configured values back.
+func testAccDriftStep(t *testing.T, cfg string, change func(ctx context.Context, client *marmot.Client) error) resource.TestStep {
+	return resource.TestStep{
+		PreConfig: func() {
+			if err := change(t.Context(), acctest.Client(t)); err != nil {
+				t.Fatalf("changing resource out of band: %s", err)
+			}
+		},
+		Config: cfg,
+		ConfigPlanChecks: resource.ConfigPlanChecks{
+			PreApply: []plancheck.PlanCheck{plancheck.ExpectNonEmptyPlan()},
+		},
+	}
+}
+
+// testAccCheckDestroyed returns a CheckDestroy that fails while get still
+// finds any resource of resourceType in the last state.
+func testAccCheckDestroyed(t *testing.T, resourceType string, get func(ctx context.Context, client *marmot.Client, id string) error) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		client := acctest.Client(t)
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			err := get(t.Context(), client, rs.Primary.ID)
+			switch {
+			case err == nil:
+				return fmt.Errorf("%s %s still exists", resourceType, rs.Primary.ID)
+			case !marmot.IsNotFound(err):
+				return fmt.Errorf("checking %s %s was destroyed: %w", resourceType, rs.Primary.ID, err)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// defaultMaxRetries is how often a failed request is retried when
+	// max_retries is unset.
+	defaultMaxRetries = 5
+
+	// retryBaseDelay is the wait before the first retry. Each later retry
+	// waits twice as long, up to retryMaxDelay.
+	retryBaseDelay = time.Second
+	retryMaxDelay  = 30 * time.Second
+)
+
+// retryOptions configures retryTransport.
+type retryOptions struct {
+	// MaxRetries is how often a request is retried after its first
+	// attempt. Zero disables retries.
+	MaxRetries int
+
+	// StatusCodes and ErrorSubstrings add to the built-in retryable
+	// failures. They mark failures the server can't have processed, so
+	// they retry every method.
+	StatusCodes     []int
+	ErrorSubstrings []string
+}
+
+// retryTransport retries requests that fail while Marmot is restarting or
+// overloaded, with exponential backoff and jitter, honouring Retry-After.
+//
+// Requests are only repeated when that is safe. 429 and 503 responses and
+// connections that could not be opened mean the request was never
+// processed, so every method is retried for them. Other gateway errors and
+// broken connections may come after the server acted, so they only retry
+// methods that are safe to repeat, not POST or PATCH.
+type retryTransport struct {
+	base http.RoundTripper
+	opts retryOptions
+
+	// substrings are opts.ErrorSubstrings, lowercased.
+	substrings []string
+}
+
+// noRetryKey marks a context whose requests retryTransport sends only once.
+type noRetryKey struct{}
+
+// withoutRetries returns a context under which requests aren't retried, for
+// checks that should fail fast rather than wait out the backoff.
+func withoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+func newRetryTransport(base http.RoundTripper, opts retryOptions) *retryTransport {
+	substrings := make([]string, 0, len(opts.ErrorSubstrings))
+	for _, s := range opts.ErrorSubstrings {
+		if s != "" {
+			substrings = append(substrings, strings.ToLower(s))
+		}
+	}
+	return &retryTransport{base: base, opts: opts, substrings: substrings}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A request whose body can't be replayed is sent once, as is one whose
+	// context was marked by withoutRetries.
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	if noRetry, _ := req.Context().Value(noRetryKey{}).(bool); noRetry {
+		replayable = false
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if attempt >= t.opts.MaxRetries || !replayable || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		retry, reason := t.shouldRetry(req.Method, resp, err)
+		if !retry {
+			return resp, err
+		}
+
+		delay := backoff(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+				delay = after
+			}
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBodyBytes))
+			resp.Body.Close()
+		}
+
+		tflog.Warn(req.Context(), "Retrying Marmot API request", map[string]interface{}{
+			"http_method": req.Method,
+			"http_path":   req.URL.Path,
+			"reason":      reason,
+			"attempt":     attempt + 1,
+			"max_retries": t.opts.MaxRetries,
+			"delay":       delay.String(),
+		})
+		if err := sleepContext(req, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// shouldRetry reports whether a failed attempt is retried, and why. When
+// the response body is read to match it, it is restored for the caller.
+func (t *retryTransport) shouldRetry(method string, resp *http.Response, err error) (bool, string) {
+	if err != nil {
+		reason := err.Error()
+		switch {
+		case t.matchesSubstring(reason):
+			return true, reason
+		case isDialError(err):
+			return true, reason
+		case isNetworkError(err) && isIdempotent(method):
+			return true, reason
+		}
+		return false, ""
+	}
+
+	if resp.StatusCode < 400 {
+		return false, ""
+	}
+	reason := resp.Status
+	for _, code := range t.opts.StatusCodes {
+		if resp.StatusCode == code {
+			return true, reason
+		}
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true, reason
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		if isIdempotent(method) {
+			return true, reason
+		}
+	}
+
+	if len(t.substrings) == 0 {
+		return false, ""
+	}
+	body, readErr := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	rest := resp.Body
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), rest), rest}
+	if readErr == nil && t.matchesSubstring(string(body)) {
+		return true, reason
+	}
+	return false, ""
+}
+
+func (t *retryTransport) matchesSubstring(s string) bool {
+	s = strings.ToLower(s)
+	for _, sub := range t.substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// isIdempotent reports whether a request with this method can be repeated
+// without changing the outcome.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isNetworkError reports whether err is a connection failure, rather than
+// an error from one of the provider's own transports.
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isDialError reports whether err means no connection could be opened, so
+// the request was never sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// backoff returns the wait before retry attempt+1: retryBaseDelay doubled
+// for each earlier retry, capped at retryMaxDelay, less up to a quarter at
+// random so parallel requests don't retry in lockstep.
+func backoff(attempt int) time.Duration {
+	d := retryMaxDelay
+	if attempt < 16 {
+		d = min(retryBaseDelay<<attempt, retryMaxDelay)
+	}
+	return d - time.Duration(rand.Int63n(int64(d/4)+1))
+}
+
+// retryAfter parses a Retry-After header, given in seconds or as an HTTP
+// date, capped at retryMaxDelay.
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	var d time.Duration
+	if secs, err := strconv.Atoi(v); err == nil {
+		d = time.Duration(secs) * time.Second
+	} else if at, err := http.ParseTime(v); err == nil {
+		d = time.Until(at)
+	} else {
+		return 0, false
+	}
+	return max(0, min(d, retryMaxDelay)), true
+}
+
+// sleepContext waits for d, returning early with the context's error if the
+// request is cancelled.
+func sleepContext(req *http.Request, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{attempt: 0, max: time.Second},
+		{attempt: 1, max: 2 * time.Second},
+		{attempt: 3, max: 8 * time.Second},
+		{attempt: 5, max: retryMaxDelay},
+		{attempt: 16, max: retryMaxDelay},
+		{attempt: 100, max: retryMaxDelay},
+	}
+
+	for _, tt := range tests {
+		for range 50 {
+			got := backoff(tt.attempt)
+			if got > tt.max || got < tt.max-tt.max/4 {
+				t.Fatalf("backoff(%d) = %s, want between %s and %s", tt.attempt, got, tt.max-tt.max/4, tt.max)
+			}
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := map[string]struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		"unset":          {value: "", wantOK: false},
+		"seconds":        {value: "3", want: 3 * time.Second, wantOK: true},
+		"zero":           {value: "0", want: 0, wantOK: true},
+		"negative":       {value: "-5", want: 0, wantOK: true},
+		"capped":         {value: "3600", want: retryMaxDelay, wantOK: true},
+		"past date":      {value: "Mon, 02 Jan 2006 15:04:05 GMT", want: 0, wantOK: true},
+		"far future":     {value: time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), want: retryMaxDelay, wantOK: true},
+		"not a duration": {value: "soon", wantOK: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := retryAfter(tt.value)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("retryAfter(%q) = %s, %t, want %s, %t", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRetryAfterDate(t *testing.T) {
+	got, ok := retryAfter(time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat))
+	if !ok || got <= 8*time.Second || got > 10*time.Second {
+		t.Errorf("retryAfter(date in 10s) = %s, %t, want about 10s", got, ok)
+	}
+}
+
+func TestRetryTransportShouldRetry(t *testing.T) {
+	rt := newRetryTransport(nil, retryOptions{MaxRetries: 3, StatusCodes: []int{520}})
+
+	tests := map[string]struct {
+		method string
+		status int
+		want   bool
+	}{
+		"get ok":            {method: http.MethodGet, status: 200, want: false},
+		"get not found":     {method: http.MethodGet, status: 404, want: false},
+		"post rate limited": {method: http.MethodPost, status: 429, want: true},
+		"post unavailable":  {method: http.MethodPost, status: 503, want: true},
+		"get bad gateway":   {method: http.MethodGet, status: 502, want: true},
+		"post bad gateway":  {method: http.MethodPost, status: 502, want: false},
+		"patch timeout":     {method: http.MethodPatch, status: 504, want: false},
+		"delete timeout":    {method: http.MethodDelete, status: 504, want: true},
+		"post internal":     {method: http.MethodPost, status: 500, want: false},
+		"post configured":   {method: http.MethodPost, status: 520, want: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Status: http.StatusText(tt.status), Body: http.NoBody}
+			if got, _ := rt.shouldRetry(tt.method, resp, nil); got != tt.want {
+				t.Errorf("shouldRetry(%s, %d) = %t, want %t", tt.method, tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRetryTransportWithoutRetries(t *testing.T) {
+	attempts := 0
+	rt := newRetryTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		attempts++
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	}), retryOptions{MaxRetries: defaultMaxRetries})
+
+	req, err := http.NewRequestWithContext(withoutRetries(t.Context()), http.MethodGet, "http://marmot.invalid/api/v1/users/me", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rt.RoundTrip(req); !isDialError(err) {
+		t.Fatalf("got error %v, want the dial error", err)
+	}
+	if attempts != 1 {
+		t.Errorf("got %d attempts, want 1", attempts)
+	}
+}
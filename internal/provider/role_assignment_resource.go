@@ -0,0 +1,241 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoleAssignmentResource{}
+var _ resource.ResourceWithImportState = &RoleAssignmentResource{}
+
+func NewRoleAssignmentResource() resource.Resource {
+	return &RoleAssignmentResource{}
+}
+
+// RoleAssignmentResource defines the resource implementation.
+type RoleAssignmentResource struct {
+	client *marmot.Client
+}
+
+// RoleAssignmentResourceModel describes the role assignment resource data model.
+type RoleAssignmentResourceModel struct {
+	UserID   types.String `tfsdk:"user_id"`
+	RoleName types.String `tfsdk:"role_name"`
+}
+
+// userRoleLocks serialises role changes per user. Marmot only updates a
+// user's roles as a whole list, so two assignments for the same user applied
+// in parallel would otherwise overwrite each other.
+var userRoleLocks sync.Map
+
+func lockUserRoles(userID string) func() {
+	mu, _ := userRoleLocks.LoadOrStore(userID, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+func (r *RoleAssignmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_assignment"
+}
+
+func (r *RoleAssignmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Grants a role to a user. Marmot roles apply across the whole " +
+			"instance, so an assignment has no narrower scope.\n\n" +
+			"Don't combine this with `role_names` on the same `marmot_user`; the two will " +
+			"keep undoing each other's changes.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the user",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the role to grant, e.g. `marmot_role.x.name` or a " +
+					"built-in role such as `admin`",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoleAssignmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *RoleAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data RoleAssignmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID, roleName := data.UserID.ValueString(), data.RoleName.ValueString()
+	if err := r.setUserRole(ctx, userID, roleName, true); err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to assign role", err)
+		return
+	}
+
+	tflog.Info(ctx, "Role assigned", map[string]any{
+		"user_id":   userID,
+		"role_name": roleName,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data RoleAssignmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.client.Users.Get(ctx, data.UserID.ValueString())
+	if err != nil {
+		if marmot.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to read user", err)
+		return
+	}
+
+	if !slices.Contains(roleNamesOf(user), data.RoleName.ValueString()) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError(
+		"Update Not Supported",
+		"Role assignments cannot be updated. Changes to user_id or role_name require replacement.",
+	)
+}
+
+func (r *RoleAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data RoleAssignmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID, roleName := data.UserID.ValueString(), data.RoleName.ValueString()
+	if err := r.setUserRole(ctx, userID, roleName, false); err != nil {
+		if marmot.IsNotFound(err) {
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to remove role assignment", err)
+		return
+	}
+
+	tflog.Info(ctx, "Role assignment removed", map[string]any{
+		"user_id":   userID,
+		"role_name": roleName,
+	})
+}
+
+// ImportState imports an assignment with the composite ID "<user_id>/<role_name>".
+func (r *RoleAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	userID, roleName, ok := strings.Cut(req.ID, "/")
+	if !ok || userID == "" || roleName == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the format 'user_id/role_name', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_name"), roleName)...)
+}
+
+// setUserRole adds or removes roleName on the user, leaving their other
+// roles and profile fields as they are.
+func (r *RoleAssignmentResource) setUserRole(ctx context.Context, userID, roleName string, assigned bool) error {
+	defer lockUserRoles(userID)()
+
+	user, err := r.client.Users.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	roles := roleNamesOf(user)
+	has := slices.Contains(roles, roleName)
+	switch {
+	case assigned && has, !assigned && !has:
+		return nil
+	case assigned:
+		roles = append(roles, roleName)
+		sort.Strings(roles)
+	default:
+		roles = slices.DeleteFunc(roles, func(name string) bool { return name == roleName })
+	}
+
+	_, err = r.client.Users.Update(ctx, userID, marmot.UpdateUserInput{
+		Name:           user.Name,
+		RoleNames:      roles,
+		ProfilePicture: user.ProfilePicture,
+	})
+	return err
+}
+
+// roleNamesOf returns the sorted names of the user's roles.
+func roleNamesOf(user *marmot.User) []string {
+	names := make([]string, 0, len(user.Roles))
+	for _, role := range user.Roles {
+		if role != nil {
+			names = append(names, role.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
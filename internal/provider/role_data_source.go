@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RoleDataSource{}
+
+func NewRoleDataSource() datasource.DataSource {
+	return &RoleDataSource{}
+}
+
+// RoleDataSource defines the data source implementation.
+type RoleDataSource struct {
+	api *apiClient
+}
+
+// RoleDataSourceModel describes the role data source data model.
+type RoleDataSourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	ID          types.String `tfsdk:"id"`
+	Description types.String `tfsdk:"description"`
+	Permissions types.Set    `tfsdk:"permissions"`
+}
+
+func (d *RoleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+func (d *RoleDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a Marmot role by name, including the built-in roles such as " +
+			"`admin` that aren't managed by Terraform.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the role",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Role ID",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the role",
+				Computed:            true,
+			},
+			"permissions": schema.SetAttribute{
+				MarkdownDescription: "Names of the permissions granted by the role",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *RoleDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.api = data.api
+}
+
+func (d *RoleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data RoleDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roles, err := d.api.listRoles(ctx)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to list roles", err)
+		return
+	}
+
+	var found *role
+	for _, r := range roles {
+		if r != nil && r.Name == data.Name.ValueString() {
+			found = r
+			break
+		}
+	}
+	if found == nil {
+		resp.Diagnostics.AddError("Role Not Found", fmt.Sprintf("No Marmot role is named %q.", data.Name.ValueString()))
+		return
+	}
+
+	data.ID = types.StringValue(found.ID)
+	data.Description = types.StringValue(found.Description)
+
+	permissions, diags := types.SetValueFrom(ctx, types.StringType, found.permissionNames())
+	resp.Diagnostics.Append(diags...)
+	data.Permissions = permissions
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,279 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoleResource{}
+var _ resource.ResourceWithImportState = &RoleResource{}
+
+func NewRoleResource() resource.Resource {
+	return &RoleResource{}
+}
+
+// RoleResource defines the resource implementation.
+type RoleResource struct {
+	api *apiClient
+}
+
+// RoleResourceModel describes the role resource data model.
+type RoleResourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Permissions types.Set    `tfsdk:"permissions"`
+	ID          types.String `tfsdk:"id"`
+}
+
+func (r *RoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A role in Marmot: a named set of permissions. Assign it to users with " +
+			"`marmot_role_assignment`, or list it in a user's `role_names`.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the role",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 255),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the role",
+				Optional:            true,
+			},
+			"permissions": schema.SetAttribute{
+				MarkdownDescription: "Names of the permissions granted by the role, such as " +
+					"`assets:read`. Names are checked against the server's permission list on apply.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Role ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = data.api
+}
+
+func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data RoleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	permissionIDs, err := r.api.permissionIDs(ctx, rolePermissions(ctx, data.Permissions, &resp.Diagnostics))
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to resolve role permissions", err)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.api.createRole(ctx, createRoleInput{
+		Name:          data.Name.ValueString(),
+		Description:   data.Description.ValueString(),
+		PermissionIDs: permissionIDs,
+	})
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to create role", err)
+		return
+	}
+
+	if created.ID == "" {
+		resp.Diagnostics.AddError("API Error", "Role created but no ID returned")
+		return
+	}
+
+	data.ID = types.StringValue(created.ID)
+
+	tflog.Info(ctx, "Role created", map[string]any{
+		"id":   data.ID.ValueString(),
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data RoleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := r.api.getRole(ctx, data.ID.ValueString())
+	if err != nil {
+		if marmot.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to read role", err)
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateModelFromResponse(ctx, &data, existing)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data RoleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state RoleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+
+	if !data.Name.Equal(state.Name) || !data.Description.Equal(state.Description) {
+		if _, err := r.api.updateRole(ctx, id, updateRoleInput{
+			Name:        data.Name.ValueString(),
+			Description: data.Description.ValueString(),
+		}); err != nil {
+			addClientError(ctx, &resp.Diagnostics, "Unable to update role", err)
+			return
+		}
+	}
+
+	if !data.Permissions.Equal(state.Permissions) {
+		permissionIDs, err := r.api.permissionIDs(ctx, rolePermissions(ctx, data.Permissions, &resp.Diagnostics))
+		if err != nil {
+			addClientError(ctx, &resp.Diagnostics, "Unable to resolve role permissions", err)
+			return
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if err := r.api.replaceRolePermissions(ctx, id, permissionIDs); err != nil {
+			addClientError(ctx, &resp.Diagnostics, "Unable to update role permissions", err)
+			return
+		}
+	}
+
+	data.ID = state.ID
+
+	tflog.Info(ctx, "Role updated", map[string]any{
+		"id":   data.ID.ValueString(),
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data RoleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.api.deleteRole(ctx, data.ID.ValueString()); err != nil {
+		if marmot.IsNotFound(err) {
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to delete role", err)
+		return
+	}
+
+	tflog.Info(ctx, "Role deleted", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+}
+
+func (r *RoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// rolePermissions returns the sorted permission names in set.
+func rolePermissions(ctx context.Context, set types.Set, diags *diag.Diagnostics) []string {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
+	}
+	var names []string
+	diags.Append(set.ElementsAs(ctx, &names, false)...)
+	sort.Strings(names)
+	return names
+}
+
+func (r *RoleResource) updateModelFromResponse(ctx context.Context, model *RoleResourceModel, role *role) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(role.ID)
+	model.Name = types.StringValue(role.Name)
+	if role.Description != "" {
+		model.Description = types.StringValue(role.Description)
+	} else {
+		model.Description = types.StringNull()
+	}
+
+	permissions, d := types.SetValueFrom(ctx, types.StringType, role.permissionNames())
+	diags.Append(d...)
+	model.Permissions = permissions
+
+	return diags
+}
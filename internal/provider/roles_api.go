@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// role mirrors the Marmot role payload.
+type role struct {
+	ID          string        `json:"id,omitempty"`
+	Name        string        `json:"name,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Permissions []*permission `json:"permissions"`
+}
+
+// permission mirrors the Marmot permission payload. Names look like
+// "assets:read".
+type permission struct {
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+	ResourceType string `json:"resource_type,omitempty"`
+	Action       string `json:"action,omitempty"`
+}
+
+type createRoleInput struct {
+	Name          string   `json:"name,omitempty"`
+	Description   string   `json:"description,omitempty"`
+	PermissionIDs []string `json:"permission_ids"`
+}
+
+type updateRoleInput struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type replacePermissionsInput struct {
+	PermissionIDs []string `json:"permission_ids"`
+}
+
+func (c *apiClient) listRoles(ctx context.Context) ([]*role, error) {
+	var out []*role
+	err := c.do(ctx, http.MethodGet, "/roles", nil, nil, &out)
+	return out, err
+}
+
+func (c *apiClient) getRole(ctx context.Context, id string) (*role, error) {
+	var out role
+	if err := c.do(ctx, http.MethodGet, "/roles/"+url.PathEscape(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *apiClient) createRole(ctx context.Context, in createRoleInput) (*role, error) {
+	var out role
+	if err := c.do(ctx, http.MethodPost, "/roles", nil, in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *apiClient) updateRole(ctx context.Context, id string, in updateRoleInput) (*role, error) {
+	var out role
+	if err := c.do(ctx, http.MethodPatch, "/roles/"+url.PathEscape(id), nil, in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *apiClient) replaceRolePermissions(ctx context.Context, id string, permissionIDs []string) error {
+	in := replacePermissionsInput{PermissionIDs: permissionIDs}
+	return c.do(ctx, http.MethodPost, "/roles/"+url.PathEscape(id)+"/permissions", nil, in, nil)
+}
+
+func (c *apiClient) deleteRole(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/roles/"+url.PathEscape(id), nil, nil, nil)
+}
+
+func (c *apiClient) listPermissions(ctx context.Context) ([]*permission, error) {
+	var out []*permission
+	err := c.do(ctx, http.MethodGet, "/permissions", nil, nil, &out)
+	return out, err
+}
+
+// permissionIDs resolves permission names to their IDs, failing on any name
+// the server doesn't know.
+func (c *apiClient) permissionIDs(ctx context.Context, names []string) ([]string, error) {
+	if len(names) == 0 {
+		return []string{}, nil
+	}
+
+	all, err := c.listPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]string, len(all))
+	for _, p := range all {
+		if p != nil {
+			byName[p.Name] = p.ID
+		}
+	}
+
+	ids := make([]string, 0, len(names))
+	var unknown []string
+	for _, name := range names {
+		id, ok := byName[name]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown permissions: %v", unknown)
+	}
+	return ids, nil
+}
+
+// permissionNames returns the sorted names of the role's permissions.
+func (r *role) permissionNames() []string {
+	names := make([]string, 0, len(r.Permissions))
+	for _, p := range r.Permissions {
+		if p != nil {
+			names = append(names, p.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,354 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &S3BucketAssetResource{}
+var _ resource.ResourceWithImportState = &S3BucketAssetResource{}
+
+// The asset type, service, and metadata keys the S3 ingestion plugin uses,
+// so buckets declared here look the same as scanned ones.
+const (
+	s3BucketAssetType = "Bucket"
+	s3Service         = "S3"
+
+	s3MetaRegion   = "region"
+	s3MetaPrefixes = "prefixes"
+)
+
+var s3BucketName = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+func NewS3BucketAssetResource() resource.Resource {
+	return &S3BucketAssetResource{}
+}
+
+// S3BucketAssetResource defines the resource implementation.
+type S3BucketAssetResource struct {
+	client          *marmot.Client
+	ignoreLabelCase bool
+	uiBaseURL       string
+}
+
+// S3BucketAssetResourceModel describes the S3 bucket asset resource data
+// model.
+type S3BucketAssetResourceModel struct {
+	Name        types.String  `tfsdk:"name"`
+	Region      types.String  `tfsdk:"region"`
+	Prefixes    types.List    `tfsdk:"prefixes"`
+	Description markdownValue `tfsdk:"description"`
+	Tags        types.Set     `tfsdk:"tags"`
+
+	ID  types.String `tfsdk:"id"`
+	MRN types.String `tfsdk:"mrn"`
+	URL types.String `tfsdk:"url"`
+}
+
+func (r *S3BucketAssetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_bucket_asset"
+}
+
+func (r *S3BucketAssetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Registers an S3 bucket as a Marmot asset, with the asset type, service, " +
+			"and metadata keys the S3 ingestion plugin uses, so the module that creates a bucket " +
+			"can register it too and it looks the same as scanned buckets. The asset's MRN is " +
+			"`mrn://bucket/s3/<name>`.\n\n" +
+			"Attributes left unset keep whatever value ingestion wrote. A bucket that ingestion " +
+			"already registered can be brought under Terraform with `terraform import`.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Bucket name, such as `aws_s3_bucket.raw.bucket`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(s3BucketName, "must be a valid S3 bucket name"),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "AWS region of the bucket, such as `eu-west-1`, stored as the " +
+					"`region` metadata key",
+				Optional: true,
+			},
+			"prefixes": schema.ListAttribute{
+				MarkdownDescription: "Key prefixes that lay out the bucket's data, such as " +
+					"`[\"raw/\", \"curated/\"]`, stored as the `prefixes` metadata key",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[^/]`), "must not start with /"),
+					),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Markdown description of the bucket",
+				Optional:            true,
+				CustomType:          markdownType{},
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Tags for the bucket",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Asset ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mrn": schema.StringAttribute{
+				MarkdownDescription: "Marmot Resource Name",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "Link to the bucket in the Marmot UI",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *S3BucketAssetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.ignoreLabelCase = data.ignoreLabelCase
+	r.uiBaseURL = data.uiBaseURL
+}
+
+func (r *S3BucketAssetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data S3BucketAssetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metadata := s3BucketMetadata(ctx, data, &resp.Diagnostics)
+	tags := setStrings(ctx, data.Tags, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, err := r.client.Assets.Create(ctx, marmot.CreateAssetInput{
+		Name:        data.Name.ValueString(),
+		Type:        s3BucketAssetType,
+		Providers:   []string{s3Service},
+		Description: data.Description.ValueString(),
+		Tags:        tags,
+		Metadata:    metadata,
+	})
+	if err != nil {
+		if isConflict(err) {
+			addTypedAssetExistsError(ctx, r.client, &resp.Diagnostics, "S3 bucket", marmot.LookupInput{
+				Type:    s3BucketAssetType,
+				Service: s3Service,
+				Name:    data.Name.ValueString(),
+			})
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to create bucket asset", err)
+		return
+	}
+
+	if asset.ID == "" {
+		resp.Diagnostics.AddError("API Error", "Asset created but no ID returned")
+		return
+	}
+
+	data.ID = types.StringValue(asset.ID)
+	data.MRN = types.StringValue(asset.Mrn)
+	data.URL = assetURL(r.uiBaseURL, asset.Mrn)
+
+	tflog.Info(ctx, "S3 bucket asset created", map[string]any{
+		"id":   data.ID.ValueString(),
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *S3BucketAssetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data S3BucketAssetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, err := r.client.Assets.Get(ctx, data.ID.ValueString())
+	if err != nil {
+		if marmot.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to read bucket asset", err)
+		return
+	}
+
+	prior := data
+	data.ID = types.StringValue(asset.ID)
+	data.MRN = types.StringValue(asset.Mrn)
+	data.URL = assetURL(r.uiBaseURL, asset.Mrn)
+	data.Name = types.StringValue(asset.Name)
+	data.Description, data.Tags = typedAssetDescriptionAndTags(ctx, asset, prior.Tags, r.ignoreLabelCase, &resp.Diagnostics)
+
+	meta := convert.Object(asset.Metadata)
+	data.Region = metadataString(meta, s3MetaRegion)
+	data.Prefixes = metadataStrings(ctx, meta, s3MetaPrefixes, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An import starts from an ID alone and reads every attribute. Otherwise,
+	// optional attributes left out of the configuration stay unset, since
+	// updates leave their server values alone.
+	if !prior.Name.IsNull() {
+		if prior.Region.IsNull() {
+			data.Region = prior.Region
+		}
+		if prior.Prefixes.IsNull() {
+			data.Prefixes = prior.Prefixes
+		}
+		if prior.Description.IsNull() {
+			data.Description = prior.Description
+		}
+		if prior.Tags.IsNull() {
+			data.Tags = prior.Tags
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *S3BucketAssetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data, state S3BucketAssetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metadata := s3BucketMetadata(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.client.Assets.Get(ctx, state.ID.ValueString())
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to read bucket asset", err)
+		return
+	}
+
+	input := typedAssetUpdate(ctx, current, data.Description, data.Tags, metadata, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, err := r.client.Assets.Update(ctx, state.ID.ValueString(), input)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to update bucket asset", err)
+		return
+	}
+
+	data.ID = types.StringValue(asset.ID)
+	data.MRN = types.StringValue(asset.Mrn)
+	data.URL = assetURL(r.uiBaseURL, asset.Mrn)
+
+	tflog.Info(ctx, "S3 bucket asset updated", map[string]any{
+		"id":   data.ID.ValueString(),
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *S3BucketAssetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data S3BucketAssetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.Assets.Delete(ctx, data.ID.ValueString()); err != nil {
+		if marmot.IsNotFound(err) {
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to delete bucket asset", err)
+		return
+	}
+
+	tflog.Info(ctx, "S3 bucket asset deleted", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+}
+
+// ImportState imports an asset by its ID or its MRN.
+func (r *S3BucketAssetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importAssetRef(ctx, r.client, req, resp)
+}
+
+// s3BucketMetadata returns the metadata keys the configuration sets.
+func s3BucketMetadata(ctx context.Context, data S3BucketAssetResourceModel, diags *diag.Diagnostics) map[string]any {
+	metadata := map[string]any{}
+	if !data.Region.IsNull() {
+		metadata[s3MetaRegion] = data.Region.ValueString()
+	}
+	if !data.Prefixes.IsNull() && !data.Prefixes.IsUnknown() {
+		var prefixes []string
+		diags.Append(data.Prefixes.ElementsAs(ctx, &prefixes, false)...)
+		metadata[s3MetaPrefixes] = nonNilStrings(prefixes)
+	}
+	return metadata
+}
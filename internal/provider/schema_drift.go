@@ -0,0 +1,249 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// maxDriftCheckBytes bounds the response bodies checked for unknown fields.
+// Larger bodies are passed through unchecked.
+const maxDriftCheckBytes = 4 << 20
+
+// driftRoute maps requests, by method and by path relative to the base
+// path, to the SDK model their successful responses decode into. An empty
+// method matches any method. A nil model marks requests that would otherwise
+// match a later route, such as /assets/search for /assets/{id}.
+type driftRoute struct {
+	method  string
+	pattern *regexp.Regexp
+	model   reflect.Type
+}
+
+func route(method, pattern string, model any) driftRoute {
+	r := driftRoute{method: method, pattern: regexp.MustCompile("^" + pattern + "$")}
+	if model != nil {
+		r.model = reflect.TypeOf(model)
+	}
+	return r
+}
+
+// driftRoutes are the responses checked for unknown fields: those the
+// provider reads back into state. The first matching route wins.
+var driftRoutes = []driftRoute{
+	route("", `/assets/(documentation|match-pattern|my-assets|summary)`, nil),
+	route("", `/assets/search`, marmot.AssetSearchResults{}),
+	route("", `/assets/lookup/[^/]+/[^/]+/[^/]+`, marmot.Asset{}),
+	route("", `/assets(/[^/]+)?`, marmot.Asset{}),
+	route("", `/lineage/direct(/[^/]+)?`, marmot.LineageEdge{}),
+	route("", `/lineage/assets/[^/]+`, marmot.Lineage{}),
+	route("", `/lineage/batch`, marmot.BatchLineageResult{}),
+	route("", `/glossary/(list|search)`, nil),
+	route("", `/glossary/([^/]+)?`, marmot.GlossaryTerm{}),
+	route(http.MethodGet, `/teams`, marmot.TeamList{}),
+	route("", `/teams(/[^/]+)?`, marmot.Team{}),
+	route(http.MethodGet, `/users`, marmot.UserList{}),
+	route("", `/users/(apikeys|login|me|preferences|update-password)`, nil),
+	route("", `/users(/[^/]+)?`, marmot.User{}),
+	route("", `/products/(list|search|rule-preview)`, nil),
+	route("", `/products/([^/]+)?`, marmot.DataProduct{}),
+	route(http.MethodGet, `/ingestion/schedules`, marmot.ScheduleList{}),
+	route("", `/ingestion/schedules(/[^/]+)?`, marmot.Schedule{}),
+}
+
+// schemaDriftTransport compares successful JSON responses against the SDK
+// models they decode into and reports fields the models don't have. Those
+// fields are dropped on decode, so a newer Marmot server can carry data the
+// provider never sees, and then loses, when a resource writes the whole
+// object back. Each unknown field is logged once as a warning; with strict
+// set the response fails instead.
+type schemaDriftTransport struct {
+	base     http.RoundTripper
+	basePath string
+	strict   bool
+
+	mu     sync.Mutex
+	warned map[string]bool
+}
+
+func newSchemaDriftTransport(base http.RoundTripper, basePath string, strict bool) *schemaDriftTransport {
+	return &schemaDriftTransport{
+		base:     base,
+		basePath: basePath,
+		strict:   strict,
+		warned:   map[string]bool{},
+	}
+}
+
+func (t *schemaDriftTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode < 200 || resp.StatusCode >= 300 || resp.Body == nil {
+		return resp, err
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "application/json" {
+		return resp, nil
+	}
+
+	relPath, ok := strings.CutPrefix(req.URL.Path, t.basePath)
+	if !ok {
+		return resp, nil
+	}
+	model := driftModel(req.Method, relPath)
+	if model == nil {
+		return resp, nil
+	}
+
+	body, readErr := io.ReadAll(io.LimitReader(resp.Body, maxDriftCheckBytes+1))
+	rest := resp.Body
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), rest), rest}
+	if readErr != nil || len(body) > maxDriftCheckBytes {
+		return resp, nil
+	}
+
+	var raw any
+	if json.Unmarshal(body, &raw) != nil {
+		return resp, nil
+	}
+	fields := unknownFields(raw, model)
+	if len(fields) == 0 {
+		return resp, nil
+	}
+
+	if t.strict {
+		rest.Close()
+		return nil, fmt.Errorf("response from %s %s has fields the provider doesn't recognise: %s. "+
+			"Upgrade the provider, or unset fail_on_unknown_fields to only log them",
+			req.Method, req.URL.Path, strings.Join(fields, ", "))
+	}
+
+	for _, field := range t.unwarned(model, fields) {
+		tflog.Warn(req.Context(), "Marmot returned a field the provider doesn't recognise; it is ignored", map[string]interface{}{
+			"http_method": req.Method,
+			"http_path":   req.URL.Path,
+			"model":       model.Name(),
+			"field":       field,
+		})
+	}
+	return resp, nil
+}
+
+// unwarned returns the fields not yet warned about for model and marks them
+// as warned.
+func (t *schemaDriftTransport) unwarned(model reflect.Type, fields []string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var out []string
+	for _, field := range fields {
+		key := model.Name() + "." + field
+		if !t.warned[key] {
+			t.warned[key] = true
+			out = append(out, field)
+		}
+	}
+	return out
+}
+
+// driftModel returns the model checked for responses to method and path, or
+// nil.
+func driftModel(method, path string) reflect.Type {
+	for _, r := range driftRoutes {
+		if (r.method == "" || r.method == method) && r.pattern.MatchString(path) {
+			return r.model
+		}
+	}
+	return nil
+}
+
+// unknownFields returns the sorted paths of the keys in the decoded JSON raw
+// that have no matching field in t, such as "sources[].properties_v2".
+// Elements of lists and maps are checked against the element type; fields
+// typed as any are not checked.
+func unknownFields(raw any, t reflect.Type) []string {
+	seen := map[string]bool{}
+	collectUnknownFields(raw, t, "", seen)
+
+	fields := make([]string, 0, len(seen))
+	for f := range seen {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func collectUnknownFields(raw any, t reflect.Type, prefix string, seen map[string]bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := raw.(map[string]any)
+		if !ok {
+			return
+		}
+		for key, value := range obj {
+			field, ok := jsonField(t, key)
+			if !ok {
+				seen[prefix+key] = true
+				continue
+			}
+			collectUnknownFields(value, field.Type, prefix+key+".", seen)
+		}
+	case reflect.Slice, reflect.Array:
+		list, ok := raw.([]any)
+		if !ok {
+			return
+		}
+		for _, elem := range list {
+			collectUnknownFields(elem, t.Elem(), strings.TrimSuffix(prefix, ".")+"[].", seen)
+		}
+	case reflect.Map:
+		obj, ok := raw.(map[string]any)
+		if !ok {
+			return
+		}
+		for _, value := range obj {
+			collectUnknownFields(value, t.Elem(), prefix+"*.", seen)
+		}
+	}
+}
+
+// jsonField returns the field of struct type t that encoding/json decodes
+// key into, matching names case-insensitively as the decoder does.
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type driftSource struct {
+	Name       string         `json:"name"`
+	Properties map[string]any `json:"properties"`
+}
+
+type driftAsset struct {
+	ID      string                  `json:"id"`
+	Type    string                  `json:"type,omitempty"`
+	Owner   *driftSource            `json:"owner"`
+	Sources []driftSource           `json:"sources"`
+	ByName  map[string]*driftSource `json:"by_name"`
+	Extra   any                     `json:"extra"`
+	Ignored string                  `json:"-"`
+	Legacy  string
+}
+
+func TestUnknownFields(t *testing.T) {
+	tests := map[string]struct {
+		body string
+		t    reflect.Type
+		want []string
+	}{
+		"all known": {
+			body: `{"id":"1","type":"table","owner":{"name":"a"},"sources":[{"name":"s","properties":{"x":1}}],"legacy":"l","ID":"case"}`,
+			t:    reflect.TypeOf(driftAsset{}),
+			want: []string{},
+		},
+		"top level and nested": {
+			body: `{"id":"1","tier":"gold","owner":{"name":"a","email":"e"},"sources":[{"name":"s","properties_v2":{}},{"properties_v2":{}}]}`,
+			t:    reflect.TypeOf(driftAsset{}),
+			want: []string{"owner.email", "sources[].properties_v2", "tier"},
+		},
+		"map values": {
+			body: `{"by_name":{"a":{"name":"a","kind":"k"}}}`,
+			t:    reflect.TypeOf(driftAsset{}),
+			want: []string{"by_name.*.kind"},
+		},
+		"untyped values not checked": {
+			body: `{"extra":{"anything":1},"sources":[{"properties":{"anything":{"deep":1}}}]}`,
+			t:    reflect.TypeOf(driftAsset{}),
+			want: []string{},
+		},
+		"skipped field is unknown": {
+			body: `{"ignored":"x"}`,
+			t:    reflect.TypeOf(driftAsset{}),
+			want: []string{"ignored"},
+		},
+		"list response": {
+			body: `[{"id":"1","tier":"gold"},{"id":"2","tier":"silver"}]`,
+			t:    reflect.TypeOf([]*driftAsset{}),
+			want: []string{"[].tier"},
+		},
+		"mismatched shape": {
+			body: `{"owner":"a","sources":{"name":"s"}}`,
+			t:    reflect.TypeOf(driftAsset{}),
+			want: []string{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var raw any
+			if err := json.Unmarshal([]byte(tt.body), &raw); err != nil {
+				t.Fatal(err)
+			}
+			if got := unknownFields(raw, tt.t); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
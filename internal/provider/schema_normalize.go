@@ -0,0 +1,287 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// decodeJSONDocument parses doc, keeping numbers exactly as written.
+func decodeJSONDocument(doc string) (any, error) {
+	dec := json.NewDecoder(strings.NewReader(doc))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected content after the JSON document")
+	}
+	return v, nil
+}
+
+// encodeCanonicalJSON writes v compactly with object keys sorted, which
+// encoding/json does for maps. HTML escaping is off so that strings such as
+// regex patterns come back as written.
+func encodeCanonicalJSON(v any) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// avroPrimitives are the Avro primitive type names.
+var avroPrimitives = map[string]bool{
+	"null": true, "boolean": true, "int": true, "long": true,
+	"float": true, "double": true, "bytes": true, "string": true,
+}
+
+// normalizeAvroSchema canonicalizes an Avro schema: object keys sorted,
+// whitespace removed, and primitive types written as {"type": "string"}
+// collapsed to "string". The order of record fields, union branches, and
+// enum symbols is meaningful in Avro and is kept.
+func normalizeAvroSchema(doc string) (string, error) {
+	v, err := decodeJSONDocument(doc)
+	if err != nil {
+		return "", err
+	}
+	switch v.(type) {
+	case string, map[string]any, []any:
+	default:
+		return "", fmt.Errorf("an Avro schema must be a type name, an object, or a union array")
+	}
+	return encodeCanonicalJSON(collapseAvroPrimitives(v))
+}
+
+func collapseAvroPrimitives(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		if t, ok := val["type"].(string); ok && len(val) == 1 && avroPrimitives[t] {
+			return t
+		}
+		for k, elem := range val {
+			val[k] = collapseAvroPrimitives(elem)
+		}
+		return val
+	case []any:
+		for i, elem := range val {
+			val[i] = collapseAvroPrimitives(elem)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// normalizeJSONSchema canonicalizes a JSON Schema document: object keys
+// sorted, whitespace removed, and "required" lists sorted, since their order
+// carries no meaning.
+func normalizeJSONSchema(doc string) (string, error) {
+	v, err := decodeJSONDocument(doc)
+	if err != nil {
+		return "", err
+	}
+	switch v.(type) {
+	case map[string]any, bool:
+	default:
+		return "", fmt.Errorf("a JSON Schema must be an object or a boolean")
+	}
+	return encodeCanonicalJSON(sortRequiredLists(v))
+}
+
+func sortRequiredLists(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, elem := range val {
+			if names, ok := stringList(elem); ok && k == "required" {
+				sort.Strings(names)
+				val[k] = names
+				continue
+			}
+			val[k] = sortRequiredLists(elem)
+		}
+		return val
+	case []any:
+		for i, elem := range val {
+			val[i] = sortRequiredLists(elem)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// stringList returns v as a []string when it is a JSON array of strings.
+func stringList(v any) ([]string, bool) {
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, false
+	}
+	out := make([]string, 0, len(arr))
+	for _, elem := range arr {
+		s, ok := elem.(string)
+		if !ok {
+			return nil, false
+		}
+		out = append(out, s)
+	}
+	return out, true
+}
+
+// normalizeProtobufSchema reformats a .proto definition into one canonical
+// layout: comments dropped, one statement per line, two-space indentation
+// per block, and single spaces between tokens. Declaration order is kept.
+func normalizeProtobufSchema(doc string) (string, error) {
+	tokens, err := protoTokens(doc)
+	if err != nil {
+		return "", err
+	}
+	if len(tokens) == 0 {
+		return "", fmt.Errorf("the schema is empty")
+	}
+
+	var b strings.Builder
+	depth := 0
+	lineStart := true
+	prev, prev2 := "", ""
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch {
+		case tok == "{" && i+1 < len(tokens) && tokens[i+1] == "}":
+			b.WriteString(" {}")
+			i++
+			if i+1 < len(tokens) && tokens[i+1] == ";" {
+				break
+			}
+			b.WriteString("\n")
+			lineStart = true
+		case tok == "{":
+			b.WriteString(" {\n")
+			depth++
+			lineStart = true
+		case tok == "}":
+			if depth == 0 {
+				return "", fmt.Errorf("unbalanced '}'")
+			}
+			depth--
+			if !lineStart {
+				b.WriteString("\n")
+			}
+			b.WriteString(strings.Repeat("  ", depth) + "}")
+			lineStart = false
+			if i+1 < len(tokens) && tokens[i+1] == ";" {
+				break
+			}
+			b.WriteString("\n")
+			lineStart = true
+		case tok == ";":
+			b.WriteString(";\n")
+			lineStart = true
+		default:
+			switch {
+			case lineStart:
+				b.WriteString(strings.Repeat("  ", depth))
+			case !protoTight(prev2, prev, tok):
+				b.WriteString(" ")
+			}
+			b.WriteString(tok)
+			lineStart = false
+		}
+		prev2, prev = prev, tokens[i]
+	}
+	if depth != 0 {
+		return "", fmt.Errorf("unbalanced '{'")
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n", nil
+}
+
+// protoTight reports whether tok follows prev, which follows prev2, with no
+// space between them, giving "map<string, int32>", "[deprecated = true]",
+// "(my.option)", "rpc Get(GetRequest)", and "a: 1" in option values.
+func protoTight(prev2, prev, tok string) bool {
+	switch {
+	case prev == "(", prev == "[", prev == "<":
+		return true
+	case tok == ")", tok == "]", tok == ">", tok == ",", tok == ":":
+		return true
+	case tok == "<" && prev == "map":
+		return true
+	case tok == "(" && prev2 == "rpc":
+		return true
+	}
+	return false
+}
+
+// protoTokens splits a .proto source into tokens, skipping whitespace and
+// comments. String literals are kept whole, and a minus sign is joined to
+// the number it negates.
+func protoTokens(src string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				return tokens, nil
+			}
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated block comment")
+			}
+			i += end + 4
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(src) && src[j] != c {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("unterminated string literal")
+			}
+			tokens = append(tokens, src[i:j+1])
+			i = j + 1
+		case isProtoWordByte(c):
+			j := i
+			for j < len(src) && isProtoWordByte(src[j]) {
+				// Keep the sign of a float exponent, as in 1e-5.
+				if (src[j] == 'e' || src[j] == 'E') && j+1 < len(src) && (src[j+1] == '-' || src[j+1] == '+') &&
+					'0' <= src[i] && src[i] <= '9' {
+					j++
+				}
+				j++
+			}
+			word := src[i:j]
+			if n := len(tokens); n > 0 && tokens[n-1] == "-" {
+				tokens[n-1] += word
+			} else {
+				tokens = append(tokens, word)
+			}
+			i = j
+		default:
+			tokens = append(tokens, string(c))
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+func isProtoWordByte(c byte) bool {
+	return c == '_' || c == '.' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
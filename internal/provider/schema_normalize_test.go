@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+type normalizeTest struct {
+	in      string
+	want    string
+	wantErr bool
+}
+
+func runNormalizeTests(t *testing.T, normalize func(string) (string, error), tests map[string]normalizeTest) {
+	t.Helper()
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := normalize(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error: got %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeAvroSchema(t *testing.T) {
+	runNormalizeTests(t, normalizeAvroSchema, map[string]normalizeTest{
+		"type name": {in: ` "string" `, want: `"string"`},
+		"record": {
+			in: `{
+				"type": "record",
+				"name": "User",
+				"fields": [
+					{"name": "id", "type": {"type": "long"}},
+					{"name": "email", "type": ["null", {"type": "string"}]}
+				]
+			}`,
+			want: `{"fields":[{"name":"id","type":"long"},{"name":"email","type":["null","string"]}],"name":"User","type":"record"}`,
+		},
+		"logical type kept": {
+			in:   `{"type": "long", "logicalType": "timestamp-millis"}`,
+			want: `{"logicalType":"timestamp-millis","type":"long"}`,
+		},
+		"enum order kept": {
+			in:   `{"type":"enum","name":"E","symbols":["b","a"]}`,
+			want: `{"name":"E","symbols":["b","a"],"type":"enum"}`,
+		},
+		"numbers and html kept": {
+			in:   `{"type":"fixed","name":"<F>","size":16,"default":1.50}`,
+			want: `{"default":1.50,"name":"<F>","size":16,"type":"fixed"}`,
+		},
+		"not a schema":   {in: `42`, wantErr: true},
+		"trailing input": {in: `"string" "int"`, wantErr: true},
+		"invalid":        {in: `{`, wantErr: true},
+	})
+}
+
+func TestNormalizeJSONSchema(t *testing.T) {
+	runNormalizeTests(t, normalizeJSONSchema, map[string]normalizeTest{
+		"object": {
+			in: `{
+				"type": "object",
+				"required": ["name", "id"],
+				"properties": {
+					"name": {"type": "string", "pattern": "^[a-z]+&$"},
+					"id": {"type": "integer"}
+				}
+			}`,
+			want: `{"properties":{"id":{"type":"integer"},"name":{"pattern":"^[a-z]+&$","type":"string"}},"required":["id","name"],"type":"object"}`,
+		},
+		"nested required": {
+			in:   `{"items":[{"required":["b","a"]}]}`,
+			want: `{"items":[{"required":["a","b"]}]}`,
+		},
+		"property named required": {
+			in:   `{"properties":{"required":{"enum":["b","a"]}}}`,
+			want: `{"properties":{"required":{"enum":["b","a"]}}}`,
+		},
+		"enum order kept": {
+			in:   `{"enum":["b","a"]}`,
+			want: `{"enum":["b","a"]}`,
+		},
+		"boolean":      {in: `true`, want: `true`},
+		"not a schema": {in: `["a"]`, wantErr: true},
+		"invalid":      {in: `{"type":`, wantErr: true},
+	})
+}
+
+func TestNormalizeProtobufSchema(t *testing.T) {
+	runNormalizeTests(t, normalizeProtobufSchema, map[string]normalizeTest{
+		"message": {
+			in: `syntax = "proto3";
+// A user.
+package example.v1;
+
+message User {
+    int64   id = 1; /* primary key */
+    map<string,string> labels=2 [deprecated=true];
+    repeated string tags = 3;
+    oneof contact { string email = 4; }
+    reserved 5 to 7;
+    message Empty {}
+}
+
+enum Status { STATUS_UNSPECIFIED = 0; STATUS_OLD = -1; }
+
+service Users {
+  rpc Get ( GetRequest ) returns ( User );
+  rpc Watch(GetRequest) returns (stream User) { option (my.opt) = { a: 1e-5 }; }
+}
+`,
+			want: `syntax = "proto3";
+package example.v1;
+message User {
+  int64 id = 1;
+  map<string, string> labels = 2 [deprecated = true];
+  repeated string tags = 3;
+  oneof contact {
+    string email = 4;
+  }
+  reserved 5 to 7;
+  message Empty {}
+}
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_OLD = -1;
+}
+service Users {
+  rpc Get(GetRequest) returns (User);
+  rpc Watch(GetRequest) returns (stream User) {
+    option (my.opt) = {
+      a: 1e-5
+    };
+  }
+}
+`,
+		},
+		"string with comment marker": {
+			in:   `option go_package = "example.com/a//b";`,
+			want: "option go_package = \"example.com/a//b\";\n",
+		},
+		"empty":                {in: "// nothing\n", wantErr: true},
+		"unbalanced open":      {in: `message A {`, wantErr: true},
+		"unbalanced close":     {in: `message A {}}`, wantErr: true},
+		"unterminated comment": {in: `message A {} /*`, wantErr: true},
+		"unterminated string":  {in: `syntax = "proto3;`, wantErr: true},
+	})
+}
@@ -0,0 +1,310 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SearchDataSource{}
+
+const (
+	searchDefaultLimit = 100
+	searchMaxLimit     = 1000
+)
+
+func NewSearchDataSource() datasource.DataSource {
+	return &SearchDataSource{}
+}
+
+// SearchDataSource defines the data source implementation.
+type SearchDataSource struct {
+	client *marmot.Client
+}
+
+// SearchDataSourceModel describes the search data source data model.
+type SearchDataSourceModel struct {
+	Query    types.String `tfsdk:"query"`
+	Types    types.Set    `tfsdk:"types"`
+	Services types.Set    `tfsdk:"services"`
+	Tags     types.Set    `tfsdk:"tags"`
+	Sort     types.String `tfsdk:"sort"`
+	Limit    types.Int64  `tfsdk:"limit"`
+	Total    types.Int64  `tfsdk:"total"`
+	Results  types.List   `tfsdk:"results"`
+}
+
+var searchResultAttrTypes = map[string]attr.Type{
+	"id":          types.StringType,
+	"mrn":         types.StringType,
+	"name":        types.StringType,
+	"type":        types.StringType,
+	"description": types.StringType,
+	"services":    types.ListType{ElemType: types.StringType},
+	"tags":        types.ListType{ElemType: types.StringType},
+	"updated_at":  types.StringType,
+	"score":       types.Float64Type,
+}
+
+func (d *SearchDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_search"
+}
+
+func (d *SearchDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Searches the Marmot catalog for assets, with optional type, service, " +
+			"and tag filters. Useful for governance checks, for example asserting that every asset " +
+			"matching `pii` carries a `restricted` tag.",
+
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				MarkdownDescription: "Full-text search query. Leave unset to match every asset that " +
+					"passes the filters.",
+				Optional: true,
+			},
+			"types": schema.SetAttribute{
+				MarkdownDescription: "Only return assets of these types",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"services": schema.SetAttribute{
+				MarkdownDescription: "Only return assets from these services",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Only return assets carrying these tags",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"sort": schema.StringAttribute{
+				MarkdownDescription: "Order of `results`: `relevance` (the API's order, the default), " +
+					"`name`, or `updated_at` (newest first). Sorting is applied to the returned " +
+					"results, after `limit`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("relevance", "name", "updated_at"),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of results to return. Defaults to %d, "+
+					"at most %d.", searchDefaultLimit, searchMaxLimit),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, searchMaxLimit),
+				},
+			},
+			"total": schema.Int64Attribute{
+				MarkdownDescription: "Total number of matching assets, which may exceed `limit`",
+				Computed:            true,
+			},
+			"results": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching assets",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Asset ID",
+							Computed:            true,
+						},
+						"mrn": schema.StringAttribute{
+							MarkdownDescription: "Marmot Resource Name",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Asset name",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Asset type",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Asset description",
+							Computed:            true,
+						},
+						"services": schema.ListAttribute{
+							MarkdownDescription: "Services associated with the asset",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"tags": schema.ListAttribute{
+							MarkdownDescription: "Tags associated with the asset",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"updated_at": schema.StringAttribute{
+							MarkdownDescription: "Last update timestamp",
+							Computed:            true,
+						},
+						"score": schema.Float64Attribute{
+							MarkdownDescription: "Relevance score from the catalog search. Null when " +
+								"`query` is unset or the asset fell outside the ranked results.",
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SearchDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *SearchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data SearchDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := int64(searchDefaultLimit)
+	if !data.Limit.IsNull() {
+		limit = data.Limit.ValueInt64()
+	}
+
+	opts := marmot.AssetSearchOptions{
+		Query:     data.Query.ValueString(),
+		Types:     setStrings(ctx, data.Types, &resp.Diagnostics),
+		Providers: setStrings(ctx, data.Services, &resp.Diagnostics),
+		Tags:      setStrings(ctx, data.Tags, &resp.Diagnostics),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var assets []*marmot.Asset
+	var total int64
+	for int64(len(assets)) < limit {
+		opts.Limit = min(limit-int64(len(assets)), searchDefaultLimit)
+		opts.Offset = int64(len(assets))
+
+		page, err := d.client.Assets.Search(ctx, opts)
+		if err != nil {
+			addClientError(ctx, &resp.Diagnostics, "Unable to search assets", err)
+			return
+		}
+		total = page.Total
+		for _, asset := range page.Assets {
+			if asset != nil {
+				assets = append(assets, asset)
+			}
+		}
+		if len(page.Assets) == 0 || int64(len(assets)) >= total {
+			break
+		}
+	}
+
+	// The asset search doesn't return scores, so take them from the unified
+	// search ranking for the same query.
+	scores := map[string]float64{}
+	if opts.Query != "" && len(assets) > 0 {
+		ranked, err := d.client.Search.Query(ctx, opts.Query, marmot.SearchOptions{
+			Types: []string{"asset"},
+			Limit: limit,
+		})
+		if err != nil {
+			addClientError(ctx, &resp.Diagnostics, "Unable to rank search results", err)
+			return
+		}
+		for _, result := range ranked.Results {
+			if result != nil && result.Type == "asset" {
+				scores[result.ID] = result.Rank
+			}
+		}
+	}
+
+	switch data.Sort.ValueString() {
+	case "name":
+		sort.SliceStable(assets, func(i, j int) bool { return assets[i].Name < assets[j].Name })
+	case "updated_at":
+		sort.SliceStable(assets, func(i, j int) bool { return assets[i].UpdatedAt > assets[j].UpdatedAt })
+	}
+
+	results := make([]attr.Value, 0, len(assets))
+	for _, asset := range assets {
+		obj, diags := searchResultValue(ctx, asset, scores)
+		resp.Diagnostics.Append(diags...)
+		results = append(results, obj)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	list, diags := types.ListValue(types.ObjectType{AttrTypes: searchResultAttrTypes}, results)
+	resp.Diagnostics.Append(diags...)
+	data.Results = list
+	data.Total = types.Int64Value(total)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// searchResultValue builds one element of the results list.
+func searchResultValue(ctx context.Context, asset *marmot.Asset, scores map[string]float64) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	services, d := types.ListValueFrom(ctx, types.StringType, nonNilStrings(asset.Providers))
+	diags.Append(d...)
+	tags, d := types.ListValueFrom(ctx, types.StringType, nonNilStrings(asset.Tags))
+	diags.Append(d...)
+
+	score := types.Float64Null()
+	if s, ok := scores[asset.ID]; ok {
+		score = types.Float64Value(s)
+	}
+
+	obj, d := types.ObjectValue(searchResultAttrTypes, map[string]attr.Value{
+		"id":          types.StringValue(asset.ID),
+		"mrn":         types.StringValue(asset.Mrn),
+		"name":        types.StringValue(asset.Name),
+		"type":        types.StringValue(asset.Type),
+		"description": types.StringValue(asset.Description),
+		"services":    services,
+		"tags":        tags,
+		"updated_at":  types.StringValue(normalizeTimestamp(asset.UpdatedAt)),
+		"score":       score,
+	})
+	diags.Append(d...)
+	return obj, diags
+}
+
+// nonNilStrings returns s, or an empty slice when s is nil, so list
+// attributes come out empty rather than null.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// canonicalServices maps the service identifiers Marmot's ingestion plugins
+// record on assets, which are also their plugin IDs, to other names the same
+// service goes by. Names are compared by serviceKey.
+var canonicalServices = map[string][]string{
+	"airflow":    {"apache airflow"},
+	"asyncapi":   {},
+	"azureblob":  {"azure blob", "azure blob storage", "azure storage"},
+	"bigquery":   {"bq", "google bigquery", "gcp bigquery"},
+	"clickhouse": {},
+	"dbt":        {"dbt core", "dbt cloud", "data build tool"},
+	"dynamodb":   {"aws dynamodb", "amazon dynamodb"},
+	"gcs":        {"google cloud storage", "gcp gcs", "gcp storage"},
+	"glue":       {"aws glue", "aws glue data catalog"},
+	"iceberg":    {"apache iceberg"},
+	"kafka":      {"apache kafka", "confluent kafka"},
+	"mongodb":    {"mongo"},
+	"mysql":      {},
+	"nats":       {"nats jetstream"},
+	"openapi":    {"swagger"},
+	"postgresql": {"postgres", "pg", "psql"},
+	"redpanda":   {},
+	"redshift":   {"aws redshift", "amazon redshift"},
+	"s3":         {"aws s3", "amazon s3", "amazon simple storage service"},
+	"snowflake":  {},
+	"sns":        {"aws sns", "amazon sns", "amazon simple notification service"},
+	"sqs":        {"aws sqs", "amazon sqs", "amazon simple queue service"},
+	"trino":      {},
+}
+
+// canonicalServiceByKey indexes canonicalServices by the serviceKey of every
+// identifier and alias.
+var canonicalServiceByKey = func() map[string]string {
+	index := map[string]string{}
+	for id, aliases := range canonicalServices {
+		index[serviceKey(id)] = id
+		for _, alias := range aliases {
+			index[serviceKey(alias)] = id
+		}
+	}
+	return index
+}()
+
+// serviceKey reduces a service name to its lowercased letters and digits, so
+// "AWS S3", "aws-s3", and "aws_s3" compare equal.
+func serviceKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
+// canonicalService returns the identifier Marmot uses for a service. Names
+// Marmot doesn't know are trimmed and lowercased, with runs of whitespace
+// replaced by a hyphen, so the same unknown name is always spelled the same.
+func canonicalService(name string) (string, error) {
+	if id, ok := canonicalServiceByKey[serviceKey(name)]; ok {
+		return id, nil
+	}
+
+	canonical := strings.ToLower(strings.Join(strings.Fields(name), "-"))
+	if canonical == "" {
+		return "", fmt.Errorf("service %q is empty", name)
+	}
+	return canonical, nil
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &CanonicalServiceFunction{}
+
+func NewCanonicalServiceFunction() function.Function {
+	return &CanonicalServiceFunction{}
+}
+
+// CanonicalServiceFunction defines the canonical_service function.
+type CanonicalServiceFunction struct{}
+
+func (f *CanonicalServiceFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "canonical_service"
+}
+
+func (f *CanonicalServiceFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Canonicalize a service name",
+		MarkdownDescription: "Returns the identifier Marmot uses for a service, the same as the ID of " +
+			"its ingestion plugin, so `AWS S3`, `Amazon S3`, and `s3` all become `s3` and " +
+			"`Postgres` becomes `postgresql`. Case, spaces, hyphens, and underscores are ignored " +
+			"when matching. Services Marmot doesn't know are returned trimmed and lowercased, with " +
+			"spaces replaced by hyphens. Fails if the name is empty.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "Service name to canonicalize",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *CanonicalServiceFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	canonical, err := canonicalService(name)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid service: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, canonical))
+}
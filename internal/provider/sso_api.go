@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// ssoTeamMapping mirrors the Marmot SSO team mapping payload: members of
+// an identity provider group are added to a team on login.
+type ssoTeamMapping struct {
+	ID           string `json:"id,omitempty"`
+	Provider     string `json:"provider,omitempty"`
+	SSOGroupName string `json:"sso_group_name,omitempty"`
+	TeamID       string `json:"team_id,omitempty"`
+	MemberRole   string `json:"member_role,omitempty"`
+	CreatedAt    string `json:"created_at,omitempty"`
+	UpdatedAt    string `json:"updated_at,omitempty"`
+}
+
+type createSSOTeamMappingInput struct {
+	Provider     string `json:"provider,omitempty"`
+	SSOGroupName string `json:"sso_group_name,omitempty"`
+	TeamID       string `json:"team_id,omitempty"`
+	MemberRole   string `json:"member_role,omitempty"`
+}
+
+type updateSSOTeamMappingInput struct {
+	TeamID     string `json:"team_id,omitempty"`
+	MemberRole string `json:"member_role,omitempty"`
+}
+
+func (c *apiClient) getSSOTeamMapping(ctx context.Context, id string) (*ssoTeamMapping, error) {
+	var out ssoTeamMapping
+	if err := c.do(ctx, http.MethodGet, "/sso/team-mappings/"+url.PathEscape(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *apiClient) createSSOTeamMapping(ctx context.Context, in createSSOTeamMappingInput) (*ssoTeamMapping, error) {
+	var out ssoTeamMapping
+	if err := c.do(ctx, http.MethodPost, "/sso/team-mappings", nil, in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *apiClient) updateSSOTeamMapping(ctx context.Context, id string, in updateSSOTeamMappingInput) (*ssoTeamMapping, error) {
+	var out ssoTeamMapping
+	if err := c.do(ctx, http.MethodPut, "/sso/team-mappings/"+url.PathEscape(id), nil, in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *apiClient) deleteSSOTeamMapping(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/sso/team-mappings/"+url.PathEscape(id), nil, nil, nil)
+}
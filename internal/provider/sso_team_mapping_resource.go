@@ -0,0 +1,258 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SSOTeamMappingResource{}
+var _ resource.ResourceWithImportState = &SSOTeamMappingResource{}
+
+func NewSSOTeamMappingResource() resource.Resource {
+	return &SSOTeamMappingResource{}
+}
+
+// SSOTeamMappingResource defines the resource implementation.
+type SSOTeamMappingResource struct {
+	api *apiClient
+}
+
+// SSOTeamMappingResourceModel describes the SSO team mapping resource data
+// model.
+type SSOTeamMappingResourceModel struct {
+	Provider     types.String `tfsdk:"provider_name"`
+	SSOGroupName types.String `tfsdk:"sso_group_name"`
+	TeamID       types.String `tfsdk:"team_id"`
+	MemberRole   types.String `tfsdk:"member_role"`
+	ID           types.String `tfsdk:"id"`
+}
+
+func (r *SSOTeamMappingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sso_team_mapping"
+}
+
+func (r *SSOTeamMappingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Adds members of an SSO group to a team when they log in. The SSO " +
+			"providers themselves, with their issuers and client credentials, are configured " +
+			"in the Marmot server's configuration and can't be managed through the API.",
+
+		Attributes: map[string]schema.Attribute{
+			"provider_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the SSO provider configured on the server, such as " +
+					"`okta` or `google`",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"sso_group_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the group in the identity provider",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"team_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the team the group's members join, such as `marmot_team.x.id`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"member_role": schema.StringAttribute{
+				MarkdownDescription: "Role the members get in the team, such as `member` or `owner`. " +
+					"Defaults to the server's default.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Mapping ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SSOTeamMappingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = data.api
+}
+
+func (r *SSOTeamMappingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data SSOTeamMappingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.api.createSSOTeamMapping(ctx, createSSOTeamMappingInput{
+		Provider:     data.Provider.ValueString(),
+		SSOGroupName: data.SSOGroupName.ValueString(),
+		TeamID:       data.TeamID.ValueString(),
+		MemberRole:   data.MemberRole.ValueString(),
+	})
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to create SSO team mapping", err)
+		return
+	}
+
+	if created.ID == "" {
+		resp.Diagnostics.AddError("API Error", "SSO team mapping created but no ID returned")
+		return
+	}
+
+	r.updateModelFromResponse(&data, created)
+
+	tflog.Info(ctx, "SSO team mapping created", map[string]any{
+		"id":             data.ID.ValueString(),
+		"provider":       data.Provider.ValueString(),
+		"sso_group_name": data.SSOGroupName.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SSOTeamMappingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data SSOTeamMappingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := r.api.getSSOTeamMapping(ctx, data.ID.ValueString())
+	if err != nil {
+		if marmot.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to read SSO team mapping", err)
+		return
+	}
+
+	r.updateModelFromResponse(&data, existing)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SSOTeamMappingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data SSOTeamMappingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated, err := r.api.updateSSOTeamMapping(ctx, data.ID.ValueString(), updateSSOTeamMappingInput{
+		TeamID:     data.TeamID.ValueString(),
+		MemberRole: data.MemberRole.ValueString(),
+	})
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to update SSO team mapping", err)
+		return
+	}
+
+	r.updateModelFromResponse(&data, updated)
+
+	tflog.Info(ctx, "SSO team mapping updated", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SSOTeamMappingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data SSOTeamMappingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.api.deleteSSOTeamMapping(ctx, data.ID.ValueString()); err != nil {
+		if marmot.IsNotFound(err) {
+			return
+		}
+		addClientError(ctx, &resp.Diagnostics, "Unable to delete SSO team mapping", err)
+		return
+	}
+
+	tflog.Info(ctx, "SSO team mapping deleted", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+}
+
+func (r *SSOTeamMappingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// updateModelFromResponse copies the mapping into model. Fields the response
+// leaves empty keep their planned value.
+func (r *SSOTeamMappingResource) updateModelFromResponse(model *SSOTeamMappingResourceModel, m *ssoTeamMapping) {
+	if m.ID != "" {
+		model.ID = types.StringValue(m.ID)
+	}
+	if m.Provider != "" {
+		model.Provider = types.StringValue(m.Provider)
+	}
+	if m.SSOGroupName != "" {
+		model.SSOGroupName = types.StringValue(m.SSOGroupName)
+	}
+	if m.TeamID != "" {
+		model.TeamID = types.StringValue(m.TeamID)
+	}
+	if m.MemberRole != "" {
+		model.MemberRole = types.StringValue(m.MemberRole)
+	} else if model.MemberRole.IsUnknown() {
+		model.MemberRole = types.StringNull()
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maxTagLength is the longest tag Marmot accepts, matching the validation on
+// marmot_asset's tags.
+const maxTagLength = 100
+
+// normalizeTag returns a tag in the form Marmot stores it: trimmed and
+// lowercased. Tags that are empty or too long once trimmed are rejected.
+func normalizeTag(tag string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(tag))
+	switch n := utf8.RuneCountInString(normalized); {
+	case n == 0:
+		return "", fmt.Errorf("tag %q is empty", tag)
+	case n > maxTagLength:
+		return "", fmt.Errorf("tag %q is %d characters long; tags can be at most %d", tag, n, maxTagLength)
+	}
+	return normalized, nil
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ function.Function = &NormalizeTagFunction{}
+	_ function.Function = &MergeTagsFunction{}
+)
+
+func NewNormalizeTagFunction() function.Function {
+	return &NormalizeTagFunction{}
+}
+
+// NormalizeTagFunction defines the normalize_tag function.
+type NormalizeTagFunction struct{}
+
+func (f *NormalizeTagFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_tag"
+}
+
+func (f *NormalizeTagFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalize a tag",
+		MarkdownDescription: fmt.Sprintf("Returns a tag trimmed and lowercased, the way Marmot stores it. "+
+			"Fails if the tag is empty or longer than %d characters once trimmed.", maxTagLength),
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "tag",
+				MarkdownDescription: "Tag to normalize",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeTagFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var tag string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &tag))
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := normalizeTag(tag)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid tag: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, normalized))
+}
+
+func NewMergeTagsFunction() function.Function {
+	return &MergeTagsFunction{}
+}
+
+// MergeTagsFunction defines the merge_tags function, which combines tag lists
+// from several sources, such as module variables, into one valid list.
+type MergeTagsFunction struct{}
+
+func (f *MergeTagsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "merge_tags"
+}
+
+func (f *MergeTagsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Merge and normalize tag lists",
+		MarkdownDescription: "Returns the tags from every list, each normalized as by `normalize_tag`, " +
+			"with duplicates removed and sorted. Fails if any tag is invalid.",
+		VariadicParameter: function.ListParameter{
+			Name:                "tags",
+			MarkdownDescription: "Lists of tags to merge",
+			ElementType:         types.StringType,
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *MergeTagsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var lists [][]string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &lists))
+	if resp.Error != nil {
+		return
+	}
+
+	seen := map[string]bool{}
+	merged := []string{}
+	for _, list := range lists {
+		for _, tag := range list {
+			normalized, err := normalizeTag(tag)
+			if err != nil {
+				resp.Error = function.NewArgumentFuncError(0, "Invalid tag: "+err.Error())
+				return
+			}
+			if !seen[normalized] {
+				seen[normalized] = true
+				merged = append(merged, normalized)
+			}
+		}
+	}
+	sort.Strings(merged)
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, merged))
+}
@@ -0,0 +1,366 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TeamOwnershipReportDataSource{}
+
+func NewTeamOwnershipReportDataSource() datasource.DataSource {
+	return &TeamOwnershipReportDataSource{}
+}
+
+// TeamOwnershipReportDataSource defines the data source implementation.
+type TeamOwnershipReportDataSource struct {
+	client *marmot.Client
+	api    *apiClient
+	assets *assetLookup
+}
+
+// TeamOwnershipReportDataSourceModel describes the team ownership report data
+// source data model.
+type TeamOwnershipReportDataSourceModel struct {
+	TeamID types.String `tfsdk:"team_id"`
+
+	TeamName                   types.String            `tfsdk:"team_name"`
+	DataProducts               []OwnedDataProductModel `tfsdk:"data_products"`
+	AssetCount                 types.Int64             `tfsdk:"asset_count"`
+	AssetCountsByType          types.Map               `tfsdk:"asset_counts_by_type"`
+	AssetsMissingDescription   types.Int64             `tfsdk:"assets_missing_description"`
+	AssetsMissingDocumentation types.Int64             `tfsdk:"assets_missing_documentation"`
+	Assets                     []OwnedAssetModel       `tfsdk:"assets"`
+}
+
+// OwnedDataProductModel describes one data product the team owns.
+type OwnedDataProductModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	AssetCount types.Int64  `tfsdk:"asset_count"`
+}
+
+// OwnedAssetModel describes one asset in a data product the team owns.
+type OwnedAssetModel struct {
+	ID                   types.String `tfsdk:"id"`
+	MRN                  types.String `tfsdk:"mrn"`
+	Name                 types.String `tfsdk:"name"`
+	Type                 types.String `tfsdk:"type"`
+	DataProductIDs       types.List   `tfsdk:"data_product_ids"`
+	MissingDescription   types.Bool   `tfsdk:"missing_description"`
+	MissingDocumentation types.Bool   `tfsdk:"missing_documentation"`
+}
+
+func (d *TeamOwnershipReportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_ownership_report"
+}
+
+func (d *TeamOwnershipReportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the assets a team owns, with counts by type and the assets " +
+			"missing a description or documentation, for per-team catalog scorecards.\n\n" +
+			"Marmot records ownership on data products rather than on individual assets, so a " +
+			"team owns the assets of every data product it is an owner of, whether they were " +
+			"added manually or matched by a rule.",
+
+		Attributes: map[string]schema.Attribute{
+			"team_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the team to report on",
+				Required:            true,
+			},
+			"team_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the team",
+				Computed:            true,
+			},
+			"data_products": schema.ListNestedAttribute{
+				MarkdownDescription: "Data products the team owns, ordered by name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Data product ID",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Data product name",
+							Computed:            true,
+						},
+						"asset_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of assets in the data product",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"asset_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of distinct assets the team owns",
+				Computed:            true,
+			},
+			"asset_counts_by_type": schema.MapAttribute{
+				MarkdownDescription: "Number of assets the team owns, keyed by asset type",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+			},
+			"assets_missing_description": schema.Int64Attribute{
+				MarkdownDescription: "Number of owned assets with neither a description nor a user description",
+				Computed:            true,
+			},
+			"assets_missing_documentation": schema.Int64Attribute{
+				MarkdownDescription: "Number of owned assets without a documentation page",
+				Computed:            true,
+			},
+			"assets": schema.ListNestedAttribute{
+				MarkdownDescription: "Assets the team owns, ordered by MRN",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Asset ID",
+							Computed:            true,
+						},
+						"mrn": schema.StringAttribute{
+							MarkdownDescription: "Marmot Resource Name",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Asset name",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Asset type",
+							Computed:            true,
+						},
+						"data_product_ids": schema.ListAttribute{
+							MarkdownDescription: "IDs of the team's data products the asset belongs to, sorted",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"missing_description": schema.BoolAttribute{
+							MarkdownDescription: "Whether the asset has neither a description nor a user description",
+							Computed:            true,
+						},
+						"missing_documentation": schema.BoolAttribute{
+							MarkdownDescription: "Whether the asset has no documentation page, or an empty one",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *TeamOwnershipReportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+	d.api = data.api
+	d.assets = data.assets
+}
+
+func (d *TeamOwnershipReportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data TeamOwnershipReportDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	teamID := data.TeamID.ValueString()
+
+	team, err := d.client.Teams.Get(ctx, teamID)
+	if marmot.IsNotFound(err) {
+		resp.Diagnostics.AddError("Team Not Found", fmt.Sprintf("No Marmot team has the ID %q.", teamID))
+		return
+	}
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to read team", err)
+		return
+	}
+
+	products, err := d.teamDataProducts(ctx, teamID)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to list data products", err)
+		return
+	}
+
+	// An asset can belong to several of the team's data products but is
+	// counted once.
+	productIDsByAsset := map[string][]string{}
+	productModels := make([]OwnedDataProductModel, 0, len(products))
+	for _, product := range products {
+		assetIDs, err := d.productAssetIDs(ctx, product.ID)
+		if err != nil {
+			addClientError(ctx, &resp.Diagnostics, fmt.Sprintf("Unable to list assets of data product %s", product.ID), err)
+			return
+		}
+		for _, id := range assetIDs {
+			productIDsByAsset[id] = append(productIDsByAsset[id], product.ID)
+		}
+		productModels = append(productModels, OwnedDataProductModel{
+			ID:         types.StringValue(product.ID),
+			Name:       types.StringValue(product.Name),
+			AssetCount: types.Int64Value(int64(len(assetIDs))),
+		})
+	}
+
+	countsByType := map[string]int64{}
+	var missingDescription, missingDocumentation int64
+	assetModels := make([]OwnedAssetModel, 0, len(productIDsByAsset))
+	for id, productIDs := range productIDsByAsset {
+		asset, err := d.assets.getByID(ctx, id)
+		if marmot.IsNotFound(err) {
+			// Deleted since the product's assets were listed.
+			continue
+		}
+		if err != nil {
+			addClientError(ctx, &resp.Diagnostics, fmt.Sprintf("Unable to read asset %s", id), err)
+			return
+		}
+
+		noDescription := strings.TrimSpace(asset.Description) == "" && strings.TrimSpace(asset.UserDescription) == ""
+		noDocumentation, err := d.missingDocumentation(ctx, asset.Mrn)
+		if err != nil {
+			addClientError(ctx, &resp.Diagnostics, fmt.Sprintf("Unable to read documentation of %s", asset.Mrn), err)
+			return
+		}
+
+		countsByType[asset.Type]++
+		if noDescription {
+			missingDescription++
+		}
+		if noDocumentation {
+			missingDocumentation++
+		}
+
+		sort.Strings(productIDs)
+		ids, diags := types.ListValueFrom(ctx, types.StringType, productIDs)
+		resp.Diagnostics.Append(diags...)
+
+		assetModels = append(assetModels, OwnedAssetModel{
+			ID:                   types.StringValue(asset.ID),
+			MRN:                  types.StringValue(asset.Mrn),
+			Name:                 types.StringValue(asset.Name),
+			Type:                 types.StringValue(asset.Type),
+			DataProductIDs:       ids,
+			MissingDescription:   types.BoolValue(noDescription),
+			MissingDocumentation: types.BoolValue(noDocumentation),
+		})
+	}
+	counts, diags := types.MapValueFrom(ctx, types.Int64Type, countsByType)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sort.Slice(assetModels, func(i, j int) bool {
+		return assetModels[i].MRN.ValueString() < assetModels[j].MRN.ValueString()
+	})
+
+	data.TeamName = types.StringValue(team.Name)
+	data.DataProducts = productModels
+	data.AssetCount = types.Int64Value(int64(len(assetModels)))
+	data.AssetCountsByType = counts
+	data.AssetsMissingDescription = types.Int64Value(missingDescription)
+	data.AssetsMissingDocumentation = types.Int64Value(missingDocumentation)
+	data.Assets = assetModels
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// teamDataProducts returns the data products that list the team as an
+// owner, ordered by name. The API can't filter products by owner, so every
+// product is listed.
+func (d *TeamOwnershipReportDataSource) teamDataProducts(ctx context.Context, teamID string) ([]*marmot.DataProduct, error) {
+	var owned []*marmot.DataProduct
+	var offset int64
+	for {
+		page, err := d.client.DataProducts.List(ctx, marmot.DataProductListOptions{
+			Limit:  searchDefaultLimit,
+			Offset: offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, product := range page.DataProducts {
+			if product != nil && productOwnedByTeam(product, teamID) {
+				owned = append(owned, product)
+			}
+		}
+		offset += int64(len(page.DataProducts))
+		if len(page.DataProducts) == 0 || offset >= page.Total {
+			break
+		}
+	}
+
+	sort.Slice(owned, func(i, j int) bool {
+		if owned[i].Name != owned[j].Name {
+			return owned[i].Name < owned[j].Name
+		}
+		return owned[i].ID < owned[j].ID
+	})
+	return owned, nil
+}
+
+func productOwnedByTeam(product *marmot.DataProduct, teamID string) bool {
+	for _, owner := range product.Owners {
+		if owner != nil && owner.Type == "team" && owner.ID == teamID {
+			return true
+		}
+	}
+	return false
+}
+
+// productAssetIDs returns the IDs of every asset in the data product, both
+// manually added and matched by its rules.
+func (d *TeamOwnershipReportDataSource) productAssetIDs(ctx context.Context, productID string) ([]string, error) {
+	var ids []string
+	for {
+		page, err := d.client.DataProducts.ResolvedAssets(ctx, productID, marmot.DataProductAssetsOptions{
+			Limit:  searchDefaultLimit,
+			Offset: int64(len(ids)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, page.AllAssets...)
+		if len(page.AllAssets) == 0 || int64(len(ids)) >= page.Total {
+			break
+		}
+	}
+	return ids, nil
+}
+
+// missingDocumentation reports whether the asset has no documentation page
+// or only a blank one.
+func (d *TeamOwnershipReportDataSource) missingDocumentation(ctx context.Context, mrn string) (bool, error) {
+	doc, err := d.api.getAssetDocumentation(ctx, mrn)
+	if marmot.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(doc.Content) == "", nil
+}
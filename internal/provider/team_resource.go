@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -105,19 +106,21 @@ func (r *TeamResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*marmot.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *marmot.Client, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func (r *TeamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data TeamResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -130,7 +133,7 @@ func (r *TeamResource) Create(ctx context.Context, req resource.CreateRequest, r
 		Description: data.Description.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create team: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to create team", err)
 		return
 	}
 
@@ -151,7 +154,7 @@ func (r *TeamResource) Create(ctx context.Context, req resource.CreateRequest, r
 			Metadata:    metadata,
 		})
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to apply team tags/metadata: %s", err))
+			addClientError(ctx, &resp.Diagnostics, "Unable to apply team tags/metadata", err)
 			return
 		}
 	}
@@ -167,6 +170,8 @@ func (r *TeamResource) Create(ctx context.Context, req resource.CreateRequest, r
 }
 
 func (r *TeamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data TeamResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -180,7 +185,7 @@ func (r *TeamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read team: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to read team", err)
 		return
 	}
 
@@ -194,6 +199,8 @@ func (r *TeamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *TeamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data TeamResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -214,7 +221,7 @@ func (r *TeamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		Metadata:    dataProductMetadata(data.Metadata),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update team: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to update team", err)
 		return
 	}
 
@@ -229,6 +236,8 @@ func (r *TeamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *TeamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data TeamResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -237,7 +246,7 @@ func (r *TeamResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	if err := r.client.Teams.Delete(ctx, data.ID.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete team: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to delete team", err)
 		return
 	}
 
@@ -285,15 +294,7 @@ func (r *TeamResource) updateModelFromResponse(ctx context.Context, model *TeamR
 		model.Tags = types.SetNull(types.StringType)
 	}
 
-	if metaMap, ok := team.Metadata.(map[string]any); ok && len(metaMap) > 0 {
-		strMap := make(map[string]string)
-		for k, v := range metaMap {
-			if strVal, ok := v.(string); ok {
-				strMap[k] = strVal
-			} else {
-				strMap[k] = fmt.Sprintf("%v", v)
-			}
-		}
+	if strMap := convert.StringMap(team.Metadata); strMap != nil {
 		metadata, diag := types.MapValueFrom(ctx, types.StringType, strMap)
 		diags.Append(diag...)
 		model.Metadata = metadata
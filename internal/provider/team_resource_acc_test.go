@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build acceptance
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/acctest"
+)
+
+func TestAccTeamResource(t *testing.T) {
+	name := acctest.RandomName("team")
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed(t, "marmot_team", func(ctx context.Context, client *marmot.Client, id string) error {
+			_, err := client.Teams.Get(ctx, id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamConfig(name, "Owns the reporting datasets"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("marmot_team.test", "name", name),
+					resource.TestCheckResourceAttr("marmot_team.test", "description", "Owns the reporting datasets"),
+					resource.TestCheckResourceAttr("marmot_team.test", "metadata.slack", "#analytics"),
+					resource.TestCheckResourceAttrSet("marmot_team.test", "id"),
+					testAccStoreID("marmot_team.test", &id),
+				),
+			},
+			{
+				Config: testAccTeamConfig(name, "Owns reporting and finance datasets"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("marmot_team.test", "description", "Owns reporting and finance datasets"),
+					resource.TestCheckResourceAttrPtr("marmot_team.test", "id", &id),
+				),
+			},
+			{
+				ResourceName:            "marmot_team.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"updated_at"},
+			},
+			testAccDriftStep(t, testAccTeamConfig(name, "Owns reporting and finance datasets"), func(ctx context.Context, client *marmot.Client) error {
+				_, err := client.Teams.Update(ctx, id, marmot.UpdateTeamInput{Name: name, Description: "Changed outside Terraform"})
+				return err
+			}),
+		},
+	})
+}
+
+func testAccTeamConfig(name, description string) string {
+	return testAccProviderConfig + fmt.Sprintf(`
+resource "marmot_team" "test" {
+  name        = %q
+  description = %q
+
+  tags = ["tfacc"]
+
+  metadata = {
+    slack = "#analytics"
+  }
+}
+`, name, description)
+}
@@ -0,0 +1,382 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/marmotdata/marmot/sdk/go/auth"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// transportOptions configures the HTTP client handed to the Marmot SDK.
+type transportOptions struct {
+	// MaxConcurrentRequests caps the number of requests in flight at once.
+	// Zero means no limit.
+	MaxConcurrentRequests int64
+
+	// RequestsPerSecond caps the request rate to the configured host. Zero
+	// means no limit.
+	RequestsPerSecond int64
+
+	// Headers are added to every request, replacing any value the SDK set.
+	Headers map[string]string
+
+	// ProxyURL, when set, is used instead of HTTP_PROXY and HTTPS_PROXY.
+	// NO_PROXY is still honoured. When nil the environment decides.
+	ProxyURL *url.URL
+
+	// MaxIdleConns is how many idle keep-alive connections are kept per
+	// host. Zero means defaultMaxIdleConns.
+	MaxIdleConns int
+
+	// IdleConnTimeout is how long an idle connection is kept open. Zero
+	// means defaultIdleConnTimeout.
+	IdleConnTimeout time.Duration
+
+	// BasePath is the API path prefix, stripped from request paths to find
+	// the model a response is checked against for unknown fields.
+	BasePath string
+
+	// FailOnUnknownFields fails responses with fields the SDK models don't
+	// have, rather than only logging them.
+	FailOnUnknownFields bool
+
+	// SecondaryAPIKey, when set, is tried on any request the primary
+	// credential gets a 401 for, and used for all later requests once it
+	// works.
+	SecondaryAPIKey string
+
+	// ChangeHeader is added to requests that change the catalog, to
+	// annotate them in Marmot's audit log.
+	ChangeHeader http.Header
+
+	// ReadOnly fails every request that could change the catalog before it
+	// is sent.
+	ReadOnly bool
+
+	// Retry configures how failed requests are retried.
+	Retry retryOptions
+}
+
+const (
+	// defaultMaxIdleConns replaces net/http's default of two idle
+	// connections per host, under which parallel applies keep opening and
+	// closing connections.
+	defaultMaxIdleConns = 16
+
+	defaultIdleConnTimeout = 90 * time.Second
+)
+
+// newHTTPClient builds the HTTP client used by the SDK, wrapping the default
+// transport with unknown field detection, error capture for diagnostics,
+// request logging, the secondary API key fallback, the extra and audit
+// headers, the rate limit, retries, the concurrency limit, and the read-only
+// guard from opts. Each retry is logged and rate limited like a new request,
+// and keeps its concurrency slot while it waits.
+func newHTTPClient(opts transportOptions) *http.Client {
+	var rt http.RoundTripper = newSchemaDriftTransport(sharedTransport(opts), opts.BasePath, opts.FailOnUnknownFields)
+	rt = &errorCaptureTransport{base: rt}
+
+	// Custom headers may carry proxy credentials, so their values are
+	// redacted from the logs too.
+	header := make(http.Header, len(opts.Headers))
+	for k, v := range opts.Headers {
+		header.Set(k, v)
+	}
+	headerNames := make([]string, 0, len(header))
+	for k := range header {
+		headerNames = append(headerNames, k)
+	}
+	rt = newLoggingTransport(rt, headerNames)
+
+	if opts.SecondaryAPIKey != "" {
+		rt = &secondaryKeyTransport{base: rt, key: opts.SecondaryAPIKey}
+	}
+
+	if len(header) > 0 {
+		rt = &headerTransport{base: rt, header: header}
+	}
+
+	if len(opts.ChangeHeader) > 0 {
+		rt = &changeHeaderTransport{base: rt, header: opts.ChangeHeader}
+	}
+
+	if opts.RequestsPerSecond > 0 {
+		rt = &rateLimitTransport{
+			base:     rt,
+			interval: time.Second / time.Duration(opts.RequestsPerSecond),
+		}
+	}
+	if opts.Retry.MaxRetries > 0 {
+		rt = newRetryTransport(rt, opts.Retry)
+	}
+	if opts.MaxConcurrentRequests > 0 {
+		rt = &concurrencyTransport{
+			base: rt,
+			sem:  make(chan struct{}, opts.MaxConcurrentRequests),
+		}
+	}
+	if opts.ReadOnly {
+		rt = &readOnlyTransport{base: rt}
+	}
+
+	return &http.Client{Transport: rt}
+}
+
+// transportKey identifies the connection pool settings of a transport.
+type transportKey struct {
+	proxyURL        string
+	maxIdleConns    int
+	idleConnTimeout time.Duration
+}
+
+var (
+	transportsMu sync.Mutex
+	transports   = map[transportKey]*http.Transport{}
+)
+
+// sharedTransport returns the transport for the pool and proxy settings in
+// opts. Provider configurations in the same plugin process with the same
+// settings, such as aliases for several catalogs, share one transport and so
+// one pool of keep-alive connections to each host.
+func sharedTransport(opts transportOptions) *http.Transport {
+	key := transportKey{
+		maxIdleConns:    opts.MaxIdleConns,
+		idleConnTimeout: opts.IdleConnTimeout,
+	}
+	if key.maxIdleConns == 0 {
+		key.maxIdleConns = defaultMaxIdleConns
+	}
+	if key.idleConnTimeout == 0 {
+		key.idleConnTimeout = defaultIdleConnTimeout
+	}
+	if opts.ProxyURL != nil {
+		key.proxyURL = opts.ProxyURL.String()
+	}
+
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+	if t, ok := transports[key]; ok {
+		return t
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConnsPerHost = key.maxIdleConns
+	if t.MaxIdleConns < key.maxIdleConns {
+		t.MaxIdleConns = key.maxIdleConns
+	}
+	t.IdleConnTimeout = key.idleConnTimeout
+	if opts.ProxyURL != nil {
+		t.Proxy = proxyFunc(opts.ProxyURL)
+	}
+	transports[key] = t
+	return t
+}
+
+// proxyFunc sends requests through proxyURL, except for hosts listed in
+// NO_PROXY.
+func proxyFunc(proxyURL *url.URL) func(*http.Request) (*url.URL, error) {
+	noProxy := os.Getenv("NO_PROXY")
+	if noProxy == "" {
+		noProxy = os.Getenv("no_proxy")
+	}
+	fn := (&httpproxy.Config{
+		HTTPProxy:  proxyURL.String(),
+		HTTPSProxy: proxyURL.String(),
+		NoProxy:    noProxy,
+	}).ProxyFunc()
+
+	return func(req *http.Request) (*url.URL, error) {
+		return fn(req.URL)
+	}
+}
+
+// headerTransport sets fixed headers on every request, for proxies and
+// gateways in front of Marmot that expect their own headers.
+type headerTransport struct {
+	base   http.RoundTripper
+	header http.Header
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for k, v := range t.header {
+		req.Header[k] = v
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// changeHeaderTransport sets fixed headers on requests that change the
+// catalog, leaving reads untouched.
+type changeHeaderTransport struct {
+	base   http.RoundTripper
+	header http.Header
+}
+
+func (t *changeHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.base.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	for k, v := range t.header {
+		req.Header[k] = v
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// readOnlySafePaths are the POST endpoints that only check a request and
+// never save it, so read_only still lets validate_on_plan use them.
+var readOnlySafePaths = []string{"/ingestion/validate", "/products/rule-preview"}
+
+// errReadOnly is returned for requests refused by readOnlyTransport.
+var errReadOnly = errors.New("the provider is read-only (read_only or MARMOT_READ_ONLY is set)")
+
+// readOnlyTransport fails requests that could change the catalog without
+// sending them, for provider configurations with read_only set.
+type readOnlyTransport struct {
+	base http.RoundTripper
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.base.RoundTrip(req)
+	case http.MethodPost:
+		for _, p := range readOnlySafePaths {
+			if strings.HasSuffix(req.URL.Path, p) {
+				return t.base.RoundTrip(req)
+			}
+		}
+	}
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, fmt.Errorf("%w, so %s %s was not sent", errReadOnly, req.Method, req.URL.Path)
+}
+
+// secondaryKeyTransport retries requests rejected with 401 using a second
+// API key, so a run keeps working while the primary key is being rotated.
+// Once the secondary key is accepted it is used for every later request.
+type secondaryKeyTransport struct {
+	base http.RoundTripper
+	key  string
+
+	switched atomic.Bool
+}
+
+func (t *secondaryKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.switched.Load() {
+		return t.base.RoundTrip(t.withKey(req))
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// The request can only be sent again if its body can be replayed.
+	retry := t.withKey(req)
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return resp, nil
+		}
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	resp, err = t.base.RoundTrip(retry)
+	if err == nil && resp.StatusCode != http.StatusUnauthorized && t.switched.CompareAndSwap(false, true) {
+		tflog.Warn(req.Context(), "Marmot rejected the primary credential; using api_key_secondary from now on", map[string]interface{}{
+			"http_method": req.Method,
+			"http_path":   req.URL.Path,
+		})
+	}
+	return resp, err
+}
+
+// withKey returns a copy of req authenticated with the secondary key instead
+// of the primary credential.
+func (t *secondaryKeyTransport) withKey(req *http.Request) *http.Request {
+	req = req.Clone(req.Context())
+	req.Header.Del("Authorization")
+	req.Header.Set(string(auth.SchemeAPIKey), t.key)
+	return req
+}
+
+// concurrencyTransport limits the number of requests in flight. A request
+// waits for a free slot until its context is cancelled.
+type concurrencyTransport struct {
+	base http.RoundTripper
+	sem  chan struct{}
+}
+
+func (t *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.sem <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	defer func() { <-t.sem }()
+
+	return t.base.RoundTrip(req)
+}
+
+// rateLimitTransport spaces requests at least interval apart. Slots are
+// reserved in arrival order, so a burst of requests is spread out evenly
+// rather than released all at once.
+type rateLimitTransport struct {
+	base     http.RoundTripper
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if wait := t.reserve(); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// reserve claims the next free slot and returns how long to wait for it.
+func (t *rateLimitTransport) reserve() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if t.next.Before(now) {
+		t.next = now
+	}
+	wait := t.next.Sub(now)
+	t.next = t.next.Add(t.interval)
+	return wait
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+	"github.com/marmotdata/terraform-provider-marmot/internal/convert"
+)
+
+// Helpers shared by the resources that register one kind of asset, such as
+// marmot_kafka_topic_asset, in the shape its ingestion plugin produces.
+
+// typedAssetUpdate returns an update that writes the given fields over the
+// asset as it currently is. The API only offers a full replace, so whatever
+// the resource doesn't set, including fields ingestion wrote, is carried
+// over rather than cleared. A null description or tags set keeps the
+// server's value.
+func typedAssetUpdate(ctx context.Context, current *marmot.Asset, description markdownValue, tags types.Set, metadata map[string]any, schemas map[string]string, diags *diag.Diagnostics) marmot.UpdateAssetInput {
+	input := marmot.UpdateAssetInput{
+		Name:            current.Name,
+		Type:            current.Type,
+		Description:     current.Description,
+		UserDescription: current.UserDescription,
+		Providers:       current.Providers,
+		Tags:            current.Tags,
+		Metadata:        map[string]any{},
+		Schema:          map[string]string{},
+		ExternalLinks:   current.ExternalLinks,
+		Sources:         current.Sources,
+		Environments:    current.Environments,
+	}
+	if !description.IsNull() {
+		input.Description = description.ValueString()
+	}
+	if !tags.IsNull() {
+		input.Tags = setStrings(ctx, tags, diags)
+	}
+	for k, v := range convert.Object(current.Metadata) {
+		input.Metadata[k] = v
+	}
+	for k, v := range metadata {
+		input.Metadata[k] = v
+	}
+	for k, v := range current.Schema {
+		input.Schema[k] = v
+	}
+	for k, v := range schemas {
+		input.Schema[k] = v
+	}
+	return input
+}
+
+// typedAssetDescriptionAndTags reads the asset's description and tags. With
+// ignoreLabelCase, tags keep their spelling in prior where they only differ
+// in case.
+func typedAssetDescriptionAndTags(ctx context.Context, asset *marmot.Asset, prior types.Set, ignoreLabelCase bool, diags *diag.Diagnostics) (markdownValue, types.Set) {
+	description := newMarkdownNull()
+	if asset.Description != "" {
+		description = newMarkdownValue(asset.Description)
+	}
+
+	tags := types.SetNull(types.StringType)
+	if len(asset.Tags) > 0 {
+		var d diag.Diagnostics
+		tags, d = types.SetValueFrom(ctx, types.StringType, asset.Tags)
+		diags.Append(d...)
+	}
+	if ignoreLabelCase {
+		tags = keepLabelCase(ctx, tags, prior, diags)
+	}
+	return description, tags
+}
+
+// addTypedAssetExistsError reports that creating an asset conflicted with
+// an existing one, typically registered by ingestion, and how to import it.
+func addTypedAssetExistsError(ctx context.Context, client *marmot.Client, diags *diag.Diagnostics, kind string, key marmot.LookupInput) {
+	id := "<asset ID>"
+	if existing, err := client.Assets.Lookup(ctx, key); err == nil && existing != nil {
+		id = existing.ID
+	}
+	diags.AddError(
+		"Asset Already Exists",
+		fmt.Sprintf("A Marmot asset for the %s %q already exists, typically registered by ingestion. "+
+			"Import it to manage it from Terraform:\n\n"+
+			"  terraform import <address> %s", kind, key.Name, id),
+	)
+}
+
+// metadataString returns the metadata value under key as a string, or null
+// when it is missing.
+func metadataString(meta map[string]interface{}, key string) types.String {
+	raw, ok := meta[key]
+	if !ok || raw == nil {
+		return types.StringNull()
+	}
+	if s, ok := raw.(string); ok {
+		return types.StringValue(s)
+	}
+	return types.StringValue(fmt.Sprintf("%v", raw))
+}
+
+// metadataInt64 returns the metadata value under key as an integer, or null
+// when it is missing or isn't a whole number. Ingestion writes numbers, but
+// values set by hand may be strings.
+func metadataInt64(meta map[string]interface{}, key string) types.Int64 {
+	switch v := meta[key].(type) {
+	case float64:
+		if v == float64(int64(v)) {
+			return types.Int64Value(int64(v))
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return types.Int64Value(n)
+		}
+	case string:
+		if n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+			return types.Int64Value(n)
+		}
+	}
+	return types.Int64Null()
+}
+
+// metadataStrings returns the metadata list under key as strings, or null
+// when it is missing or isn't a list.
+func metadataStrings(ctx context.Context, meta map[string]interface{}, key string, diags *diag.Diagnostics) types.List {
+	raw := convert.Array(meta[key])
+	if raw == nil {
+		return types.ListNull(types.StringType)
+	}
+	values := make([]string, 0, len(raw))
+	for _, v := range raw {
+		values = append(values, fmt.Sprintf("%v", v))
+	}
+	list, d := types.ListValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
+	return list
+}
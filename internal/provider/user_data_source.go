@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UserDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &UserDataSource{}
+
+func NewUserDataSource() datasource.DataSource {
+	return &UserDataSource{}
+}
+
+// UserDataSource defines the data source implementation.
+type UserDataSource struct {
+	client *marmot.Client
+}
+
+// UserDataSourceModel describes the user data source data model.
+type UserDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Username       types.String `tfsdk:"username"`
+	Email          types.String `tfsdk:"email"`
+	Name           types.String `tfsdk:"name"`
+	RoleNames      types.Set    `tfsdk:"role_names"`
+	ProfilePicture types.String `tfsdk:"profile_picture"`
+	Active         types.Bool   `tfsdk:"active"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
+}
+
+func (d *UserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a single Marmot user by `id`, `username`, or `email`, so owners " +
+			"can be referenced without hard-coding user IDs. Exactly one of the three must be set.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "User ID",
+				Optional:            true,
+				Computed:            true,
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Login username",
+				Optional:            true,
+				Computed:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email address from one of the user's linked identities. Matched " +
+					"case-insensitively, and kept as configured. When the user is found by `id` or " +
+					"`username`, the address of the first linked identity.",
+				Optional: true,
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Display name of the user",
+				Computed:            true,
+			},
+			"role_names": schema.SetAttribute{
+				MarkdownDescription: "Names of the roles assigned to the user",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"profile_picture": schema.StringAttribute{
+				MarkdownDescription: "URL of the user's profile picture",
+				Computed:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user account is active",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Creation timestamp",
+				Computed:            true,
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "Last update timestamp",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *UserDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("username"),
+			path.MatchRoot("email"),
+		),
+	}
+}
+
+func (d *UserDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data UserDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var user *marmot.User
+	var err error
+	switch {
+	case !data.ID.IsNull():
+		user, err = d.client.Users.Get(ctx, data.ID.ValueString())
+	case !data.Username.IsNull():
+		username := data.Username.ValueString()
+		user, err = findUser(ctx, d.client, username, func(u *marmot.User) bool {
+			return u.Username == username
+		})
+	default:
+		email := data.Email.ValueString()
+		user, err = findUser(ctx, d.client, "", func(u *marmot.User) bool {
+			return userHasEmail(u, email)
+		})
+	}
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Unable to read user", err)
+		return
+	}
+	if user == nil {
+		resp.Diagnostics.AddError("User Not Found", "No Marmot user matches the given username or email.")
+		return
+	}
+
+	data.ID = types.StringValue(user.ID)
+	data.Username = types.StringValue(user.Username)
+	// A configured email is the lookup key; keep it as written rather than
+	// replacing it with the first identity's address, which may differ in
+	// case or belong to another identity.
+	if data.Email.IsNull() {
+		data.Email = types.StringValue(userEmail(user))
+	}
+	data.Name = types.StringValue(user.Name)
+	data.RoleNames = userRoleNameSet(ctx, user, &resp.Diagnostics)
+	data.ProfilePicture = types.StringValue(user.ProfilePicture)
+	data.Active = types.BoolValue(user.Active)
+	data.CreatedAt = types.StringValue(user.CreatedAt)
+	data.UpdatedAt = types.StringValue(user.UpdatedAt)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findUser pages through the users matching query and returns the first one
+// for which match reports true, or nil when none does.
+func findUser(ctx context.Context, client *marmot.Client, query string, match func(*marmot.User) bool) (*marmot.User, error) {
+	const pageSize = 100
+	var offset int64
+	for {
+		page, err := client.Users.List(ctx, marmot.UsersListOptions{
+			Query:  query,
+			Limit:  pageSize,
+			Offset: offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range page.Users {
+			if user != nil && match(user) {
+				return user, nil
+			}
+		}
+		offset += int64(len(page.Users))
+		if len(page.Users) == 0 || offset >= page.Total {
+			return nil, nil
+		}
+	}
+}
+
+// userHasEmail reports whether any of the user's linked identities carries
+// email, ignoring case.
+func userHasEmail(user *marmot.User, email string) bool {
+	for _, identity := range user.Identities {
+		if identity != nil && strings.EqualFold(identity.ProviderEmail, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// userEmail returns the first email address found on the user's linked
+// identities. Local accounts have none.
+func userEmail(user *marmot.User) string {
+	for _, identity := range user.Identities {
+		if identity != nil && identity.ProviderEmail != "" {
+			return identity.ProviderEmail
+		}
+	}
+	return ""
+}
+
+// userRoleNameSet builds a sorted set of the user's role names, returning an
+// empty set when the user has no roles.
+func userRoleNameSet(ctx context.Context, user *marmot.User, diags *diag.Diagnostics) types.Set {
+	set, d := types.SetValueFrom(ctx, types.StringType, roleNamesOf(user))
+	diags.Append(d...)
+	return set
+}
@@ -128,19 +128,21 @@ func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*marmot.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *marmot.Client, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data UserResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -154,6 +156,7 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withSensitiveLogValues(ctx, password.ValueString())
 
 	user, err := r.client.Users.Create(ctx, marmot.CreateUserInput{
 		Name:           data.Name.ValueString(),
@@ -163,7 +166,7 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		ProfilePicture: data.ProfilePicture.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create user: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to create user", err)
 		return
 	}
 
@@ -187,6 +190,8 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 }
 
 func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data UserResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -200,7 +205,7 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to read user", err)
 		return
 	}
 
@@ -214,6 +219,8 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data UserResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -238,6 +245,7 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		}
 		password = pw.ValueString()
 	}
+	ctx = withSensitiveLogValues(ctx, password)
 
 	user, err := r.client.Users.Update(ctx, state.ID.ValueString(), marmot.UpdateUserInput{
 		Name:           data.Name.ValueString(),
@@ -246,7 +254,7 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		ProfilePicture: data.ProfilePicture.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update user: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to update user", err)
 		return
 	}
 
@@ -265,6 +273,8 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
 	var data UserResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -273,7 +283,7 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	if err := r.client.Users.Delete(ctx, data.ID.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete user: %s", err))
+		addClientError(ctx, &resp.Diagnostics, "Unable to delete user", err)
 		return
 	}
 
@@ -0,0 +1,189 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	marmot "github.com/marmotdata/marmot/sdk/go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UsersDataSource{}
+
+func NewUsersDataSource() datasource.DataSource {
+	return &UsersDataSource{}
+}
+
+// UsersDataSource defines the data source implementation.
+type UsersDataSource struct {
+	client *marmot.Client
+}
+
+// UsersDataSourceModel describes the users data source data model.
+type UsersDataSourceModel struct {
+	Query  types.String `tfsdk:"query"`
+	Active types.Bool   `tfsdk:"active"`
+	Users  types.List   `tfsdk:"users"`
+	IDs    types.List   `tfsdk:"ids"`
+}
+
+var usersDataSourceUserAttrTypes = map[string]attr.Type{
+	"id":         types.StringType,
+	"username":   types.StringType,
+	"email":      types.StringType,
+	"name":       types.StringType,
+	"role_names": types.SetType{ElemType: types.StringType},
+	"active":     types.BoolType,
+}
+
+func (d *UsersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *UsersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists Marmot users, optionally filtered by a search query and active status.",
+
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				MarkdownDescription: "Search query matched against usernames and names",
+				Optional:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Only return users with this active status",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the matching users, in the order returned by the API",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"users": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching users",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "User ID",
+							Computed:            true,
+						},
+						"username": schema.StringAttribute{
+							MarkdownDescription: "Login username",
+							Computed:            true,
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "Email address from the user's linked identities, if any",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Display name of the user",
+							Computed:            true,
+						},
+						"role_names": schema.SetAttribute{
+							MarkdownDescription: "Names of the roles assigned to the user",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"active": schema.BoolAttribute{
+							MarkdownDescription: "Whether the user account is active",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *UsersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withAPIErrorCapture(ctx)
+
+	var data UsersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := marmot.UsersListOptions{
+		Query: data.Query.ValueString(),
+		Limit: 100,
+	}
+	if !data.Active.IsNull() {
+		active := data.Active.ValueBool()
+		opts.Active = &active
+	}
+
+	var users []*marmot.User
+	for {
+		page, err := d.client.Users.List(ctx, opts)
+		if err != nil {
+			addClientError(ctx, &resp.Diagnostics, "Unable to list users", err)
+			return
+		}
+		for _, user := range page.Users {
+			if user != nil {
+				users = append(users, user)
+			}
+		}
+		opts.Offset += int64(len(page.Users))
+		if len(page.Users) == 0 || opts.Offset >= page.Total {
+			break
+		}
+	}
+
+	userType := types.ObjectType{AttrTypes: usersDataSourceUserAttrTypes}
+	ids := make([]string, 0, len(users))
+	elems := make([]attr.Value, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+
+		obj, diags := types.ObjectValue(usersDataSourceUserAttrTypes, map[string]attr.Value{
+			"id":         types.StringValue(user.ID),
+			"username":   types.StringValue(user.Username),
+			"email":      types.StringValue(userEmail(user)),
+			"name":       types.StringValue(user.Name),
+			"role_names": userRoleNameSet(ctx, user, &resp.Diagnostics),
+			"active":     types.BoolValue(user.Active),
+		})
+		resp.Diagnostics.Append(diags...)
+		elems = append(elems, obj)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	list, diags := types.ListValue(userType, elems)
+	resp.Diagnostics.Append(diags...)
+	data.Users = list
+
+	idList, diags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	data.IDs = idList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// mrnPrefix starts every Marmot Resource Name.
+const mrnPrefix = "mrn://"
+
+// parseMRN splits a Marmot Resource Name of the form
+// mrn://<type>/<service>/<name> into its parts. The name may itself contain
+// slashes.
+func parseMRN(mrn string) (assetType, service, name string, err error) {
+	rest, ok := strings.CutPrefix(mrn, mrnPrefix)
+	if !ok {
+		return "", "", "", fmt.Errorf("must start with %q", mrnPrefix)
+	}
+
+	parts := strings.SplitN(rest, "/", 3)
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("must have the form %s<type>/<service>/<name>", mrnPrefix)
+	}
+	for i, label := range []string{"type", "service", "name"} {
+		if parts[i] == "" {
+			return "", "", "", fmt.Errorf("has an empty %s segment", label)
+		}
+		if strings.TrimSpace(parts[i]) != parts[i] {
+			return "", "", "", fmt.Errorf("has leading or trailing whitespace in its %s segment", label)
+		}
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+var _ validator.String = mrnValidator{}
+
+// mrnValidator checks that a string is a well-formed Marmot Resource Name, so
+// malformed identifiers fail at validate time rather than as a 400 on apply.
+type mrnValidator struct{}
+
+// isMRN returns a validator for Marmot Resource Name attributes.
+func isMRN() validator.String {
+	return mrnValidator{}
+}
+
+func (v mrnValidator) Description(ctx context.Context) string {
+	return "value must be a Marmot Resource Name of the form mrn://<type>/<service>/<name>"
+}
+
+func (v mrnValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a Marmot Resource Name of the form `mrn://<type>/<service>/<name>`"
+}
+
+func (v mrnValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if _, _, _, err := parseMRN(value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid MRN",
+			fmt.Sprintf("%q is not a valid Marmot Resource Name: it %s.", value, err),
+		)
+	}
+}
+
+var _ validator.String = durationValidator{}
+
+// durationValidator checks that a string parses as a positive Go duration.
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration such as 30m or 1h30m"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a positive duration such as `30m` or `1h30m`"
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("%q is not a positive duration such as 30m or 1h30m.", value),
+		)
+	}
+}
+
+var _ validator.String = linkURLValidator{}
+
+// linkURLValidator checks that an external link is an absolute http or https
+// URL once its placeholders are expanded.
+type linkURLValidator struct{}
+
+func (v linkURLValidator) Description(ctx context.Context) string {
+	return "value must be an absolute http or https URL, optionally using the {{mrn}} and {{name}} placeholders"
+}
+
+func (v linkURLValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be an absolute `http` or `https` URL, optionally using the `{{mrn}}` and `{{name}}` placeholders"
+}
+
+func (v linkURLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	u, err := url.Parse(expandLinkURL(value, "mrn://type/service/name", "name"))
+	switch {
+	case err != nil:
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid URL", fmt.Sprintf("%q is not a valid URL: %s.", value, err))
+	case u.Scheme == "" || u.Host == "":
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid URL", fmt.Sprintf("%q is not an absolute URL such as https://example.com/path.", value))
+	case u.Scheme != "http" && u.Scheme != "https":
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid URL", fmt.Sprintf("%q uses the %s scheme; external links must use http or https.", value, u.Scheme))
+	}
+}
+
+var _ validator.String = timestampValidator{}
+
+// timestampValidator checks that a string is an RFC 3339 timestamp.
+type timestampValidator struct{}
+
+func (v timestampValidator) Description(ctx context.Context) string {
+	return "value must be an RFC 3339 timestamp such as 2026-01-31T18:00:00Z"
+}
+
+func (v timestampValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be an RFC 3339 timestamp such as `2026-01-31T18:00:00Z`"
+}
+
+func (v timestampValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			fmt.Sprintf("%q is not an RFC 3339 timestamp such as 2026-01-31T18:00:00Z.", value),
+		)
+	}
+}